@@ -0,0 +1,57 @@
+package arena
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Arena hands out values carved from chunks of chunkSize elements, turning
+// one allocation per value into one per chunk. Nothing is freed
+// individually: a chunk stays alive while any value in it is referenced,
+// and Reset lets the garbage collector reclaim every chunk at once.
+type Arena[T any] struct {
+	chunkSize int
+	current   []T
+	chunks    int
+	allocated int
+}
+
+func New[T any](chunkSize int) *Arena[T] {
+	if chunkSize <= 0 {
+		msg := fmt.Sprintf("arena: invalid chunk size: %d", chunkSize)
+		panic(errors.New(msg))
+	}
+
+	return &Arena[T]{chunkSize: chunkSize}
+}
+
+// New returns a pointer to a zero value owned by the arena
+func (a *Arena[T]) New() *T {
+	if len(a.current) == 0 {
+		a.current = make([]T, a.chunkSize)
+		a.chunks++
+	}
+
+	value := &a.current[0]
+	a.current = a.current[1:]
+	a.allocated++
+
+	return value
+}
+
+// Len is the number of values handed out since the last Reset
+func (a *Arena[T]) Len() int {
+	return a.allocated
+}
+
+func (a *Arena[T]) Chunks() int {
+	return a.chunks
+}
+
+// Reset drops the arena's reference to its chunks. Values handed out before
+// stay valid for as long as they are referenced.
+func (a *Arena[T]) Reset() {
+	a.current = nil
+	a.chunks = 0
+	a.allocated = 0
+}
@@ -0,0 +1,78 @@
+package arena
+
+import "testing"
+
+type node struct {
+	value int
+	next  *node
+}
+
+func TestNewHandsOutDistinctZeroValues(t *testing.T) {
+	a := New[node](4)
+
+	var head *node
+
+	for i := 0; i < 10; i++ {
+		n := a.New()
+
+		if n.value != 0 || n.next != nil {
+			t.Errorf("Expected a zero value, got %+v", *n)
+		}
+
+		n.value = i
+		n.next = head
+		head = n
+	}
+
+	for i := 9; i >= 0; i-- {
+		if head.value != i {
+			t.Errorf("Expected value to be %d, got %d", i, head.value)
+		}
+
+		head = head.next
+	}
+
+	if a.Len() != 10 || a.Chunks() != 3 {
+		t.Errorf("Expected 10 values in 3 chunks, got %d in %d", a.Len(), a.Chunks())
+	}
+}
+
+func TestAllocatesOncePerChunk(t *testing.T) {
+	a := New[node](100)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		for i := 0; i < 100; i++ {
+			a.New()
+		}
+	})
+
+	if allocs != 1 {
+		t.Errorf("Expected one allocation per chunk, got %v", allocs)
+	}
+}
+
+func TestResetKeepsOutstandingValues(t *testing.T) {
+	a := New[node](2)
+	n := a.New()
+	n.value = 42
+
+	a.Reset()
+
+	if a.Len() != 0 || a.Chunks() != 0 {
+		t.Errorf("Expected an empty arena, got %d values in %d chunks", a.Len(), a.Chunks())
+	}
+
+	if m := a.New(); m == n || n.value != 42 {
+		t.Errorf("Expected a fresh chunk after Reset")
+	}
+}
+
+func TestNewPanicsOnInvalidChunkSize(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	New[node](0)
+}
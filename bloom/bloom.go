@@ -0,0 +1,194 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"algorithms/hashtable"
+)
+
+var errCorrupt = errors.New("bloom: corrupt filter")
+
+// Filter is a Bloom filter: a membership test that never misses an added key
+// but may report a key that was never added. Every key sets k bits of an
+// m-bit array chosen by double hashing, h1 + i*h2 for i < k, both derived
+// from a single 64-bit hash of the key.
+type Filter[K any] struct {
+	words  []uint64
+	bits   uint64
+	hashes int
+	count  uint64
+	hasher hashtable.Hasher[K]
+}
+
+// New sizes a filter for n keys at false-positive rate p, hashing keys like
+// a HashTable does
+func New[K any](n uint64, p float64) *Filter[K] {
+	return NewWithHasher(n, p, hashtable.DefaultHasher[K]())
+}
+
+func NewWithHasher[K any](n uint64, p float64, hasher hashtable.Hasher[K]) *Filter[K] {
+	if n == 0 {
+		msg := fmt.Sprintf("invalid expected number of keys: %d", n)
+		panic(errors.New(msg))
+	}
+
+	if !(p > 0 && p < 1) {
+		msg := fmt.Sprintf("invalid false-positive rate: %v", p)
+		panic(errors.New(msg))
+	}
+
+	bits, hashes := Parameters(n, p)
+
+	return &Filter[K]{
+		words:  make([]uint64, (bits+63)/64),
+		bits:   bits,
+		hashes: hashes,
+		hasher: hasher,
+	}
+}
+
+// Parameters returns the optimal number of bits, -n ln p / ln² 2, and of
+// hashes, (m/n) ln 2, for n keys at false-positive rate p
+func Parameters(n uint64, p float64) (bits uint64, hashes int) {
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	bits = max(uint64(m), 64)
+	hashes = max(int(math.Round(float64(bits)/float64(n)*math.Ln2)), 1)
+
+	return
+}
+
+func (f *Filter[K]) locations(key K) (h1, h2 uint64) {
+	hash := f.hasher.Hash(key)
+	h1 = hashtable.IntHash(hash)
+	h2 = hashtable.IntHash(h1^hash) | 1
+
+	return
+}
+
+func (f *Filter[K]) Add(key K) {
+	h1, h2 := f.locations(key)
+
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.bits
+		f.words[bit/64] |= 1 << (bit % 64)
+	}
+
+	f.count++
+}
+
+func (f *Filter[K]) MayContain(key K) bool {
+	h1, h2 := f.locations(key)
+
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.bits
+
+		if f.words[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Count is the number of calls to Add, duplicates included
+func (f *Filter[K]) Count() uint64 {
+	return f.count
+}
+
+func (f *Filter[K]) Bits() uint64 {
+	return f.bits
+}
+
+func (f *Filter[K]) Hashes() int {
+	return f.hashes
+}
+
+// FalsePositiveRate estimates the current rate from the fraction of bits set
+func (f *Filter[K]) FalsePositiveRate() float64 {
+	set := 0
+
+	for _, word := range f.words {
+		for ; word != 0; word &= word - 1 {
+			set++
+		}
+	}
+
+	return math.Pow(float64(set)/float64(f.bits), float64(f.hashes))
+}
+
+// Union adds every key of other, which must have the same size and hashes
+func (f *Filter[K]) Union(other *Filter[K]) error {
+	if f.bits != other.bits || f.hashes != other.hashes {
+		msg := fmt.Sprintf("incompatible filters: %d bits and %d hashes, %d bits and %d hashes", f.bits, f.hashes, other.bits, other.hashes)
+		return errors.New(msg)
+	}
+
+	for i, word := range other.words {
+		f.words[i] |= word
+	}
+
+	f.count += other.count
+
+	return nil
+}
+
+func (f *Filter[K]) Clear() {
+	clear(f.words)
+	f.count = 0
+}
+
+// MarshalBinary encodes the bits and parameters but not the hasher, so the
+// filter must be restored with the hasher it was built with
+func (f *Filter[K]) MarshalBinary() ([]byte, error) {
+	buffer := make([]byte, 0, 3*binary.MaxVarintLen64+8*len(f.words))
+
+	buffer = binary.AppendUvarint(buffer, f.bits)
+	buffer = binary.AppendUvarint(buffer, uint64(f.hashes))
+	buffer = binary.AppendUvarint(buffer, f.count)
+
+	for _, word := range f.words {
+		buffer = binary.LittleEndian.AppendUint64(buffer, word)
+	}
+
+	return buffer, nil
+}
+
+// UnmarshalBinary restores a filter, keeping its hasher or using the default
+// one on a zero Filter
+func (f *Filter[K]) UnmarshalBinary(buffer []byte) error {
+	var fields [3]uint64
+
+	for i := range fields {
+		value, n := binary.Uvarint(buffer)
+
+		if n <= 0 {
+			return errCorrupt
+		}
+
+		fields[i] = value
+		buffer = buffer[n:]
+	}
+
+	bits, hashes, count := fields[0], fields[1], fields[2]
+
+	if bits == 0 || hashes == 0 || hashes > math.MaxInt32 || uint64(len(buffer)) != (bits+63)/64*8 {
+		return errCorrupt
+	}
+
+	words := make([]uint64, len(buffer)/8)
+
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(buffer[8*i:])
+	}
+
+	if f.hasher == nil {
+		f.hasher = hashtable.DefaultHasher[K]()
+	}
+
+	f.words, f.bits, f.hashes, f.count = words, bits, int(hashes), count
+
+	return nil
+}
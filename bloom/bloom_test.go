@@ -0,0 +1,139 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+
+	"algorithms/hashtable"
+)
+
+func TestParameters(t *testing.T) {
+	bits, hashes := Parameters(1000, 0.01)
+
+	if bits != 9586 || hashes != 7 {
+		t.Errorf("Expected 9586 bits and 7 hashes, got %d and %d", bits, hashes)
+	}
+}
+
+func TestNoFalseNegatives(t *testing.T) {
+	f := New[string](1000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		f.Add(fmt.Sprintf("key-%d", i))
+	}
+
+	for i := 0; i < 1000; i++ {
+		if !f.MayContain(fmt.Sprintf("key-%d", i)) {
+			t.Fatalf("Expected key-%d to be reported", i)
+		}
+	}
+
+	if f.Count() != 1000 {
+		t.Errorf("Expected count to be 1000, got %d", f.Count())
+	}
+}
+
+func TestFalsePositiveRate(t *testing.T) {
+	f := New[int](10000, 0.01)
+
+	for i := 0; i < 10000; i++ {
+		f.Add(i)
+	}
+
+	positives := 0
+
+	for i := 10000; i < 110000; i++ {
+		if f.MayContain(i) {
+			positives++
+		}
+	}
+
+	if rate := float64(positives) / 100000; rate > 0.02 {
+		t.Errorf("Expected false-positive rate near 0.01, got %v", rate)
+	}
+
+	if estimate := f.FalsePositiveRate(); estimate < 0.005 || estimate > 0.02 {
+		t.Errorf("Expected estimated rate near 0.01, got %v", estimate)
+	}
+}
+
+func TestInvalidParameters(t *testing.T) {
+	for _, p := range []float64{0, 1, -0.5} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			New[int](10, p)
+		}()
+	}
+}
+
+func TestUnionAndClear(t *testing.T) {
+	a, b := New[int](100, 0.01), New[int](100, 0.01)
+	a.Add(1)
+	b.Add(2)
+
+	if err := a.Union(b); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !a.MayContain(1) || !a.MayContain(2) || a.Count() != 2 {
+		t.Errorf("Expected the union to hold both keys")
+	}
+
+	if err := a.Union(New[int](1000, 0.01)); err == nil {
+		t.Errorf("Expected an error for incompatible filters")
+	}
+
+	a.Clear()
+
+	if a.MayContain(1) || a.Count() != 0 {
+		t.Errorf("Expected an empty filter after Clear")
+	}
+}
+
+func TestCustomHasher(t *testing.T) {
+	hasher := hashtable.HasherFunc[[]byte](func(key []byte) uint64 {
+		return hashtable.StringHash(string(key))
+	})
+
+	f := NewWithHasher[[]byte](10, 0.01, hasher)
+	f.Add([]byte("abc"))
+
+	if !f.MayContain([]byte("abc")) {
+		t.Errorf("Expected abc to be reported")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	f := New[string](100, 0.01)
+
+	for i := 0; i < 100; i++ {
+		f.Add(fmt.Sprint(i))
+	}
+
+	data, _ := f.MarshalBinary()
+
+	var restored Filter[string]
+
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if restored.Bits() != f.Bits() || restored.Hashes() != f.Hashes() || restored.Count() != 100 {
+		t.Errorf("Expected parameters to survive a round trip")
+	}
+
+	for i := 0; i < 100; i++ {
+		if !restored.MayContain(fmt.Sprint(i)) {
+			t.Fatalf("Expected %d to be reported after a round trip", i)
+		}
+	}
+
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("Expected an error for truncated data")
+	}
+}
@@ -0,0 +1,92 @@
+package bloom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"algorithms/hashtable"
+	"algorithms/internal/stream"
+)
+
+// WriteTo streams the filter in the format of MarshalBinary without
+// encoding it whole first
+func (f *Filter[K]) WriteTo(w io.Writer) (int64, error) {
+	return stream.Write(w, [3]uint64{f.bits, uint64(f.hashes), f.count}, f.words)
+}
+
+// ReadFrom replaces the filter with one written by WriteTo or MarshalBinary,
+// reading no further than its end. Like UnmarshalBinary it keeps the hasher,
+// or uses the default one on a zero Filter, and leaves the filter unchanged
+// on error.
+func (f *Filter[K]) ReadFrom(r io.Reader) (int64, error) {
+	source := stream.NewReader(r)
+	bits, hashes, count, err := readHeader(source)
+
+	if err != nil {
+		return source.Consumed(), err
+	}
+
+	words := make([]uint64, 0, min((bits+63)/64, stream.ChunkWords))
+
+	err = source.Words((bits+63)/64, func(_ int, word uint64) {
+		words = append(words, word)
+	})
+
+	if err != nil {
+		return source.Consumed(), err
+	}
+
+	if f.hasher == nil {
+		f.hasher = hashtable.DefaultHasher[K]()
+	}
+
+	f.words, f.bits, f.hashes, f.count = words, bits, hashes, count
+
+	return source.Consumed(), nil
+}
+
+// MergeFrom unions the filter read from r into this one as it streams in,
+// with the same requirements as Union. If r fails partway the filter may be
+// left with part of the other filter's bits, but never with bits it did not
+// hold or receive.
+func (f *Filter[K]) MergeFrom(r io.Reader) error {
+	source := stream.NewReader(r)
+	bits, hashes, count, err := readHeader(source)
+
+	if err != nil {
+		return err
+	}
+
+	if f.bits != bits || f.hashes != hashes {
+		msg := fmt.Sprintf("incompatible filters: %d bits and %d hashes, %d bits and %d hashes", f.bits, f.hashes, bits, hashes)
+		return errors.New(msg)
+	}
+
+	err = source.Words(uint64(len(f.words)), func(i int, word uint64) {
+		f.words[i] |= word
+	})
+
+	if err != nil {
+		return err
+	}
+
+	f.count += count
+
+	return nil
+}
+
+func readHeader(source *stream.Reader) (bits uint64, hashes int, count uint64, err error) {
+	fields, err := source.Header()
+
+	if err != nil {
+		return
+	}
+
+	if fields[0] == 0 || fields[1] == 0 || fields[1] > math.MaxInt32 {
+		return 0, 0, 0, errCorrupt
+	}
+
+	return fields[0], int(fields[1]), fields[2], nil
+}
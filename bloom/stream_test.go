@@ -0,0 +1,93 @@
+package bloom
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	f := New[string](10000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		f.Add(fmt.Sprint(i))
+	}
+
+	var buffer bytes.Buffer
+
+	written, err := f.WriteTo(&buffer)
+
+	if err != nil || written != int64(buffer.Len()) {
+		t.Fatalf("Expected %d bytes written without error, got %d and %v", buffer.Len(), written, err)
+	}
+
+	if data, _ := f.MarshalBinary(); !bytes.Equal(data, buffer.Bytes()) {
+		t.Errorf("Expected WriteTo to match MarshalBinary")
+	}
+
+	// A trailing byte must be left for whoever reads next
+	buffer.WriteByte(42)
+	size := int64(buffer.Len())
+
+	var restored Filter[string]
+
+	read, err := restored.ReadFrom(&buffer)
+
+	if err != nil || read != size-1 || buffer.Len() != 1 {
+		t.Fatalf("Expected %d bytes read without error, got %d and %v", size-1, read, err)
+	}
+
+	if restored.Bits() != f.Bits() || restored.Hashes() != f.Hashes() || restored.Count() != 1000 {
+		t.Errorf("Expected parameters to survive a round trip")
+	}
+
+	for i := 0; i < 1000; i++ {
+		if !restored.MayContain(fmt.Sprint(i)) {
+			t.Fatalf("Expected %d to be reported after a round trip", i)
+		}
+	}
+
+	data, _ := f.MarshalBinary()
+
+	if _, err := restored.ReadFrom(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF for truncated data, got %v", err)
+	}
+
+	if restored.Count() != 1000 {
+		t.Errorf("Expected a failed read to leave the filter unchanged")
+	}
+}
+
+func TestMergeFrom(t *testing.T) {
+	a, b := New[int](10000, 0.01), New[int](10000, 0.01)
+
+	for i := 0; i < 500; i++ {
+		a.Add(i)
+		b.Add(i + 500)
+	}
+
+	var buffer bytes.Buffer
+
+	b.WriteTo(&buffer)
+
+	if err := a.MergeFrom(&buffer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if !a.MayContain(i) {
+			t.Fatalf("Expected %d to be reported after a merge", i)
+		}
+	}
+
+	if a.Count() != 1000 {
+		t.Errorf("Expected count to be 1000, got %d", a.Count())
+	}
+
+	New[int](100, 0.01).WriteTo(&buffer)
+
+	if err := a.MergeFrom(&buffer); err == nil {
+		t.Errorf("Expected an error for incompatible filters")
+	}
+}
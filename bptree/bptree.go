@@ -0,0 +1,452 @@
+package bptree
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type Storage interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+type syncer interface {
+	Sync() error
+}
+
+const (
+	DefaultPageSize  = 4096
+	DefaultCacheSize = 256
+)
+
+// Tree is a B+-tree whose nodes live in fixed-size pages of a Storage. Page 0
+// holds the metadata, every other page holds a single node. Deleted entries
+// are removed from their leaf but nodes are never merged.
+type Tree struct {
+	storage   Storage
+	pageSize  int
+	root      uint64
+	pageCount uint64
+	count     uint64
+	cacheSize int
+	pages     map[uint64]*list.Element
+	recency   *list.List
+	buffer    []byte
+}
+
+func Create(storage Storage, pageSize int) (*Tree, error) {
+	if pageSize < 128 || pageSize > 1<<16 {
+		msg := fmt.Sprintf("invalid page size: %d", pageSize)
+		return nil, errors.New(msg)
+	}
+
+	t := newTree(storage, pageSize)
+
+	root := t.allocate(true)
+	t.root = root.id
+
+	if err := t.Flush(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func Open(storage Storage) (*Tree, error) {
+	meta := make([]byte, metaSize)
+
+	if _, err := storage.ReadAt(meta, 0); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(meta[:4], magic[:]) {
+		return nil, errors.New("not a bptree file")
+	}
+
+	pageSize := int(binary.LittleEndian.Uint32(meta[4:]))
+
+	if pageSize < 128 || pageSize > 1<<16 {
+		msg := fmt.Sprintf("invalid page size: %d", pageSize)
+		return nil, errors.New(msg)
+	}
+
+	t := newTree(storage, pageSize)
+	t.root = binary.LittleEndian.Uint64(meta[8:])
+	t.pageCount = binary.LittleEndian.Uint64(meta[16:])
+	t.count = binary.LittleEndian.Uint64(meta[24:])
+
+	return t, nil
+}
+
+func newTree(storage Storage, pageSize int) *Tree {
+	return &Tree{
+		storage:   storage,
+		pageSize:  pageSize,
+		pageCount: 1,
+		cacheSize: DefaultCacheSize,
+		pages:     make(map[uint64]*list.Element),
+		recency:   list.New(),
+		buffer:    make([]byte, pageSize),
+	}
+}
+
+func (t *Tree) SetCacheSize(pages int) {
+	if pages < 1 {
+		pages = 1
+	}
+
+	t.cacheSize = pages
+}
+
+func (t *Tree) Len() uint64 {
+	return t.count
+}
+
+func (t *Tree) PageSize() int {
+	return t.pageSize
+}
+
+func (t *Tree) Get(key []byte) (value []byte, found bool, err error) {
+	defer t.trimCache(&err)
+
+	leaf, err := t.findLeaf(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	i, found := search(leaf.keys, key)
+	if !found {
+		return nil, false, nil
+	}
+
+	return clone(leaf.values[i]), true, nil
+}
+
+func (t *Tree) Put(key, value []byte) (err error) {
+	if 2+len(key)+2+len(value) > t.maxEntrySize() {
+		msg := fmt.Sprintf("entry too large: %d bytes, max is %d", len(key)+len(value), t.maxEntrySize()-4)
+		return errors.New(msg)
+	}
+
+	defer t.trimCache(&err)
+
+	split, separator, right, err := t.insert(t.root, key, value)
+	if err != nil || !split {
+		return err
+	}
+
+	root := t.allocate(false)
+	root.keys = [][]byte{separator}
+	root.children = []uint64{t.root, right}
+	t.root = root.id
+
+	return nil
+}
+
+func (t *Tree) Delete(key []byte) (deleted bool, err error) {
+	defer t.trimCache(&err)
+
+	leaf, err := t.findLeaf(key)
+	if err != nil {
+		return false, err
+	}
+
+	i, found := search(leaf.keys, key)
+	if !found {
+		return false, nil
+	}
+
+	leaf.keys = append(leaf.keys[:i], leaf.keys[i+1:]...)
+	leaf.values = append(leaf.values[:i], leaf.values[i+1:]...)
+	leaf.dirty = true
+	t.count--
+
+	return true, nil
+}
+
+// Scan calls f for every entry with a key greater than or equal to start, in
+// key order, until f returns false. The key and value belong to the tree:
+// f must copy them to keep or modify them. Only the current leaf is needed
+// as the scan moves on, so the cache is trimmed after every leaf loaded.
+func (t *Tree) Scan(start []byte, f func(key, value []byte) bool) (err error) {
+	defer t.trimCache(&err)
+
+	leaf, err := t.findLeaf(start)
+	if err != nil {
+		return err
+	}
+
+	i := sort.Search(len(leaf.keys), func(i int) bool {
+		return bytes.Compare(leaf.keys[i], start) >= 0
+	})
+
+	for {
+		for ; i < len(leaf.keys); i++ {
+			if !f(leaf.keys[i], leaf.values[i]) {
+				return nil
+			}
+		}
+
+		if leaf.next == nullPage {
+			return nil
+		}
+
+		if leaf, err = t.load(leaf.next); err != nil {
+			return err
+		}
+
+		if t.trimCache(&err); err != nil {
+			return err
+		}
+
+		i = 0
+	}
+}
+
+func (t *Tree) ScanPrefix(prefix []byte, f func(key, value []byte) bool) error {
+	return t.Scan(prefix, func(key, value []byte) bool {
+		return bytes.HasPrefix(key, prefix) && f(key, value)
+	})
+}
+
+func (t *Tree) Flush() error {
+	for element := t.recency.Front(); element != nil; element = element.Next() {
+		if err := t.write(element.Value.(*node)); err != nil {
+			return err
+		}
+	}
+
+	meta := make([]byte, metaSize)
+	copy(meta, magic[:])
+	binary.LittleEndian.PutUint32(meta[4:], uint32(t.pageSize))
+	binary.LittleEndian.PutUint64(meta[8:], t.root)
+	binary.LittleEndian.PutUint64(meta[16:], t.pageCount)
+	binary.LittleEndian.PutUint64(meta[24:], t.count)
+
+	if _, err := t.storage.WriteAt(meta, 0); err != nil {
+		return err
+	}
+
+	if s, ok := t.storage.(syncer); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
+
+func (t *Tree) maxEntrySize() int {
+	// Guarantee that both halves of a split fit in a page
+	return (t.pageSize - nodeHeaderSize - 8) / 4
+}
+
+func (t *Tree) insert(id uint64, key, value []byte) (split bool, separator []byte, right uint64, err error) {
+	n, err := t.load(id)
+	if err != nil {
+		return
+	}
+
+	i, found := search(n.keys, key)
+
+	if n.leaf {
+		if found {
+			n.values[i] = clone(value)
+		} else {
+			n.keys = insertAt(n.keys, i, clone(key))
+			n.values = insertAt(n.values, i, clone(value))
+			t.count++
+		}
+
+		n.dirty = true
+	} else {
+		if found {
+			i++
+		}
+
+		var childSplit bool
+		var childSeparator []byte
+		var childRight uint64
+
+		childSplit, childSeparator, childRight, err = t.insert(n.children[i], key, value)
+		if err != nil || !childSplit {
+			return
+		}
+
+		n.keys = insertAt(n.keys, i, childSeparator)
+		n.children = insertAt(n.children, i+1, childRight)
+		n.dirty = true
+	}
+
+	if n.encodedSize() <= t.pageSize {
+		return
+	}
+
+	sibling, separator := t.split(n)
+
+	return true, separator, sibling.id, nil
+}
+
+func (t *Tree) split(n *node) (sibling *node, separator []byte) {
+	sibling = t.allocate(n.leaf)
+	mid := t.splitPoint(n)
+
+	if n.leaf {
+		sibling.keys = append(sibling.keys, n.keys[mid:]...)
+		sibling.values = append(sibling.values, n.values[mid:]...)
+		sibling.next = n.next
+
+		n.keys = n.keys[:mid:mid]
+		n.values = n.values[:mid:mid]
+		n.next = sibling.id
+
+		separator = clone(sibling.keys[0])
+	} else {
+		separator = n.keys[mid]
+
+		sibling.keys = append(sibling.keys, n.keys[mid+1:]...)
+		sibling.children = append(sibling.children, n.children[mid+1:]...)
+
+		n.keys = n.keys[:mid:mid]
+		n.children = n.children[: mid+1 : mid+1]
+	}
+
+	n.dirty = true
+
+	return
+}
+
+// splitPoint balances the encoded bytes rather than the number of keys
+func (t *Tree) splitPoint(n *node) int {
+	half := n.encodedSize() / 2
+	size := nodeHeaderSize
+
+	for i, key := range n.keys {
+		size += 2 + len(key)
+
+		if n.leaf {
+			size += 2 + len(n.values[i])
+		} else {
+			size += 8
+		}
+
+		if size >= half && i > 0 {
+			return i
+		}
+	}
+
+	return len(n.keys) / 2
+}
+
+func (t *Tree) findLeaf(key []byte) (*node, error) {
+	n, err := t.load(t.root)
+
+	for err == nil && !n.leaf {
+		i, found := search(n.keys, key)
+		if found {
+			i++
+		}
+
+		n, err = t.load(n.children[i])
+	}
+
+	return n, err
+}
+
+func (t *Tree) allocate(leaf bool) *node {
+	n := &node{
+		id:    t.pageCount,
+		leaf:  leaf,
+		dirty: true,
+	}
+
+	t.pageCount++
+	t.cache(n)
+
+	return n
+}
+
+func (t *Tree) load(id uint64) (*node, error) {
+	if element, ok := t.pages[id]; ok {
+		t.recency.MoveToFront(element)
+		return element.Value.(*node), nil
+	}
+
+	if id == metaPage || id >= t.pageCount {
+		msg := fmt.Sprintf("page out of range: %d", id)
+		return nil, errors.New(msg)
+	}
+
+	if _, err := t.storage.ReadAt(t.buffer, int64(id)*int64(t.pageSize)); err != nil {
+		return nil, err
+	}
+
+	n, err := decodeNode(id, t.buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	t.cache(n)
+
+	return n, nil
+}
+
+func (t *Tree) cache(n *node) {
+	t.pages[n.id] = t.recency.PushFront(n)
+}
+
+func (t *Tree) write(n *node) error {
+	if !n.dirty {
+		return nil
+	}
+
+	n.encode(t.buffer)
+
+	if _, err := t.storage.WriteAt(t.buffer, int64(n.id)*int64(t.pageSize)); err != nil {
+		return err
+	}
+
+	n.dirty = false
+
+	return nil
+}
+
+// Pages are only evicted between operations so nodes on the current path stay valid
+func (t *Tree) trimCache(err *error) {
+	for *err == nil && t.recency.Len() > t.cacheSize {
+		element := t.recency.Back()
+		n := element.Value.(*node)
+
+		if *err = t.write(n); *err != nil {
+			return
+		}
+
+		t.recency.Remove(element)
+		delete(t.pages, n.id)
+	}
+}
+
+func search(keys [][]byte, key []byte) (int, bool) {
+	i := sort.Search(len(keys), func(i int) bool {
+		return bytes.Compare(keys[i], key) >= 0
+	})
+
+	return i, i < len(keys) && bytes.Equal(keys[i], key)
+}
+
+func insertAt[E any](elements []E, i int, element E) []E {
+	var zero E
+
+	elements = append(elements, zero)
+	copy(elements[i+1:], elements[i:])
+	elements[i] = element
+
+	return elements
+}
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
@@ -0,0 +1,240 @@
+package bptree
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createTree(t *testing.T, pageSize int) (*Tree, *os.File) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	t.Cleanup(func() { file.Close() })
+
+	tree, err := Create(file, pageSize)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	return tree, file
+}
+
+func key(i int) []byte {
+	return []byte(fmt.Sprintf("key-%06d", i))
+}
+
+func TestPutAndGet(t *testing.T) {
+	tree, _ := createTree(t, 256)
+	tree.SetCacheSize(4)
+
+	for _, i := range rand.New(rand.NewSource(1)).Perm(2000) {
+		if err := tree.Put(key(i), []byte(fmt.Sprint(i))); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+	}
+
+	if tree.Len() != 2000 {
+		t.Errorf("Expected length to be 2000, got %d", tree.Len())
+	}
+
+	for i := 0; i < 2000; i++ {
+		value, found, err := tree.Get(key(i))
+
+		if err != nil || !found || string(value) != fmt.Sprint(i) {
+			t.Fatalf("Expected value to be %d, got %s (found %v, err %v)", i, value, found, err)
+		}
+	}
+
+	if _, found, _ := tree.Get([]byte("missing")); found {
+		t.Errorf("Expected missing key to not be found")
+	}
+}
+
+func TestPutOverwritesValue(t *testing.T) {
+	tree, _ := createTree(t, DefaultPageSize)
+
+	tree.Put([]byte("foo"), []byte("bar"))
+	tree.Put([]byte("foo"), []byte("baz"))
+
+	value, _, _ := tree.Get([]byte("foo"))
+
+	if string(value) != "baz" {
+		t.Errorf("Expected value to be 'baz', got %s", value)
+	}
+
+	if tree.Len() != 1 {
+		t.Errorf("Expected length to be 1, got %d", tree.Len())
+	}
+}
+
+func TestScanReturnsKeysInOrder(t *testing.T) {
+	tree, _ := createTree(t, 256)
+
+	for _, i := range rand.New(rand.NewSource(2)).Perm(500) {
+		tree.Put(key(i), nil)
+	}
+
+	var previous []byte
+	counter := 0
+
+	tree.Scan(key(100), func(k, _ []byte) bool {
+		if previous != nil && bytes.Compare(previous, k) >= 0 {
+			t.Errorf("Expected %s to come after %s", k, previous)
+		}
+
+		previous = k
+		counter++
+
+		return counter < 50
+	})
+
+	if counter != 50 {
+		t.Errorf("Expected counter to be 50, got %d", counter)
+	}
+
+	if !bytes.Equal(previous, key(149)) {
+		t.Errorf("Expected last key to be %s, got %s", key(149), previous)
+	}
+}
+
+func TestScanKeepsTheCacheBounded(t *testing.T) {
+	tree, _ := createTree(t, 256)
+	tree.SetCacheSize(4)
+
+	for i := 0; i < 2000; i++ {
+		tree.Put(key(i), []byte(fmt.Sprint(i)))
+	}
+
+	cached, counter := 0, 0
+
+	err := tree.Scan(nil, func(_, _ []byte) bool {
+		cached = max(cached, tree.recency.Len())
+		counter++
+
+		return true
+	})
+
+	if err != nil || counter != 2000 {
+		t.Fatalf("Expected 2000 entries without error, got %d and %v", counter, err)
+	}
+
+	// The path to the first leaf may exceed the bound until the next leaf
+	if cached > 8 {
+		t.Errorf("Expected at most 8 cached pages during the scan, got %d", cached)
+	}
+}
+
+func TestGetReturnsACopy(t *testing.T) {
+	tree, _ := createTree(t, DefaultPageSize)
+
+	tree.Put([]byte("foo"), []byte("bar"))
+
+	value, _, _ := tree.Get([]byte("foo"))
+	value[0] = 'c'
+
+	if value, _, _ := tree.Get([]byte("foo")); string(value) != "bar" {
+		t.Errorf("Expected value to be 'bar', got %s", value)
+	}
+}
+
+func TestScanPrefix(t *testing.T) {
+	tree, _ := createTree(t, 256)
+
+	for _, k := range []string{"app/a", "app/b", "apple", "bin/x", "app/c", "ap"} {
+		tree.Put([]byte(k), nil)
+	}
+
+	keys := make([]string, 0)
+
+	tree.ScanPrefix([]byte("app/"), func(k, _ []byte) bool {
+		keys = append(keys, string(k))
+		return true
+	})
+
+	if fmt.Sprint(keys) != "[app/a app/b app/c]" {
+		t.Errorf("Expected [app/a app/b app/c], got %v", keys)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tree, _ := createTree(t, 256)
+
+	for i := 0; i < 300; i++ {
+		tree.Put(key(i), []byte("value"))
+	}
+
+	for i := 0; i < 300; i += 2 {
+		if deleted, _ := tree.Delete(key(i)); !deleted {
+			t.Errorf("Expected %s to be deleted", key(i))
+		}
+	}
+
+	if deleted, _ := tree.Delete(key(0)); deleted {
+		t.Errorf("Expected second delete to report nothing removed")
+	}
+
+	counter := 0
+
+	tree.Scan(nil, func(k, _ []byte) bool {
+		counter++
+		return true
+	})
+
+	if counter != 150 || tree.Len() != 150 {
+		t.Errorf("Expected 150 entries, got %d (len %d)", counter, tree.Len())
+	}
+}
+
+func TestReopenPersistedTree(t *testing.T) {
+	tree, file := createTree(t, 512)
+
+	for i := 0; i < 1000; i++ {
+		tree.Put(key(i), []byte(fmt.Sprint(i*i)))
+	}
+
+	if err := tree.Flush(); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	reopened, err := Open(file)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if reopened.Len() != 1000 {
+		t.Errorf("Expected length to be 1000, got %d", reopened.Len())
+	}
+
+	for i := 0; i < 1000; i++ {
+		value, found, _ := reopened.Get(key(i))
+
+		if !found || string(value) != fmt.Sprint(i*i) {
+			t.Fatalf("Expected value to be %d, got %s", i*i, value)
+		}
+	}
+}
+
+func TestRejectsOversizedEntries(t *testing.T) {
+	tree, _ := createTree(t, 256)
+
+	if err := tree.Put(make([]byte, 200), nil); err == nil {
+		t.Errorf("Expected error for oversized entry")
+	}
+}
+
+func TestOpenRejectsForeignFiles(t *testing.T) {
+	file, _ := os.Create(filepath.Join(t.TempDir(), "other"))
+	defer file.Close()
+
+	file.Write(make([]byte, 64))
+
+	if _, err := Open(file); err == nil {
+		t.Errorf("Expected error when opening a file without the magic header")
+	}
+}
@@ -0,0 +1,167 @@
+package bptree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	metaPage   uint64 = 0
+	nullPage   uint64 = 0
+	leafPage   byte   = 1
+	branchPage byte   = 2
+
+	// kind, key count and next leaf pointer
+	nodeHeaderSize = 1 + 2 + 8
+	// magic, page size, root, page count and entry count
+	metaSize = 4 + 4 + 8 + 8 + 8
+)
+
+var magic = [4]byte{'B', 'P', 'T', '1'}
+
+type node struct {
+	id       uint64
+	leaf     bool
+	keys     [][]byte
+	values   [][]byte
+	children []uint64
+	next     uint64
+	dirty    bool
+}
+
+func (n *node) encodedSize() int {
+	size := nodeHeaderSize
+
+	for _, key := range n.keys {
+		size += 2 + len(key)
+	}
+
+	if n.leaf {
+		for _, value := range n.values {
+			size += 2 + len(value)
+		}
+	} else {
+		size += 8 * len(n.children)
+	}
+
+	return size
+}
+
+func (n *node) encode(page []byte) {
+	kind := branchPage
+	if n.leaf {
+		kind = leafPage
+	}
+
+	page[0] = kind
+	binary.LittleEndian.PutUint16(page[1:], uint16(len(n.keys)))
+	binary.LittleEndian.PutUint64(page[3:], n.next)
+
+	offset := nodeHeaderSize
+
+	putBytes := func(b []byte) {
+		binary.LittleEndian.PutUint16(page[offset:], uint16(len(b)))
+		offset += 2
+		offset += copy(page[offset:], b)
+	}
+
+	if n.leaf {
+		for i, key := range n.keys {
+			putBytes(key)
+			putBytes(n.values[i])
+		}
+	} else {
+		for _, child := range n.children {
+			binary.LittleEndian.PutUint64(page[offset:], child)
+			offset += 8
+		}
+
+		for _, key := range n.keys {
+			putBytes(key)
+		}
+	}
+
+	// Zero the tail so stale bytes never reach the file
+	for i := offset; i < len(page); i++ {
+		page[i] = 0
+	}
+}
+
+func decodeNode(id uint64, page []byte) (*node, error) {
+	if len(page) < nodeHeaderSize {
+		return nil, errors.New("page too small")
+	}
+
+	n := node{id: id}
+
+	switch page[0] {
+	case leafPage:
+		n.leaf = true
+	case branchPage:
+		n.leaf = false
+	default:
+		msg := fmt.Sprintf("corrupted page %d: unknown kind %d", id, page[0])
+		return nil, errors.New(msg)
+	}
+
+	count := int(binary.LittleEndian.Uint16(page[1:]))
+	n.next = binary.LittleEndian.Uint64(page[3:])
+
+	offset := nodeHeaderSize
+	var err error
+
+	getBytes := func() []byte {
+		if err != nil || offset+2 > len(page) {
+			err = errors.New(fmt.Sprintf("corrupted page %d: truncated entry", id))
+			return nil
+		}
+
+		length := int(binary.LittleEndian.Uint16(page[offset:]))
+		offset += 2
+
+		if offset+length > len(page) {
+			err = errors.New(fmt.Sprintf("corrupted page %d: truncated entry", id))
+			return nil
+		}
+
+		b := make([]byte, length)
+		copy(b, page[offset:offset+length])
+		offset += length
+
+		return b
+	}
+
+	n.keys = make([][]byte, 0, count)
+
+	if n.leaf {
+		n.values = make([][]byte, 0, count)
+
+		for i := 0; i < count; i++ {
+			n.keys = append(n.keys, getBytes())
+			n.values = append(n.values, getBytes())
+		}
+	} else {
+		if offset+8*(count+1) > len(page) {
+			msg := fmt.Sprintf("corrupted page %d: truncated children", id)
+			return nil, errors.New(msg)
+		}
+
+		n.children = make([]uint64, count+1)
+
+		for i := range n.children {
+			n.children[i] = binary.LittleEndian.Uint64(page[offset:])
+			offset += 8
+		}
+
+		for i := 0; i < count; i++ {
+			n.keys = append(n.keys, getBytes())
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/hashtable"
+)
+
+// CostLRU bounds the total cost of its entries, as measured by a user cost
+// function such as their size in bytes, rather than their number. Inserting
+// evicts least recently used entries until the total fits the budget again.
+type CostLRU[K comparable, V any] struct {
+	budget  uint64
+	total   uint64
+	cost    func(K, V) uint64
+	table   *hashtable.HashTable[K, *entry[K, V]]
+	recency recencyList[K, V]
+	onEvict func(K, V)
+}
+
+func NewCostLRU[K comparable, V any](budget uint64, cost func(K, V) uint64) *CostLRU[K, V] {
+	if budget == 0 {
+		msg := fmt.Sprintf("invalid budget: %d", budget)
+		panic(errors.New(msg))
+	}
+
+	c := CostLRU[K, V]{
+		budget: budget,
+		cost:   cost,
+		table:  hashtable.NewHashTable[K, *entry[K, V]](),
+	}
+
+	c.recency.init()
+
+	return &c
+}
+
+// OnEvict registers a callback invoked for entries dropped to make room
+func (c *CostLRU[K, V]) OnEvict(f func(K, V)) {
+	c.onEvict = f
+}
+
+func (c *CostLRU[K, V]) Get(key K) (value V, found bool) {
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	c.recency.moveToFront(e)
+
+	return e.value, true
+}
+
+// Peek reads a value without marking it as recently used
+func (c *CostLRU[K, V]) Peek(key K) (value V, found bool) {
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	return e.value, true
+}
+
+// Put stores value and evicts until the cache fits its budget. An entry
+// costing more than the whole budget is rejected, and replaces nothing: Put
+// returns false and removes any previous value of key.
+func (c *CostLRU[K, V]) Put(key K, value V) bool {
+	cost := c.cost(key, value)
+
+	if cost > c.budget {
+		c.Remove(key)
+		return false
+	}
+
+	if e, found := c.table.TryGet(key); found {
+		c.total = c.total - e.cost + cost
+		e.value, e.cost = value, cost
+		c.recency.moveToFront(e)
+	} else {
+		e := &entry[K, V]{key: key, value: value, cost: cost}
+		c.table.Insert(key, e)
+		c.recency.pushFront(e)
+		c.total += cost
+	}
+
+	c.evict()
+
+	return true
+}
+
+func (c *CostLRU[K, V]) Remove(key K) bool {
+	e, found := c.table.Delete(key)
+
+	if !found {
+		return false
+	}
+
+	c.recency.unlink(e)
+	c.total -= e.cost
+
+	return true
+}
+
+// CostOf returns the cost recorded for key when it was stored
+func (c *CostLRU[K, V]) CostOf(key K) (uint64, bool) {
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return 0, false
+	}
+
+	return e.cost, true
+}
+
+func (c *CostLRU[K, V]) Len() int {
+	return int(c.table.Size())
+}
+
+// Cost is the total cost of the entries
+func (c *CostLRU[K, V]) Cost() uint64 {
+	return c.total
+}
+
+func (c *CostLRU[K, V]) Budget() uint64 {
+	return c.budget
+}
+
+// SetBudget changes the budget, evicting at once when it shrinks
+func (c *CostLRU[K, V]) SetBudget(budget uint64) {
+	if budget == 0 {
+		msg := fmt.Sprintf("invalid budget: %d", budget)
+		panic(errors.New(msg))
+	}
+
+	c.budget = budget
+	c.evict()
+}
+
+func (c *CostLRU[K, V]) evict() {
+	for c.total > c.budget {
+		oldest := c.recency.back()
+
+		c.recency.unlink(oldest)
+		c.table.Delete(oldest.key)
+		c.total -= oldest.cost
+
+		if c.onEvict != nil {
+			c.onEvict(oldest.key, oldest.value)
+		}
+	}
+}
+
+// Dump exports the entries from least to most recently used. Costs are not
+// exported since Warm recomputes them with the cost function.
+func (c *CostLRU[K, V]) Dump() []Entry[K, V] {
+	return c.recency.appendOldestFirst(make([]Entry[K, V], 0, c.Len()))
+}
+
+// Warm puts the entries in order, the last one becoming the most recently
+// used, evicting to fit the budget as Put does
+func (c *CostLRU[K, V]) Warm(entries []Entry[K, V]) {
+	for _, e := range entries {
+		c.Put(e.Key, e.Value)
+	}
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"testing"
+)
+
+func byteCost(_ string, value []byte) uint64 {
+	return uint64(len(value))
+}
+
+func TestCostLRUEvictsUntilUnderBudget(t *testing.T) {
+	c := NewCostLRU[string, []byte](10, byteCost)
+	evicted := make([]string, 0)
+
+	c.OnEvict(func(key string, _ []byte) {
+		evicted = append(evicted, key)
+	})
+
+	c.Put("a", make([]byte, 4))
+	c.Put("b", make([]byte, 4))
+	c.Get("a")
+	c.Put("c", make([]byte, 5))
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("Expected b to be evicted, got %v", evicted)
+	}
+
+	if c.Cost() != 9 || c.Len() != 2 {
+		t.Errorf("Expected cost 9 over 2 entries, got %d over %d", c.Cost(), c.Len())
+	}
+
+	c.Put("d", make([]byte, 10))
+
+	if c.Len() != 1 || c.Cost() != 10 || len(evicted) != 3 {
+		t.Errorf("Expected d alone after evicting everything else, got %d entries costing %d", c.Len(), c.Cost())
+	}
+}
+
+func TestCostLRUUpdateTracksCost(t *testing.T) {
+	c := NewCostLRU[string, []byte](10, byteCost)
+
+	c.Put("a", make([]byte, 2))
+	c.Put("b", make([]byte, 3))
+	c.Put("a", make([]byte, 6))
+
+	if cost, _ := c.CostOf("a"); cost != 6 || c.Cost() != 9 {
+		t.Errorf("Expected a to cost 6 of 9, got %d of %d", cost, c.Cost())
+	}
+
+	c.Remove("b")
+
+	if c.Cost() != 6 {
+		t.Errorf("Expected cost to be 6, got %d", c.Cost())
+	}
+
+	if _, found := c.CostOf("b"); found {
+		t.Errorf("Expected b to be removed")
+	}
+}
+
+func TestCostLRURejectsOversizedEntries(t *testing.T) {
+	c := NewCostLRU[string, []byte](10, byteCost)
+
+	c.Put("a", make([]byte, 1))
+	c.Put("b", make([]byte, 1))
+
+	if c.Put("b", make([]byte, 11)) {
+		t.Errorf("Expected an entry over budget to be rejected")
+	}
+
+	if _, found := c.Peek("b"); found || c.Cost() != 1 {
+		t.Errorf("Expected the previous value of b to be removed, cost %d", c.Cost())
+	}
+
+	if _, found := c.Peek("a"); !found {
+		t.Errorf("Expected a to be kept")
+	}
+}
+
+func TestCostLRUSetBudget(t *testing.T) {
+	c := NewCostLRU[string, []byte](10, byteCost)
+
+	c.Put("a", make([]byte, 3))
+	c.Put("b", make([]byte, 3))
+	c.Put("c", make([]byte, 3))
+	c.SetBudget(5)
+
+	if c.Len() != 1 || c.Budget() != 5 {
+		t.Errorf("Expected a single entry under the new budget, got %d", c.Len())
+	}
+
+	if _, found := c.Peek("c"); !found {
+		t.Errorf("Expected the most recent entry to be kept")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	c.SetBudget(0)
+}
+
+func TestCostLRUDumpAndWarm(t *testing.T) {
+	c := NewCostLRU[string, string](10, func(_ string, value string) uint64 {
+		return uint64(len(value))
+	})
+
+	c.Put("foo", "aaa")
+	c.Put("bar", "bbbb")
+	c.Get("foo")
+
+	warmed := NewCostLRU[string, string](5, func(_ string, value string) uint64 {
+		return uint64(len(value))
+	})
+
+	warmed.Warm(c.Dump())
+
+	if _, found := warmed.Peek("bar"); found {
+		t.Errorf("Expected 'bar' to be evicted to fit the smaller budget")
+	}
+
+	if cost, found := warmed.CostOf("foo"); !found || cost != 3 || warmed.Cost() != 3 {
+		t.Errorf("Expected cost to be 3, got %d", warmed.Cost())
+	}
+}
@@ -0,0 +1,73 @@
+package cache
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	segment uint8
+	cost    uint64
+	prev    *entry[K, V]
+	next    *entry[K, V]
+}
+
+// recencyList is an intrusive doubly linked list ordered from most to least
+// recently used, with a sentinel root so that no operation needs nil checks.
+type recencyList[K comparable, V any] struct {
+	root entry[K, V]
+	size int
+}
+
+func (l *recencyList[K, V]) init() {
+	l.root.prev = &l.root
+	l.root.next = &l.root
+	l.size = 0
+}
+
+func (l *recencyList[K, V]) pushFront(e *entry[K, V]) {
+	e.prev = &l.root
+	e.next = l.root.next
+	l.root.next.prev = e
+	l.root.next = e
+	l.size++
+}
+
+func (l *recencyList[K, V]) unlink(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	l.size--
+}
+
+func (l *recencyList[K, V]) moveToFront(e *entry[K, V]) {
+	if l.root.next == e {
+		return
+	}
+
+	l.unlink(e)
+	l.pushFront(e)
+}
+
+func (l *recencyList[K, V]) back() *entry[K, V] {
+	if l.size == 0 {
+		return nil
+	}
+
+	return l.root.prev
+}
+
+// Entry is an exported cache entry as returned by Dump. Protected records
+// the SLRU segment of the entry and is always false for the other caches.
+type Entry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	Protected bool
+}
+
+// appendOldestFirst appends the entries from least to most recently used
+func (l *recencyList[K, V]) appendOldestFirst(entries []Entry[K, V]) []Entry[K, V] {
+	for e := l.root.prev; e != &l.root; e = e.prev {
+		entries = append(entries, Entry[K, V]{Key: e.key, Value: e.value, Protected: e.segment == protected})
+	}
+
+	return entries
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/hashtable"
+)
+
+// LRU keeps its entries in a hash table for lookups and in an intrusive
+// doubly linked list ordered from most to least recently used.
+type LRU[K comparable, V any] struct {
+	capacity int
+	table    *hashtable.HashTable[K, *entry[K, V]]
+	recency  recencyList[K, V]
+	onEvict  func(K, V)
+}
+
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity <= 0 {
+		msg := fmt.Sprintf("invalid capacity: %d", capacity)
+		panic(errors.New(msg))
+	}
+
+	c := LRU[K, V]{
+		capacity: capacity,
+		table:    hashtable.NewHashTable[K, *entry[K, V]](),
+	}
+
+	c.recency.init()
+
+	return &c
+}
+
+// OnEvict registers a callback invoked for entries dropped to make room
+func (c *LRU[K, V]) OnEvict(f func(K, V)) {
+	c.onEvict = f
+}
+
+func (c *LRU[K, V]) Get(key K) (value V, found bool) {
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	c.recency.moveToFront(e)
+
+	return e.value, true
+}
+
+// Peek reads a value without marking it as recently used
+func (c *LRU[K, V]) Peek(key K) (value V, found bool) {
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	return e.value, true
+}
+
+func (c *LRU[K, V]) Put(key K, value V) {
+	if e, found := c.table.TryGet(key); found {
+		e.value = value
+		c.recency.moveToFront(e)
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value}
+	c.table.Insert(key, e)
+	c.recency.pushFront(e)
+
+	if c.Len() > c.capacity {
+		c.evict()
+	}
+}
+
+func (c *LRU[K, V]) Remove(key K) bool {
+	e, found := c.table.Delete(key)
+
+	if !found {
+		return false
+	}
+
+	c.recency.unlink(e)
+
+	return true
+}
+
+func (c *LRU[K, V]) Len() int {
+	return int(c.table.Size())
+}
+
+func (c *LRU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *LRU[K, V]) evict() {
+	oldest := c.recency.back()
+
+	c.recency.unlink(oldest)
+	c.table.Delete(oldest.key)
+
+	if c.onEvict != nil {
+		c.onEvict(oldest.key, oldest.value)
+	}
+}
+
+// Dump exports the entries from least to most recently used, so that a new
+// cache warmed with them ends up in the same order
+func (c *LRU[K, V]) Dump() []Entry[K, V] {
+	return c.recency.appendOldestFirst(make([]Entry[K, V], 0, c.Len()))
+}
+
+// Warm puts the entries in order, the last one becoming the most recently
+// used. Entries beyond the capacity evict the oldest ones as Put does.
+func (c *LRU[K, V]) Warm(entries []Entry[K, V]) {
+	for _, e := range entries {
+		c.Put(e.Key, e.Value)
+	}
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLRUGetAndPut(t *testing.T) {
+	c := NewLRU[string, int](2)
+
+	c.Put("foo", 1)
+	c.Put("bar", 2)
+
+	if value, found := c.Get("foo"); !found || value != 1 {
+		t.Errorf("Expected value to be 1, got %d", value)
+	}
+
+	c.Put("foo", 10)
+
+	if value, _ := c.Get("foo"); value != 10 {
+		t.Errorf("Expected value to be 10, got %d", value)
+	}
+
+	if c.Len() != 2 {
+		t.Errorf("Expected length to be 2, got %d", c.Len())
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[string, int](2)
+	evicted := make([]string, 0)
+
+	c.OnEvict(func(key string, _ int) {
+		evicted = append(evicted, key)
+	})
+
+	c.Put("foo", 1)
+	c.Put("bar", 2)
+	c.Get("foo")
+	c.Put("baz", 3)
+
+	if _, found := c.Get("bar"); found {
+		t.Errorf("Expected 'bar' to be evicted")
+	}
+
+	if fmt.Sprint(evicted) != "[bar]" {
+		t.Errorf("Expected [bar] to be evicted, got %v", evicted)
+	}
+
+	c.Peek("foo")
+	c.Put("qux", 4)
+
+	if _, found := c.Get("foo"); found {
+		t.Errorf("Expected Peek to not refresh 'foo'")
+	}
+}
+
+func TestLRURemove(t *testing.T) {
+	c := NewLRU[int, int](10)
+
+	for i := 0; i < 10; i++ {
+		c.Put(i, i)
+	}
+
+	if !c.Remove(5) {
+		t.Errorf("Expected 5 to be removed")
+	}
+
+	if c.Remove(5) {
+		t.Errorf("Expected second remove to report nothing removed")
+	}
+
+	c.Put(10, 10)
+
+	for i := 0; i <= 10; i++ {
+		if _, found := c.Get(i); found != (i != 5) {
+			t.Errorf("Expected presence of %d to be %v", i, i != 5)
+		}
+	}
+}
+
+func TestLRUDumpAndWarm(t *testing.T) {
+	c := NewLRU[string, int](3)
+
+	c.Put("foo", 1)
+	c.Put("bar", 2)
+	c.Put("baz", 3)
+	c.Get("foo")
+
+	entries := c.Dump()
+
+	if fmt.Sprint(entries) != "[{bar 2 false} {baz 3 false} {foo 1 false}]" {
+		t.Errorf("Expected entries from least to most recently used, got %v", entries)
+	}
+
+	warmed := NewLRU[string, int](3)
+	warmed.Warm(entries)
+	warmed.Put("qux", 4)
+
+	if _, found := warmed.Peek("bar"); found {
+		t.Errorf("Expected 'bar' to be evicted first after warming")
+	}
+
+	if fmt.Sprint(warmed.Dump()) != "[{baz 3 false} {foo 1 false} {qux 4 false}]" {
+		t.Errorf("Expected the warmed order to be kept, got %v", warmed.Dump())
+	}
+}
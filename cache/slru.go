@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/hashtable"
+)
+
+const (
+	probation uint8 = iota
+	protected
+)
+
+// Admission decides whether a new key may replace the entry that would be
+// evicted for it. Record is called on every access so that the policy can
+// track key popularity.
+type Admission[K comparable] interface {
+	Record(key K)
+	Admit(candidate, victim K) bool
+}
+
+// FrequencyEstimator is the minimal interface of an approximate counter such
+// as a count-min sketch.
+type FrequencyEstimator[K comparable] interface {
+	Add(key K, delta uint64)
+	Estimate(key K) uint64
+}
+
+// FrequencyAdmission implements TinyLFU: a candidate is admitted only when
+// it has been seen more often than the victim.
+type FrequencyAdmission[K comparable] struct {
+	estimator FrequencyEstimator[K]
+}
+
+func NewFrequencyAdmission[K comparable](estimator FrequencyEstimator[K]) *FrequencyAdmission[K] {
+	return &FrequencyAdmission[K]{estimator: estimator}
+}
+
+func (f *FrequencyAdmission[K]) Record(key K) {
+	f.estimator.Add(key, 1)
+}
+
+func (f *FrequencyAdmission[K]) Admit(candidate, victim K) bool {
+	return f.estimator.Estimate(candidate) > f.estimator.Estimate(victim)
+}
+
+// SLRU splits its capacity into a probationary segment for entries seen once
+// and a protected segment for entries hit again, so that a burst of one-off
+// keys cannot flush the frequently used ones.
+type SLRU[K comparable, V any] struct {
+	capacity          int
+	protectedCapacity int
+	table             *hashtable.HashTable[K, *entry[K, V]]
+	segments          [2]recencyList[K, V]
+	admission         Admission[K]
+	onEvict           func(K, V)
+}
+
+// NewSLRU reserves 80% of the capacity for the protected segment
+func NewSLRU[K comparable, V any](capacity int) *SLRU[K, V] {
+	return NewSLRUWithRatio[K, V](capacity, 0.8)
+}
+
+func NewSLRUWithRatio[K comparable, V any](capacity int, protectedRatio float64) *SLRU[K, V] {
+	protectedCapacity := int(float64(capacity) * protectedRatio)
+
+	if capacity < 2 || protectedRatio <= 0 || protectedRatio >= 1 || protectedCapacity < 1 || protectedCapacity >= capacity {
+		msg := fmt.Sprintf("invalid SLRU parameters: capacity %d, protected ratio %f", capacity, protectedRatio)
+		panic(errors.New(msg))
+	}
+
+	c := SLRU[K, V]{
+		capacity:          capacity,
+		protectedCapacity: protectedCapacity,
+		table:             hashtable.NewHashTable[K, *entry[K, V]](),
+	}
+
+	c.segments[probation].init()
+	c.segments[protected].init()
+
+	return &c
+}
+
+func (c *SLRU[K, V]) SetAdmission(admission Admission[K]) {
+	c.admission = admission
+}
+
+func (c *SLRU[K, V]) OnEvict(f func(K, V)) {
+	c.onEvict = f
+}
+
+func (c *SLRU[K, V]) Get(key K) (value V, found bool) {
+	if c.admission != nil {
+		c.admission.Record(key)
+	}
+
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	c.hit(e)
+
+	return e.value, true
+}
+
+func (c *SLRU[K, V]) Peek(key K) (value V, found bool) {
+	e, found := c.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	return e.value, true
+}
+
+// Put returns false when the admission policy rejected a new key
+func (c *SLRU[K, V]) Put(key K, value V) bool {
+	if c.admission != nil {
+		c.admission.Record(key)
+	}
+
+	if e, found := c.table.TryGet(key); found {
+		e.value = value
+		c.hit(e)
+		return true
+	}
+
+	if c.Len() >= c.capacity {
+		victim := c.victim()
+
+		if c.admission != nil && !c.admission.Admit(key, victim.key) {
+			return false
+		}
+
+		c.evict(victim)
+	}
+
+	e := &entry[K, V]{key: key, value: value, segment: probation}
+	c.table.Insert(key, e)
+	c.segments[probation].pushFront(e)
+
+	return true
+}
+
+func (c *SLRU[K, V]) Remove(key K) bool {
+	e, found := c.table.Delete(key)
+
+	if !found {
+		return false
+	}
+
+	c.segments[e.segment].unlink(e)
+
+	return true
+}
+
+func (c *SLRU[K, V]) Len() int {
+	return int(c.table.Size())
+}
+
+func (c *SLRU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *SLRU[K, V]) hit(e *entry[K, V]) {
+	if e.segment == protected {
+		c.segments[protected].moveToFront(e)
+		return
+	}
+
+	c.segments[probation].unlink(e)
+	e.segment = protected
+	c.segments[protected].pushFront(e)
+
+	c.demote()
+}
+
+// demote moves the least recently used protected entries back to probation
+// until the protected segment fits, giving them another chance
+func (c *SLRU[K, V]) demote() {
+	for c.segments[protected].size > c.protectedCapacity {
+		demoted := c.segments[protected].back()
+		c.segments[protected].unlink(demoted)
+		demoted.segment = probation
+		c.segments[probation].pushFront(demoted)
+	}
+}
+
+func (c *SLRU[K, V]) victim() *entry[K, V] {
+	if victim := c.segments[probation].back(); victim != nil {
+		return victim
+	}
+
+	return c.segments[protected].back()
+}
+
+func (c *SLRU[K, V]) evict(victim *entry[K, V]) {
+	c.segments[victim.segment].unlink(victim)
+	c.table.Delete(victim.key)
+
+	if c.onEvict != nil {
+		c.onEvict(victim.key, victim.value)
+	}
+}
+
+// Dump exports the probationary entries then the protected ones, each from
+// least to most recently used. The frequencies kept by an admission policy
+// belong to the policy and are not part of the dump.
+func (c *SLRU[K, V]) Dump() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, c.Len())
+	entries = c.segments[probation].appendOldestFirst(entries)
+
+	return c.segments[protected].appendOldestFirst(entries)
+}
+
+// Warm restores the entries into their segments without consulting the
+// admission policy, since they were admitted before being dumped. Overflowing
+// protected entries are demoted and the cache is then trimmed to its capacity.
+func (c *SLRU[K, V]) Warm(entries []Entry[K, V]) {
+	for _, warmed := range entries {
+		c.Remove(warmed.Key)
+
+		e := &entry[K, V]{key: warmed.Key, value: warmed.Value, segment: probation}
+
+		if warmed.Protected {
+			e.segment = protected
+		}
+
+		c.table.Insert(e.key, e)
+		c.segments[e.segment].pushFront(e)
+	}
+
+	c.demote()
+
+	for c.Len() > c.capacity {
+		c.evict(c.victim())
+	}
+}
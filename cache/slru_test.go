@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSLRUProtectsFrequentlyUsedEntries(t *testing.T) {
+	c := NewSLRU[string, int](5)
+
+	c.Put("hot", 1)
+	c.Get("hot")
+
+	// A scan of one-off keys only churns the probationary segment
+	for i := 0; i < 100; i++ {
+		c.Put(fmt.Sprint(i), i)
+	}
+
+	if _, found := c.Get("hot"); !found {
+		t.Errorf("Expected 'hot' to survive the scan")
+	}
+
+	if c.Len() != 5 {
+		t.Errorf("Expected length to be 5, got %d", c.Len())
+	}
+}
+
+func TestSLRUDemotesWhenProtectedSegmentOverflows(t *testing.T) {
+	c := NewSLRUWithRatio[int, int](4, 0.5)
+	evicted := make([]int, 0)
+
+	c.OnEvict(func(key, _ int) {
+		evicted = append(evicted, key)
+	})
+
+	for i := 1; i <= 3; i++ {
+		c.Put(i, i)
+		c.Get(i)
+	}
+
+	// 1 was demoted to probation when 3 was promoted, so it is evicted first
+	c.Put(4, 4)
+	c.Put(5, 5)
+
+	if fmt.Sprint(evicted) != "[1]" {
+		t.Errorf("Expected [1] to be evicted, got %v", evicted)
+	}
+}
+
+func TestSLRURemove(t *testing.T) {
+	c := NewSLRU[string, int](5)
+
+	c.Put("foo", 1)
+	c.Get("foo")
+
+	if !c.Remove("foo") || c.Remove("foo") {
+		t.Errorf("Expected 'foo' to be removed exactly once")
+	}
+
+	if c.Len() != 0 {
+		t.Errorf("Expected length to be 0, got %d", c.Len())
+	}
+}
+
+type exactCounter[K comparable] map[K]uint64
+
+func (e exactCounter[K]) Add(key K, delta uint64) {
+	e[key] += delta
+}
+
+func (e exactCounter[K]) Estimate(key K) uint64 {
+	return e[key]
+}
+
+func TestSLRUFrequencyAdmissionRejectsRareKeys(t *testing.T) {
+	c := NewSLRU[string, int](2)
+	c.SetAdmission(NewFrequencyAdmission[string](exactCounter[string]{}))
+
+	for i := 0; i < 5; i++ {
+		c.Get("a")
+		c.Get("b")
+	}
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	if c.Put("rare", 3) {
+		t.Errorf("Expected a key seen once to be rejected")
+	}
+
+	if _, found := c.Peek("a"); !found {
+		t.Errorf("Expected 'a' to stay cached")
+	}
+
+	for i := 0; i < 10; i++ {
+		c.Get("popular")
+	}
+
+	if !c.Put("popular", 4) {
+		t.Errorf("Expected a frequently requested key to be admitted")
+	}
+}
+
+func TestSLRUDumpAndWarm(t *testing.T) {
+	c := NewSLRUWithRatio[string, int](4, 0.5)
+
+	c.Put("foo", 1)
+	c.Put("bar", 2)
+	c.Put("baz", 3)
+	c.Get("foo")
+
+	entries := c.Dump()
+
+	if fmt.Sprint(entries) != "[{bar 2 false} {baz 3 false} {foo 1 true}]" {
+		t.Errorf("Expected probationary then protected entries, got %v", entries)
+	}
+
+	warmed := NewSLRUWithRatio[string, int](4, 0.5)
+	warmed.Warm(entries)
+
+	if fmt.Sprint(warmed.Dump()) != fmt.Sprint(entries) {
+		t.Errorf("Expected the warmed cache to match, got %v", warmed.Dump())
+	}
+
+	small := NewSLRUWithRatio[string, int](2, 0.5)
+	small.SetAdmission(NewFrequencyAdmission[string](exactCounter[string]{}))
+	small.Warm(entries)
+
+	if fmt.Sprint(small.Dump()) != "[{baz 3 false} {foo 1 true}]" {
+		t.Errorf("Expected the oldest probationary entry to be evicted, got %v", small.Dump())
+	}
+}
@@ -0,0 +1,209 @@
+package curve
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestMorton2(t *testing.T) {
+	if code := Morton2(0b101, 0b011); code != 0b011011 {
+		t.Errorf("Expected code to be 0b011011, got %b", code)
+	}
+
+	for _, p := range [][2]uint32{{0, 0}, {1, 2}, {12345, 67890}, {1<<32 - 1, 7}} {
+		if x, y := DecodeMorton2(Morton2(p[0], p[1])); x != p[0] || y != p[1] {
+			t.Errorf("Expected %v after a round trip, got (%d, %d)", p, x, y)
+		}
+	}
+}
+
+func TestMorton3(t *testing.T) {
+	if code := Morton3(1, 0, 1); code != 0b101 {
+		t.Errorf("Expected code to be 0b101, got %b", code)
+	}
+
+	for _, p := range [][3]uint32{{0, 0, 0}, {3, 5, 9}, {MaxMorton3, 1, MaxMorton3}} {
+		if x, y, z := DecodeMorton3(Morton3(p[0], p[1], p[2])); x != p[0] || y != p[1] || z != p[2] {
+			t.Errorf("Expected %v after a round trip, got (%d, %d, %d)", p, x, y, z)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Morton3(MaxMorton3+1, 0, 0)
+}
+
+func TestHilbert2(t *testing.T) {
+	expected := [][2]uint32{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+
+	for code, p := range expected {
+		if got := Hilbert2(1, p[0], p[1]); got != uint64(code) {
+			t.Errorf("Expected %v to have code %d, got %d", p, code, got)
+		}
+	}
+
+	const order = 4
+
+	px, py := DecodeHilbert2(order, 0)
+
+	for code := uint64(0); code < 1<<(2*order); code++ {
+		x, y := DecodeHilbert2(order, code)
+
+		if Hilbert2(order, x, y) != code {
+			t.Fatalf("Expected (%d, %d) to encode back to %d", x, y, code)
+		}
+
+		if distance := absDiff(x, px) + absDiff(y, py); code > 0 && distance != 1 {
+			t.Fatalf("Expected code %d to be adjacent to its predecessor, got distance %d", code, distance)
+		}
+
+		px, py = x, y
+	}
+}
+
+func TestHilbert3(t *testing.T) {
+	const order = 3
+
+	seen := make(map[[3]uint32]bool)
+	px, py, pz := DecodeHilbert3(order, 0)
+
+	for code := uint64(0); code < 1<<(3*order); code++ {
+		x, y, z := DecodeHilbert3(order, code)
+
+		if Hilbert3(order, x, y, z) != code {
+			t.Fatalf("Expected (%d, %d, %d) to encode back to %d", x, y, z, code)
+		}
+
+		if distance := absDiff(x, px) + absDiff(y, py) + absDiff(z, pz); code > 0 && distance != 1 {
+			t.Fatalf("Expected code %d to be adjacent to its predecessor, got distance %d", code, distance)
+		}
+
+		seen[[3]uint32{x, y, z}] = true
+		px, py, pz = x, y, z
+	}
+
+	if len(seen) != 1<<(3*order) {
+		t.Errorf("Expected every cell to be visited, got %d", len(seen))
+	}
+}
+
+func TestHilbertOutOfRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Hilbert2(3, 8, 0)
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
+
+func codesIn(ranges []Range) []uint64 {
+	codes := make([]uint64, 0)
+
+	for _, r := range ranges {
+		for code := r.Lo; code <= r.Hi; code++ {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes
+}
+
+func TestRanges2(t *testing.T) {
+	min, max := [2]uint32{3, 2}, [2]uint32{9, 6}
+
+	curves := map[string]struct {
+		ranges []Range
+		encode func(x, y uint32) uint64
+	}{
+		"morton": {MortonRanges2(min, max, 0), Morton2},
+		"hilbert": {HilbertRanges2(4, min, max, 0), func(x, y uint32) uint64 {
+			return Hilbert2(4, x, y)
+		}},
+	}
+
+	for name, curve := range curves {
+		expected := make([]uint64, 0)
+
+		for x := min[0]; x <= max[0]; x++ {
+			for y := min[1]; y <= max[1]; y++ {
+				expected = append(expected, curve.encode(x, y))
+			}
+		}
+
+		slices.Sort(expected)
+
+		if got := codesIn(curve.ranges); !slices.Equal(got, expected) {
+			t.Errorf("Expected %s ranges to cover %v, got %v", name, expected, got)
+		}
+
+		for i := 1; i < len(curve.ranges); i++ {
+			if curve.ranges[i].Lo <= curve.ranges[i-1].Hi+1 {
+				t.Errorf("Expected %s ranges to be sorted and disjoint, got %v", name, curve.ranges)
+			}
+		}
+	}
+}
+
+func TestRanges3(t *testing.T) {
+	min, max := [3]uint32{1, 2, 0}, [3]uint32{5, 3, 6}
+	expected := make([]uint64, 0)
+
+	for x := min[0]; x <= max[0]; x++ {
+		for y := min[1]; y <= max[1]; y++ {
+			for z := min[2]; z <= max[2]; z++ {
+				expected = append(expected, Hilbert3(3, x, y, z))
+			}
+		}
+	}
+
+	slices.Sort(expected)
+
+	if got := codesIn(HilbertRanges3(3, min, max, 0)); !slices.Equal(got, expected) {
+		t.Errorf("Expected hilbert ranges to cover %v, got %v", expected, got)
+	}
+
+	if ranges := MortonRanges3([3]uint32{0, 0, 0}, [3]uint32{3, 3, 3}, 0); len(ranges) != 1 || ranges[0] != (Range{0, 63}) {
+		t.Errorf("Expected an aligned cube to be a single range, got %v", ranges)
+	}
+}
+
+func TestWholeGrid(t *testing.T) {
+	ranges := MortonRanges2([2]uint32{0, 0}, [2]uint32{1<<32 - 1, 1<<32 - 1}, 0)
+
+	if len(ranges) != 1 || ranges[0] != (Range{0, 1<<64 - 1}) {
+		t.Errorf("Expected the whole grid to be a single range, got %v", ranges)
+	}
+}
+
+func TestCoarsen(t *testing.T) {
+	ranges := []Range{{0, 1}, {3, 4}, {10, 12}, {14, 20}}
+	expected := []Range{{0, 4}, {10, 20}}
+
+	if got := Coarsen(ranges, 2); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := MortonRanges2([2]uint32{3, 2}, [2]uint32{9, 6}, 3); len(got) > 3 {
+		t.Errorf("Expected at most 3 ranges, got %v", got)
+	}
+}
+
+func TestKeyOrder(t *testing.T) {
+	if bytes.Compare(Key(255), Key(256)) >= 0 {
+		t.Errorf("Expected keys to sort like their codes")
+	}
+}
@@ -0,0 +1,137 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Hilbert2 maps a point of a 2^order x 2^order grid to its position along the
+// Hilbert curve, which unlike the Z-order curve never jumps between distant
+// cells, so nearby codes are always nearby points
+func Hilbert2(order int, x, y uint32) uint64 {
+	checkOrder(order, 32)
+
+	return hilbertIndex(order, []uint64{checkCoordinate(order, x), checkCoordinate(order, y)})
+}
+
+func DecodeHilbert2(order int, code uint64) (x, y uint32) {
+	checkOrder(order, 32)
+
+	axes := hilbertAxes(order, code, 2)
+
+	return uint32(axes[0]), uint32(axes[1])
+}
+
+func Hilbert3(order int, x, y, z uint32) uint64 {
+	checkOrder(order, 21)
+
+	axes := []uint64{checkCoordinate(order, x), checkCoordinate(order, y), checkCoordinate(order, z)}
+
+	return hilbertIndex(order, axes)
+}
+
+func DecodeHilbert3(order int, code uint64) (x, y, z uint32) {
+	checkOrder(order, 21)
+
+	axes := hilbertAxes(order, code, 3)
+
+	return uint32(axes[0]), uint32(axes[1]), uint32(axes[2])
+}
+
+func checkOrder(order, limit int) {
+	if order <= 0 || order > limit {
+		msg := fmt.Sprintf("invalid order: %d", order)
+		panic(errors.New(msg))
+	}
+}
+
+func checkCoordinate(order int, v uint32) uint64 {
+	if uint64(v) >= 1<<order {
+		msg := fmt.Sprintf("coordinate out of range for order %d: %d", order, v)
+		panic(errors.New(msg))
+	}
+
+	return uint64(v)
+}
+
+// hilbertIndex uses Skilling's transform of the axes into the transposed
+// index, whose bits interleaved from the most significant one form the code
+func hilbertIndex(order int, x []uint64) uint64 {
+	n := len(x)
+
+	for q := uint64(1) << (order - 1); q > 1; q >>= 1 {
+		p := q - 1
+
+		for i := 0; i < n; i++ {
+			if x[i]&q != 0 {
+				x[0] ^= p
+			} else {
+				t := (x[0] ^ x[i]) & p
+				x[0] ^= t
+				x[i] ^= t
+			}
+		}
+	}
+
+	for i := 1; i < n; i++ {
+		x[i] ^= x[i-1]
+	}
+
+	t := uint64(0)
+
+	for q := uint64(1) << (order - 1); q > 1; q >>= 1 {
+		if x[n-1]&q != 0 {
+			t ^= q - 1
+		}
+	}
+
+	code := uint64(0)
+
+	for bit := order - 1; bit >= 0; bit-- {
+		for i := 0; i < n; i++ {
+			code = code<<1 | ((x[i]^t)>>bit)&1
+		}
+	}
+
+	return code
+}
+
+func hilbertAxes(order int, code uint64, n int) []uint64 {
+	if n*order < 64 && code >= 1<<(n*order) {
+		msg := fmt.Sprintf("code out of range for order %d: %d", order, code)
+		panic(errors.New(msg))
+	}
+
+	x := make([]uint64, n)
+
+	for bit := order - 1; bit >= 0; bit-- {
+		for i := 0; i < n; i++ {
+			shift := bit*n + n - 1 - i
+			x[i] |= (code >> shift & 1) << bit
+		}
+	}
+
+	t := x[n-1] >> 1
+
+	for i := n - 1; i > 0; i-- {
+		x[i] ^= x[i-1]
+	}
+
+	x[0] ^= t
+
+	for q := uint64(2); q != 1<<order; q <<= 1 {
+		p := q - 1
+
+		for i := n - 1; i >= 0; i-- {
+			if x[i]&q != 0 {
+				x[0] ^= p
+			} else {
+				t := (x[0] ^ x[i]) & p
+				x[0] ^= t
+				x[i] ^= t
+			}
+		}
+	}
+
+	return x
+}
@@ -0,0 +1,84 @@
+package curve
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// MaxMorton3 is the largest coordinate Morton3 accepts, as 3 coordinates of
+// 21 bits fill a 64-bit code
+const MaxMorton3 = 1<<21 - 1
+
+// Morton2 interleaves the bits of x and y, x in the even bits, so that codes
+// follow the Z-order curve
+func Morton2(x, y uint32) uint64 {
+	return spread2(x) | spread2(y)<<1
+}
+
+func DecodeMorton2(code uint64) (x, y uint32) {
+	return compact2(code), compact2(code >> 1)
+}
+
+func Morton3(x, y, z uint32) uint64 {
+	if x > MaxMorton3 || y > MaxMorton3 || z > MaxMorton3 {
+		msg := fmt.Sprintf("coordinates out of range: (%d, %d, %d)", x, y, z)
+		panic(errors.New(msg))
+	}
+
+	return spread3(x) | spread3(y)<<1 | spread3(z)<<2
+}
+
+func DecodeMorton3(code uint64) (x, y, z uint32) {
+	return compact3(code), compact3(code >> 1), compact3(code >> 2)
+}
+
+func spread2(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000ffff0000ffff
+	x = (x | x<<8) & 0x00ff00ff00ff00ff
+	x = (x | x<<4) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+
+	return x
+}
+
+func compact2(x uint64) uint32 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x>>4) & 0x00ff00ff00ff00ff
+	x = (x | x>>8) & 0x0000ffff0000ffff
+	x = (x | x>>16) & 0x00000000ffffffff
+
+	return uint32(x)
+}
+
+func spread3(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<32) & 0x001f00000000ffff
+	x = (x | x<<16) & 0x001f0000ff0000ff
+	x = (x | x<<8) & 0x100f00f00f00f00f
+	x = (x | x<<4) & 0x10c30c30c30c30c3
+	x = (x | x<<2) & 0x1249249249249249
+
+	return x
+}
+
+func compact3(x uint64) uint32 {
+	x &= 0x1249249249249249
+	x = (x | x>>2) & 0x10c30c30c30c30c3
+	x = (x | x>>4) & 0x100f00f00f00f00f
+	x = (x | x>>8) & 0x001f0000ff0000ff
+	x = (x | x>>16) & 0x001f00000000ffff
+	x = (x | x>>32) & 0x00000000001fffff
+
+	return uint32(x)
+}
+
+// Key encodes a code big-endian, so that byte-ordered structures such as the
+// B+-tree keep keys in curve order
+func Key(code uint64) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), code)
+}
@@ -0,0 +1,174 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Range is an inclusive interval of curve codes
+type Range struct {
+	Lo, Hi uint64
+}
+
+// MortonRanges2 returns the sorted, disjoint code ranges covering exactly the
+// points of the box from min to max inclusive. When maxRanges is positive the
+// ranges separated by the smallest gaps are merged until at most maxRanges
+// remain, trading extra points to filter out for fewer range scans.
+func MortonRanges2(min, max [2]uint32, maxRanges int) []Range {
+	return decompose(32, min[:], max[:], maxRanges, func(p []uint64) uint64 {
+		return Morton2(uint32(p[0]), uint32(p[1]))
+	})
+}
+
+func MortonRanges3(min, max [3]uint32, maxRanges int) []Range {
+	for i := range max {
+		checkCoordinate(21, max[i])
+	}
+
+	return decompose(21, min[:], max[:], maxRanges, func(p []uint64) uint64 {
+		return Morton3(uint32(p[0]), uint32(p[1]), uint32(p[2]))
+	})
+}
+
+func HilbertRanges2(order int, min, max [2]uint32, maxRanges int) []Range {
+	checkOrder(order, 32)
+
+	return decompose(order, min[:], max[:], maxRanges, func(p []uint64) uint64 {
+		return Hilbert2(order, uint32(p[0]), uint32(p[1]))
+	})
+}
+
+func HilbertRanges3(order int, min, max [3]uint32, maxRanges int) []Range {
+	checkOrder(order, 21)
+
+	return decompose(order, min[:], max[:], maxRanges, func(p []uint64) uint64 {
+		return Hilbert3(order, uint32(p[0]), uint32(p[1]), uint32(p[2]))
+	})
+}
+
+// decomposer splits the grid recursively into aligned cells of side 2^level.
+// Both curves visit every point of such a cell before leaving it, so a cell
+// inside the box is the single range of codes sharing the prefix of any of
+// its points.
+type decomposer struct {
+	lo, hi []uint64
+	encode func(p []uint64) uint64
+	ranges []Range
+}
+
+func decompose(bits int, min, max []uint32, maxRanges int, encode func(p []uint64) uint64) []Range {
+	d := decomposer{
+		lo:     make([]uint64, len(min)),
+		hi:     make([]uint64, len(max)),
+		encode: encode,
+		ranges: make([]Range, 0),
+	}
+
+	for i := range min {
+		if min[i] > max[i] {
+			msg := fmt.Sprintf("invalid box: %v to %v", min, max)
+			panic(errors.New(msg))
+		}
+
+		d.lo[i], d.hi[i] = uint64(min[i]), uint64(max[i])
+		checkCoordinate(bits, max[i])
+	}
+
+	d.visit(make([]uint64, len(min)), bits)
+
+	sort.Slice(d.ranges, func(i, j int) bool {
+		return d.ranges[i].Lo < d.ranges[j].Lo
+	})
+
+	merged := d.ranges[:1]
+
+	for _, r := range d.ranges[1:] {
+		if last := &merged[len(merged)-1]; r.Lo == last.Hi+1 {
+			last.Hi = r.Hi
+		} else {
+			merged = append(merged, r)
+		}
+	}
+
+	return Coarsen(merged, maxRanges)
+}
+
+func (d *decomposer) visit(origin []uint64, level int) {
+	side := uint64(1) << level
+	inside := true
+
+	for i := range origin {
+		end := origin[i] + side - 1
+
+		if end < d.lo[i] || origin[i] > d.hi[i] {
+			return
+		}
+
+		if origin[i] < d.lo[i] || end > d.hi[i] {
+			inside = false
+		}
+	}
+
+	if inside {
+		// The shift is 64 for the whole 2D grid, where the mask wraps to all ones
+		mask := uint64(1)<<(level*len(origin)) - 1
+		lo := d.encode(origin) &^ mask
+		d.ranges = append(d.ranges, Range{Lo: lo, Hi: lo | mask})
+
+		return
+	}
+
+	half := side / 2
+
+	for child := 0; child < 1<<len(origin); child++ {
+		corner := slices.Clone(origin)
+
+		for i := range corner {
+			if child>>i&1 == 1 {
+				corner[i] += half
+			}
+		}
+
+		d.visit(corner, level-1)
+	}
+}
+
+// Coarsen merges the sorted ranges separated by the smallest gaps until at
+// most maxRanges remain. It returns ranges unchanged when maxRanges is not
+// positive.
+func Coarsen(ranges []Range, maxRanges int) []Range {
+	if maxRanges <= 0 || len(ranges) <= maxRanges {
+		return ranges
+	}
+
+	gaps := make([]int, len(ranges)-1)
+
+	for i := range gaps {
+		gaps[i] = i
+	}
+
+	sort.SliceStable(gaps, func(a, b int) bool {
+		return ranges[gaps[a]+1].Lo-ranges[gaps[a]].Hi < ranges[gaps[b]+1].Lo-ranges[gaps[b]].Hi
+	})
+
+	closed := make([]bool, len(ranges)-1)
+
+	for _, gap := range gaps[:len(ranges)-maxRanges] {
+		closed[gap] = true
+	}
+
+	coarse := make([]Range, 0, maxRanges)
+	coarse = append(coarse, ranges[0])
+
+	for i, r := range ranges[1:] {
+		if closed[i] {
+			coarse[len(coarse)-1].Hi = r.Hi
+		} else {
+			coarse = append(coarse, r)
+		}
+	}
+
+	return coarse
+}
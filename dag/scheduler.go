@@ -0,0 +1,259 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"algorithms/hashtable"
+)
+
+var ErrCycle = errors.New("dag: dependency cycle")
+
+type Task interface {
+	Run(ctx context.Context) error
+}
+
+type TaskFunc func(ctx context.Context) error
+
+func (f TaskFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+type Status int
+
+const (
+	Pending Status = iota
+	Running
+	Succeeded
+	Failed
+	Skipped
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Running:
+		return "running"
+	case Succeeded:
+		return "succeeded"
+	case Failed:
+		return "failed"
+	case Skipped:
+		return "skipped"
+	}
+
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+type Result[K comparable] struct {
+	ID     K
+	Status Status
+	Err    error
+}
+
+type node[K comparable] struct {
+	id         K
+	task       Task
+	deps       []K
+	dependents []*node[K]
+	waiting    int
+	status     Status
+	err        error
+}
+
+// Scheduler runs tasks once all of their dependencies succeeded, using at
+// most Workers goroutines. Tasks depending on a failed task are skipped.
+// With FailFast the first failure also cancels running tasks and skips
+// everything not yet started.
+type Scheduler[K comparable] struct {
+	Workers  int
+	FailFast bool
+
+	nodes *hashtable.HashTable[K, *node[K]]
+	order []*node[K]
+}
+
+func NewScheduler[K comparable](workers int) *Scheduler[K] {
+	return &Scheduler[K]{
+		Workers: workers,
+		nodes:   hashtable.NewHashTable[K, *node[K]](),
+		order:   make([]*node[K], 0),
+	}
+}
+
+// Add registers a task. Dependencies may be added later, they are only
+// resolved when Run is called.
+func (s *Scheduler[K]) Add(id K, task Task, deps ...K) error {
+	if s.nodes.Contains(id) {
+		msg := fmt.Sprintf("dag: duplicate task: %v", id)
+		return errors.New(msg)
+	}
+
+	n := &node[K]{id: id, task: task, deps: deps}
+
+	s.nodes.Insert(id, n)
+	s.order = append(s.order, n)
+
+	return nil
+}
+
+func (s *Scheduler[K]) resolve() ([]*node[K], error) {
+	ready := make([]*node[K], 0)
+
+	for _, n := range s.order {
+		n.dependents = n.dependents[:0]
+		n.status = Pending
+		n.err = nil
+	}
+
+	for _, n := range s.order {
+		n.waiting = len(n.deps)
+
+		for _, dep := range n.deps {
+			parent, found := s.nodes.TryGet(dep)
+
+			if !found {
+				msg := fmt.Sprintf("dag: task %v depends on unknown task %v", n.id, dep)
+				return nil, errors.New(msg)
+			}
+
+			parent.dependents = append(parent.dependents, n)
+		}
+
+		if n.waiting == 0 {
+			ready = append(ready, n)
+		}
+	}
+
+	// Kahn's algorithm on a copy of the counters to reject cycles up front
+	waiting := make(map[*node[K]]int, len(s.order))
+	queue := append([]*node[K]{}, ready...)
+	visited := 0
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		visited++
+
+		for _, child := range n.dependents {
+			if _, seen := waiting[child]; !seen {
+				waiting[child] = child.waiting
+			}
+
+			waiting[child]--
+
+			if waiting[child] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	if visited != len(s.order) {
+		return nil, ErrCycle
+	}
+
+	return ready, nil
+}
+
+// Run executes every task and returns their results in the order they were
+// added, along with the first task error or the context error.
+func (s *Scheduler[K]) Run(ctx context.Context) ([]Result[K], error) {
+	if s.Workers <= 0 {
+		msg := fmt.Sprintf("dag: invalid worker count: %d", s.Workers)
+		return nil, errors.New(msg)
+	}
+
+	ready, err := s.resolve()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *node[K], s.Workers)
+	done := make(chan *node[K])
+
+	var workers sync.WaitGroup
+
+	for i := 0; i < s.Workers; i++ {
+		workers.Add(1)
+
+		go func() {
+			defer workers.Done()
+
+			for n := range jobs {
+				n.err = n.task.Run(ctx)
+				done <- n
+			}
+		}()
+	}
+
+	var firstErr error
+	running := 0
+
+	for {
+		for len(ready) > 0 && running < s.Workers && ctx.Err() == nil {
+			n := ready[0]
+			ready = ready[1:]
+
+			n.status = Running
+			running++
+			jobs <- n
+		}
+
+		if running == 0 {
+			break
+		}
+
+		n := <-done
+		running--
+
+		if n.err != nil {
+			n.status = Failed
+
+			if firstErr == nil {
+				firstErr = n.err
+			}
+
+			if s.FailFast {
+				cancel()
+			}
+
+			continue
+		}
+
+		n.status = Succeeded
+
+		for _, child := range n.dependents {
+			child.waiting--
+
+			if child.waiting == 0 {
+				ready = append(ready, child)
+			}
+		}
+	}
+
+	close(jobs)
+	workers.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+
+	results := make([]Result[K], len(s.order))
+
+	for i, n := range s.order {
+		if n.status == Pending {
+			n.status = Skipped
+		}
+
+		results[i] = Result[K]{ID: n.id, Status: n.status, Err: n.err}
+	}
+
+	return results, firstErr
+}
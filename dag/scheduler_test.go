@@ -0,0 +1,174 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mutex sync.Mutex
+	order []string
+}
+
+func (r *recorder) task(id string) Task {
+	return TaskFunc(func(context.Context) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.order = append(r.order, id)
+
+		return nil
+	})
+}
+
+func (r *recorder) position(id string) int {
+	for i, value := range r.order {
+		if value == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func TestRunsDependenciesFirst(t *testing.T) {
+	r := &recorder{}
+	scheduler := NewScheduler[string](4)
+
+	scheduler.Add("link", r.task("link"), "compile-a", "compile-b")
+	scheduler.Add("compile-a", r.task("compile-a"), "fetch")
+	scheduler.Add("compile-b", r.task("compile-b"), "fetch")
+	scheduler.Add("fetch", r.task("fetch"))
+
+	results, err := scheduler.Run(context.Background())
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	for _, result := range results {
+		if result.Status != Succeeded {
+			t.Errorf("Expected %s to succeed, got %s", result.ID, result.Status)
+		}
+	}
+
+	if r.position("fetch") != 0 || r.position("link") != 3 {
+		t.Errorf("Expected fetch first and link last, got %v", r.order)
+	}
+}
+
+func TestRespectsWorkerLimit(t *testing.T) {
+	scheduler := NewScheduler[int](2)
+
+	var active, peak int32
+
+	for i := 0; i < 10; i++ {
+		scheduler.Add(i, TaskFunc(func(context.Context) error {
+			current := atomic.AddInt32(&active, 1)
+
+			for {
+				seen := atomic.LoadInt32(&peak)
+
+				if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+
+			return nil
+		}))
+	}
+
+	if _, err := scheduler.Run(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent tasks, got %d", peak)
+	}
+}
+
+func TestSkipsDependentsOfFailedTask(t *testing.T) {
+	r := &recorder{}
+	failure := errors.New("boom")
+	scheduler := NewScheduler[string](2)
+
+	scheduler.Add("a", TaskFunc(func(context.Context) error {
+		return failure
+	}))
+	scheduler.Add("b", r.task("b"), "a")
+	scheduler.Add("c", r.task("c"), "b")
+	scheduler.Add("d", r.task("d"))
+
+	results, err := scheduler.Run(context.Background())
+
+	if err != failure {
+		t.Errorf("Expected error to be %v, got %v", failure, err)
+	}
+
+	expected := []Status{Failed, Skipped, Skipped, Succeeded}
+
+	for i, result := range results {
+		if result.Status != expected[i] {
+			t.Errorf("Expected %s to be %s, got %s", result.ID, expected[i], result.Status)
+		}
+	}
+
+	if results[0].Err != failure {
+		t.Errorf("Expected the failed result to carry its error, got %v", results[0].Err)
+	}
+}
+
+func TestFailFastSkipsRemainingTasks(t *testing.T) {
+	r := &recorder{}
+	scheduler := NewScheduler[string](1)
+	scheduler.FailFast = true
+
+	scheduler.Add("a", TaskFunc(func(context.Context) error {
+		return errors.New("boom")
+	}))
+	scheduler.Add("b", r.task("b"))
+
+	results, _ := scheduler.Run(context.Background())
+
+	if results[1].Status != Skipped {
+		t.Errorf("Expected b to be skipped, got %s", results[1].Status)
+	}
+
+	if len(r.order) != 0 {
+		t.Errorf("Expected no other task to run, got %v", r.order)
+	}
+}
+
+func TestRejectsCyclesAndUnknownDependencies(t *testing.T) {
+	r := &recorder{}
+
+	cyclic := NewScheduler[string](1)
+	cyclic.Add("a", r.task("a"), "b")
+	cyclic.Add("b", r.task("b"), "a")
+
+	if _, err := cyclic.Run(context.Background()); err != ErrCycle {
+		t.Errorf("Expected error to be %v, got %v", ErrCycle, err)
+	}
+
+	unknown := NewScheduler[string](1)
+	unknown.Add("a", r.task("a"), "missing")
+
+	if _, err := unknown.Run(context.Background()); err == nil {
+		t.Errorf("Expected an error for an unknown dependency")
+	}
+
+	if err := unknown.Add("a", r.task("a")); err == nil {
+		t.Errorf("Expected an error for a duplicate task")
+	}
+
+	if len(r.order) != 0 {
+		t.Errorf("Expected no task to run, got %v", r.order)
+	}
+}
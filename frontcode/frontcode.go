@@ -0,0 +1,302 @@
+package frontcode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+)
+
+const DefaultBucketSize = 16
+
+var errCorrupt = errors.New("frontcode: corrupt index")
+
+// Index is a read-only set of sorted strings stored front-coded: keys are
+// grouped in buckets whose first key is stored whole, and every following key
+// only stores the length of the prefix it shares with its predecessor and the
+// remaining suffix. Lookups binary search the bucket heads and decode a single
+// bucket.
+type Index struct {
+	data       []byte
+	offsets    []uint32
+	count      int
+	bucketSize int
+}
+
+// New builds an index from keys sorted in ascending order without duplicates
+func New(keys []string, bucketSize int) (*Index, error) {
+	if bucketSize <= 0 {
+		msg := fmt.Sprintf("invalid bucket size: %d", bucketSize)
+		return nil, errors.New(msg)
+	}
+
+	x := Index{
+		data:       make([]byte, 0),
+		offsets:    make([]uint32, 0, (len(keys)+bucketSize-1)/bucketSize),
+		count:      len(keys),
+		bucketSize: bucketSize,
+	}
+
+	for i, key := range keys {
+		if i > 0 && keys[i-1] >= key {
+			msg := fmt.Sprintf("keys are not sorted and unique at %d: %q after %q", i, key, keys[i-1])
+			return nil, errors.New(msg)
+		}
+
+		if i%bucketSize == 0 {
+			x.offsets = append(x.offsets, uint32(len(x.data)))
+			x.data = binary.AppendUvarint(x.data, uint64(len(key)))
+			x.data = append(x.data, key...)
+			continue
+		}
+
+		shared := sharedPrefix(keys[i-1], key)
+		x.data = binary.AppendUvarint(x.data, uint64(shared))
+		x.data = binary.AppendUvarint(x.data, uint64(len(key)-shared))
+		x.data = append(x.data, key[shared:]...)
+	}
+
+	return &x, nil
+}
+
+func sharedPrefix(a, b string) int {
+	n := 0
+
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+
+	return n
+}
+
+func (x *Index) Len() int {
+	return x.count
+}
+
+// Bytes is the size of the encoded keys
+func (x *Index) Bytes() int {
+	return len(x.data) + 4*len(x.offsets)
+}
+
+// head returns the first key of a bucket and the offset of the key after it
+func (x *Index) head(bucket int) ([]byte, int) {
+	offset := int(x.offsets[bucket])
+	length, n := binary.Uvarint(x.data[offset:])
+	end := offset + n + int(length)
+
+	return x.data[offset+n : end], end
+}
+
+// scan decodes the keys of a bucket in order. The key slice is reused
+// between calls, so f must copy it to keep it.
+func (x *Index) scan(bucket int, key []byte, f func(rank int, key []byte) bool) ([]byte, bool) {
+	rank := bucket * x.bucketSize
+	end := min(rank+x.bucketSize, x.count)
+
+	head, offset := x.head(bucket)
+	key = append(key[:0], head...)
+
+	for {
+		if !f(rank, key) {
+			return key, false
+		}
+
+		rank++
+
+		if rank == end {
+			return key, true
+		}
+
+		shared, n := binary.Uvarint(x.data[offset:])
+		offset += n
+		length, n := binary.Uvarint(x.data[offset:])
+		offset += n
+
+		key = append(key[:shared], x.data[offset:offset+int(length)]...)
+		offset += int(length)
+	}
+}
+
+// lowerBound returns the rank of the first key not smaller than key
+func (x *Index) lowerBound(key string) int {
+	bucket := sort.Search(len(x.offsets), func(i int) bool {
+		head, _ := x.head(i)
+		return string(head) > key
+	}) - 1
+
+	if bucket < 0 {
+		return 0
+	}
+
+	rank := min((bucket+1)*x.bucketSize, x.count)
+
+	x.scan(bucket, nil, func(r int, k []byte) bool {
+		if string(k) >= key {
+			rank = r
+			return false
+		}
+
+		return true
+	})
+
+	return rank
+}
+
+// Find returns the rank of key in sorted order
+func (x *Index) Find(key string) (rank int, found bool) {
+	rank = x.lowerBound(key)
+
+	if rank == x.count {
+		return rank, false
+	}
+
+	return rank, x.At(rank) == key
+}
+
+func (x *Index) Contains(key string) bool {
+	_, found := x.Find(key)
+
+	return found
+}
+
+// At returns the key of the given rank
+func (x *Index) At(rank int) string {
+	if rank < 0 || rank >= x.count {
+		msg := fmt.Sprintf("rank out of range: %d", rank)
+		panic(errors.New(msg))
+	}
+
+	var key string
+
+	x.scan(rank/x.bucketSize, nil, func(r int, k []byte) bool {
+		if r == rank {
+			key = string(k)
+			return false
+		}
+
+		return true
+	})
+
+	return key
+}
+
+// RangeFrom visits the keys from rank onwards in order until f returns false
+func (x *Index) RangeFrom(rank int, f func(string) bool) {
+	var buffer []byte
+
+	for bucket := max(rank, 0) / x.bucketSize; bucket < len(x.offsets); bucket++ {
+		var more bool
+
+		buffer, more = x.scan(bucket, buffer, func(r int, k []byte) bool {
+			return r < rank || f(string(k))
+		})
+
+		if !more {
+			return
+		}
+	}
+}
+
+func (x *Index) Range(f func(string) bool) {
+	x.RangeFrom(0, f)
+}
+
+func (x *Index) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		x.Range(yield)
+	}
+}
+
+// ScanPrefix visits the keys starting with prefix in order until f returns
+// false
+func (x *Index) ScanPrefix(prefix string, f func(string) bool) {
+	x.RangeFrom(x.lowerBound(prefix), func(key string) bool {
+		return strings.HasPrefix(key, prefix) && f(key)
+	})
+}
+
+func (x *Index) WithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		x.ScanPrefix(prefix, yield)
+	}
+}
+
+// MarshalBinary encodes the index so it can be embedded or stored and loaded
+// without rebuilding
+func (x *Index) MarshalBinary() ([]byte, error) {
+	buffer := make([]byte, 0, len(x.data)+3*binary.MaxVarintLen64)
+
+	buffer = binary.AppendUvarint(buffer, uint64(x.count))
+	buffer = binary.AppendUvarint(buffer, uint64(x.bucketSize))
+	buffer = binary.AppendUvarint(buffer, uint64(len(x.data)))
+	buffer = append(buffer, x.data...)
+
+	return buffer, nil
+}
+
+// UnmarshalBinary restores an index, walking the data once to rebuild the
+// bucket offsets
+func (x *Index) UnmarshalBinary(buffer []byte) error {
+	var fields [3]uint64
+
+	for i := range fields {
+		value, n := binary.Uvarint(buffer)
+
+		if n <= 0 {
+			return errCorrupt
+		}
+
+		fields[i] = value
+		buffer = buffer[n:]
+	}
+
+	count, bucketSize, length := fields[0], fields[1], fields[2]
+
+	if bucketSize == 0 || length != uint64(len(buffer)) || count > length {
+		return errCorrupt
+	}
+
+	data := buffer
+	offsets := make([]uint32, 0, (count+bucketSize-1)/bucketSize)
+	previous := uint64(0)
+	offset := 0
+
+	for i := uint64(0); i < count; i++ {
+		var shared uint64
+
+		if i%bucketSize == 0 {
+			offsets = append(offsets, uint32(offset))
+		} else {
+			value, n := binary.Uvarint(data[offset:])
+
+			if n <= 0 || value > previous {
+				return errCorrupt
+			}
+
+			shared = value
+			offset += n
+		}
+
+		suffix, n := binary.Uvarint(data[offset:])
+
+		if n <= 0 || suffix > uint64(len(data)-offset-n) {
+			return errCorrupt
+		}
+
+		offset += n + int(suffix)
+		previous = shared + suffix
+	}
+
+	if offset != len(data) {
+		return errCorrupt
+	}
+
+	x.data = append([]byte(nil), data...)
+	x.offsets = offsets
+	x.count = int(count)
+	x.bucketSize = int(bucketSize)
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package frontcode
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func sampleKeys(n int) []string {
+	random := rand.New(rand.NewSource(1))
+	unique := map[string]bool{}
+
+	for len(unique) < n {
+		unique[fmt.Sprintf("user/%d/%c", random.Intn(n*10), 'a'+rune(random.Intn(26)))] = true
+	}
+
+	keys := make([]string, 0, n)
+
+	for key := range unique {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func TestFindAndAt(t *testing.T) {
+	keys := sampleKeys(1000)
+
+	for _, bucketSize := range []int{1, 3, DefaultBucketSize} {
+		index, err := New(keys, bucketSize)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if index.Len() != len(keys) {
+			t.Errorf("Expected length to be %d, got %d", len(keys), index.Len())
+		}
+
+		for rank, key := range keys {
+			if found := index.At(rank); found != key {
+				t.Errorf("Expected key %d to be %q, got %q", rank, key, found)
+			}
+
+			if r, found := index.Find(key); !found || r != rank {
+				t.Errorf("Expected %q at rank %d, got %d (found %v)", key, rank, r, found)
+			}
+		}
+
+		for _, missing := range []string{"", "a", "user/", "user/1/", "zzz"} {
+			rank, found := index.Find(missing)
+			expected := sort.SearchStrings(keys, missing)
+
+			if found || rank != expected {
+				t.Errorf("Expected %q to be missing with rank %d, got %d (found %v)", missing, expected, rank, found)
+			}
+		}
+	}
+}
+
+func TestCompression(t *testing.T) {
+	keys := sampleKeys(1000)
+	index, _ := New(keys, DefaultBucketSize)
+	raw := 0
+
+	for _, key := range keys {
+		raw += len(key)
+	}
+
+	if index.Bytes() >= raw*3/4 {
+		t.Errorf("Expected front coding to save space, got %d bytes for %d raw bytes", index.Bytes(), raw)
+	}
+}
+
+func TestScanPrefix(t *testing.T) {
+	keys := []string{"app", "apple", "applet", "apply", "apt", "banana", "band"}
+	index, _ := New(keys, 2)
+
+	cases := map[string]string{
+		"appl": "[apple applet apply]",
+		"ap":   "[app apple applet apply apt]",
+		"ban":  "[banana band]",
+		"c":    "[]",
+		"":     fmt.Sprint(keys),
+	}
+
+	for prefix, expected := range cases {
+		found := make([]string, 0)
+
+		for key := range index.WithPrefix(prefix) {
+			found = append(found, key)
+		}
+
+		if fmt.Sprint(found) != expected {
+			t.Errorf("Expected prefix %q to yield %s, got %v", prefix, expected, found)
+		}
+	}
+
+	count := 0
+
+	index.ScanPrefix("ap", func(string) bool {
+		count++
+		return count < 2
+	})
+
+	if count != 2 {
+		t.Errorf("Expected the scan to stop after 2 keys, got %d", count)
+	}
+}
+
+func TestNewRejectsUnsortedKeys(t *testing.T) {
+	if _, err := New([]string{"b", "a"}, 4); err == nil {
+		t.Errorf("Expected an error for unsorted keys")
+	}
+
+	if _, err := New([]string{"a", "a"}, 4); err == nil {
+		t.Errorf("Expected an error for duplicate keys")
+	}
+
+	if _, err := New([]string{"a"}, 0); err == nil {
+		t.Errorf("Expected an error for an invalid bucket size")
+	}
+}
+
+func TestAtOutOfRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	index, _ := New([]string{"a"}, 4)
+	index.At(1)
+}
+
+func TestMarshalBinary(t *testing.T) {
+	keys := sampleKeys(500)
+	index, _ := New(keys, 7)
+	data, _ := index.MarshalBinary()
+
+	var restored Index
+
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for rank, key := range keys {
+		if r, found := restored.Find(key); !found || r != rank {
+			t.Errorf("Expected %q at rank %d, got %d (found %v)", key, rank, r, found)
+		}
+	}
+
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("Expected an error for truncated data")
+	}
+
+	var empty Index
+
+	data, _ = (&Index{bucketSize: 1}).MarshalBinary()
+
+	if err := empty.UnmarshalBinary(data); err != nil || empty.Len() != 0 {
+		t.Errorf("Expected an empty index, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package fulltext
+
+import (
+	"math"
+	"sort"
+
+	"algorithms/hashtable"
+	"algorithms/text"
+)
+
+// Index is an in-memory inverted index. Documents are tokenized with
+// text.Words and numbered from 0 in the order they are added; every term maps
+// to the compressed list of documents holding it.
+type Index struct {
+	terms   *hashtable.HashTable[string, *postings]
+	lengths []int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		terms:   hashtable.NewHashTable[string, *postings](),
+		lengths: make([]int, 0),
+	}
+}
+
+// Add indexes document and returns its ID
+func (x *Index) Add(document string) int {
+	doc := len(x.lengths)
+	frequencies := hashtable.NewCounter[string]()
+
+	text.Words(document).ForEach(func(word string) {
+		frequencies.Add(word, 1)
+	})
+
+	for term, frequency := range frequencies.All() {
+		x.terms.ComputeIfAbsent(term, func(string) *postings {
+			return &postings{}
+		}).add(doc, frequency)
+	}
+
+	x.lengths = append(x.lengths, frequencies.Total())
+
+	return doc
+}
+
+// Len is the number of documents
+func (x *Index) Len() int {
+	return len(x.lengths)
+}
+
+// DocumentFrequency is the number of documents holding term
+func (x *Index) DocumentFrequency(term string) int {
+	if p, found := x.terms.TryGet(term); found {
+		return p.count
+	}
+
+	return 0
+}
+
+// Search returns the IDs of the documents matching q in increasing order
+func (x *Index) Search(q Query) []int {
+	return q.docs(x)
+}
+
+type Result struct {
+	Doc   int
+	Score float64
+}
+
+// Rank scores the documents matching q by TF-IDF over the terms of q outside
+// of any Not, (count / length) * ln(1 + documents / document frequency)
+// summed over the terms, and returns the best limit of them, all of them when
+// limit is not positive, highest score first
+func (x *Index) Rank(q Query, limit int) []Result {
+	matches := x.Search(q)
+	scores := make(map[int]float64, len(matches))
+
+	for _, doc := range matches {
+		scores[doc] = 0
+	}
+
+	seen := hashtable.NewSet[string]()
+
+	for _, term := range q.terms(nil) {
+		p, found := x.terms.TryGet(term)
+
+		if !found || !seen.Add(term) {
+			continue
+		}
+
+		idf := math.Log(1 + float64(x.Len())/float64(p.count))
+
+		p.each(func(doc, frequency int) {
+			if score, matched := scores[doc]; matched {
+				scores[doc] = score + float64(frequency)/float64(x.lengths[doc])*idf
+			}
+		})
+	}
+
+	results := make([]Result, 0, len(matches))
+
+	for _, doc := range matches {
+		results = append(results, Result{Doc: doc, Score: scores[doc]})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
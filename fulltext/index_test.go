@@ -0,0 +1,101 @@
+package fulltext
+
+import (
+	"slices"
+	"testing"
+)
+
+func newTestIndex() *Index {
+	x := NewIndex()
+
+	x.Add("The quick brown fox jumps over the lazy dog")
+	x.Add("A quick brown dog outpaces a quick fox")
+	x.Add("Lazy afternoons are for sleeping dogs")
+	x.Add("The fox, the fox and the hound")
+
+	return x
+}
+
+func TestSearch(t *testing.T) {
+	x := newTestIndex()
+
+	cases := []struct {
+		query    Query
+		expected []int
+	}{
+		{Term("fox"), []int{0, 1, 3}},
+		{Term("FOX!"), []int{0, 1, 3}},
+		{Term("missing"), []int{}},
+		{Term(""), []int{}},
+		{Term("quick fox"), []int{0, 1}},
+		{And(Term("quick"), Term("lazy")), []int{0}},
+		{Or(Term("lazy"), Term("hound")), []int{0, 2, 3}},
+		{Not(Term("fox")), []int{2}},
+		{And(Term("fox"), Not(Term("quick"))), []int{3}},
+		{And(), []int{0, 1, 2, 3}},
+		{Or(), []int{}},
+	}
+
+	for _, c := range cases {
+		if got := x.Search(c.query); !slices.Equal(got, c.expected) {
+			t.Errorf("Expected %v to match %v, got %v", c.query, c.expected, got)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	x := newTestIndex()
+
+	results := x.Rank(Term("fox"), 0)
+
+	if len(results) != 3 || results[0].Doc != 3 {
+		t.Fatalf("Expected document 3 to rank first, got %v", results)
+	}
+
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Errorf("Expected scores in decreasing order, got %v", results)
+		}
+	}
+
+	// quick is rarer than fox, so a document holding it twice wins
+	if top := x.Rank(Or(Term("quick"), Term("fox")), 1); len(top) != 1 || top[0].Doc != 1 {
+		t.Errorf("Expected document 1 to rank first, got %v", top)
+	}
+
+	for _, result := range x.Rank(Not(Term("fox")), 0) {
+		if result.Score != 0 {
+			t.Errorf("Expected negated terms not to score, got %v", result)
+		}
+	}
+}
+
+func TestDocumentFrequency(t *testing.T) {
+	x := newTestIndex()
+
+	if x.DocumentFrequency("the") != 2 || x.DocumentFrequency("nothing") != 0 || x.Len() != 4 {
+		t.Errorf("Expected the in 2 of 4 documents, got %d of %d", x.DocumentFrequency("the"), x.Len())
+	}
+}
+
+func TestPostingsCompression(t *testing.T) {
+	p := postings{}
+
+	for doc := 0; doc < 1000; doc += 3 {
+		p.add(doc, doc%5+1)
+	}
+
+	if len(p.data) != 2*p.count {
+		t.Errorf("Expected small gaps and counts to take a byte each, got %d bytes for %d postings", len(p.data), p.count)
+	}
+
+	doc := 0
+
+	p.each(func(got, frequency int) {
+		if got != doc || frequency != doc%5+1 {
+			t.Errorf("Expected posting (%d, %d), got (%d, %d)", doc, doc%5+1, got, frequency)
+		}
+
+		doc += 3
+	})
+}
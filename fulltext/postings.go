@@ -0,0 +1,103 @@
+package fulltext
+
+import "encoding/binary"
+
+// postings lists the documents holding a term in increasing order, each as
+// the uvarint gap from the previous document followed by the uvarint count
+// of the term in it. Documents are added with increasing IDs, so the list is
+// only ever appended to.
+type postings struct {
+	data  []byte
+	last  int
+	count int
+}
+
+func (p *postings) add(doc, frequency int) {
+	p.data = binary.AppendUvarint(p.data, uint64(doc-p.last))
+	p.data = binary.AppendUvarint(p.data, uint64(frequency))
+	p.last = doc
+	p.count++
+}
+
+func (p *postings) each(f func(doc, frequency int)) {
+	doc := 0
+
+	for offset := 0; offset < len(p.data); {
+		gap, n := binary.Uvarint(p.data[offset:])
+		offset += n
+		frequency, n := binary.Uvarint(p.data[offset:])
+		offset += n
+
+		doc += int(gap)
+		f(doc, int(frequency))
+	}
+}
+
+func (p *postings) docs() []int {
+	docs := make([]int, 0, p.count)
+
+	p.each(func(doc, _ int) {
+		docs = append(docs, doc)
+	})
+
+	return docs
+}
+
+func intersect(a, b []int) []int {
+	result := make([]int, 0, min(len(a), len(b)))
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+func union(a, b []int) []int {
+	result := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	result = append(result, a[i:]...)
+
+	return append(result, b[j:]...)
+}
+
+// complement returns the documents below n missing from the sorted docs
+func complement(docs []int, n int) []int {
+	result := make([]int, 0, n-len(docs))
+
+	for doc, i := 0, 0; doc < n; doc++ {
+		if i < len(docs) && docs[i] == doc {
+			i++
+			continue
+		}
+
+		result = append(result, doc)
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+package fulltext
+
+import (
+	"strings"
+
+	"algorithms/text"
+)
+
+type queryKind int
+
+const (
+	termQuery queryKind = iota
+	andQuery
+	orQuery
+	notQuery
+)
+
+// Query is a boolean combination of terms, built with Term, And, Or and Not
+type Query struct {
+	kind     queryKind
+	term     string
+	children []Query
+}
+
+// Term matches the documents holding word, normalized like indexed text. A
+// word that tokenizes into several terms matches documents holding them all,
+// one without any term matches nothing.
+func Term(word string) Query {
+	terms := text.Words(word).Slice()
+
+	switch len(terms) {
+	case 0:
+		return Or()
+	case 1:
+		return Query{kind: termQuery, term: terms[0]}
+	}
+
+	children := make([]Query, len(terms))
+
+	for i, term := range terms {
+		children[i] = Query{kind: termQuery, term: term}
+	}
+
+	return And(children...)
+}
+
+// And matches the documents matching every query, all documents for none
+func And(queries ...Query) Query {
+	return Query{kind: andQuery, children: queries}
+}
+
+// Or matches the documents matching any query, no document for none
+func Or(queries ...Query) Query {
+	return Query{kind: orQuery, children: queries}
+}
+
+// Not matches the documents not matching q
+func Not(q Query) Query {
+	return Query{kind: notQuery, children: []Query{q}}
+}
+
+func (q Query) docs(x *Index) []int {
+	switch q.kind {
+	case termQuery:
+		if p, found := x.terms.TryGet(q.term); found {
+			return p.docs()
+		}
+
+		return []int{}
+	case andQuery:
+		docs := complement(nil, x.Len())
+
+		for _, child := range q.children {
+			docs = intersect(docs, child.docs(x))
+		}
+
+		return docs
+	case orQuery:
+		docs := []int{}
+
+		for _, child := range q.children {
+			docs = union(docs, child.docs(x))
+		}
+
+		return docs
+	}
+
+	return complement(q.children[0].docs(x), x.Len())
+}
+
+// terms appends the terms contributing to ranking, which leaves out negated
+// ones
+func (q Query) terms(terms []string) []string {
+	switch q.kind {
+	case termQuery:
+		return append(terms, q.term)
+	case notQuery:
+		return terms
+	}
+
+	for _, child := range q.children {
+		terms = child.terms(terms)
+	}
+
+	return terms
+}
+
+func (q Query) String() string {
+	switch q.kind {
+	case termQuery:
+		return q.term
+	case notQuery:
+		return "NOT " + q.children[0].String()
+	}
+
+	operator := " AND "
+
+	if q.kind == orQuery {
+		operator = " OR "
+	}
+
+	parts := make([]string, len(q.children))
+
+	for i, child := range q.children {
+		parts[i] = child.String()
+	}
+
+	return "(" + strings.Join(parts, operator) + ")"
+}
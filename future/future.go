@@ -0,0 +1,245 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrEmpty = errors.New("future: no futures to wait for")
+
+// Future holds a value or an error that becomes available later. It settles
+// exactly once, by Resolve or Reject, and every waiter sees the same result.
+type Future[T any] struct {
+	once  sync.Once
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func New[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+func Resolved[T any](value T) *Future[T] {
+	f := New[T]()
+	f.Resolve(value)
+
+	return f
+}
+
+func Rejected[T any](err error) *Future[T] {
+	f := New[T]()
+	f.Reject(err)
+
+	return f
+}
+
+// Go runs fn in a goroutine and settles the future with its result
+func Go[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Future[T] {
+	f := New[T]()
+
+	go func() {
+		f.settle(fn(ctx))
+	}()
+
+	return f
+}
+
+// Resolve reports whether it settled the future, which only the first call
+// to Resolve or Reject does
+func (f *Future[T]) Resolve(value T) bool {
+	return f.settle(value, nil)
+}
+
+func (f *Future[T]) Reject(err error) bool {
+	var zero T
+
+	return f.settle(zero, err)
+}
+
+func (f *Future[T]) settle(value T, err error) bool {
+	settled := false
+
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		settled = true
+		close(f.done)
+	})
+
+	return settled
+}
+
+// Done is closed once the future settles
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the future settles or ctx is done
+func (f *Future[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func (f *Future[T]) Settled() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Then derives a future from the value of f. A rejection of f skips fn and
+// rejects the derived future with the same error. When ctx is done before f
+// settles the derived future rejects with the error of ctx, which also frees
+// the goroutine waiting on f.
+func Then[T, U any](ctx context.Context, f *Future[T], fn func(T) (U, error)) *Future[U] {
+	next := New[U]()
+
+	go func() {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			next.Reject(ctx.Err())
+			return
+		}
+
+		if f.err != nil {
+			next.Reject(f.err)
+			return
+		}
+
+		next.settle(fn(f.value))
+	}()
+
+	return next
+}
+
+// Catch derives a future that recovers from a rejection of f with fn, and
+// passes a resolved value through. Like Then it rejects with the error of ctx
+// when ctx is done before f settles.
+func (f *Future[T]) Catch(ctx context.Context, fn func(error) (T, error)) *Future[T] {
+	next := New[T]()
+
+	go func() {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			next.Reject(ctx.Err())
+			return
+		}
+
+		if f.err == nil {
+			next.Resolve(f.value)
+			return
+		}
+
+		next.settle(fn(f.err))
+	}()
+
+	return next
+}
+
+// All resolves with every value in order once all futures resolve, or
+// rejects with the first rejection or the error of ctx
+func All[T any](ctx context.Context, futures ...*Future[T]) *Future[[]T] {
+	all := New[[]T]()
+
+	go func() {
+		values := make([]T, len(futures))
+		pending := len(futures)
+		settled := make(chan int, len(futures))
+
+		for i, f := range futures {
+			go func() {
+				select {
+				case <-f.done:
+					settled <- i
+				case <-all.done:
+				}
+			}()
+		}
+
+		for pending > 0 {
+			select {
+			case i := <-settled:
+				if err := futures[i].err; err != nil {
+					all.Reject(err)
+					return
+				}
+
+				values[i] = futures[i].value
+				pending--
+			case <-ctx.Done():
+				all.Reject(ctx.Err())
+				return
+			}
+		}
+
+		all.Resolve(values)
+	}()
+
+	return all
+}
+
+// Any resolves with the first value to resolve, and rejects with every
+// error joined when all futures reject
+func Any[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return first(ctx, futures, false)
+}
+
+// Race settles like the first future to settle
+func Race[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return first(ctx, futures, true)
+}
+
+func first[T any](ctx context.Context, futures []*Future[T], acceptErrors bool) *Future[T] {
+	winner := New[T]()
+
+	if len(futures) == 0 {
+		winner.Reject(ErrEmpty)
+		return winner
+	}
+
+	go func() {
+		settled := make(chan int, len(futures))
+		errs := make([]error, len(futures))
+
+		for i, f := range futures {
+			go func() {
+				select {
+				case <-f.done:
+					settled <- i
+				case <-winner.done:
+				}
+			}()
+		}
+
+		for pending := len(futures); pending > 0; pending-- {
+			select {
+			case i := <-settled:
+				f := futures[i]
+
+				if f.err == nil || acceptErrors {
+					winner.settle(f.value, f.err)
+					return
+				}
+
+				errs[i] = f.err
+			case <-ctx.Done():
+				winner.Reject(ctx.Err())
+				return
+			}
+		}
+
+		winner.Reject(errors.Join(errs...))
+	}()
+
+	return winner
+}
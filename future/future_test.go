@@ -0,0 +1,153 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+func after[T any](d time.Duration, value T, err error) *Future[T] {
+	return Go(context.Background(), func(context.Context) (T, error) {
+		time.Sleep(d)
+		return value, err
+	})
+}
+
+func TestResolveAndReject(t *testing.T) {
+	f := New[int]()
+
+	if f.Settled() {
+		t.Errorf("Expected a new future to be pending")
+	}
+
+	if !f.Resolve(1) || f.Resolve(2) || f.Reject(errBoom) {
+		t.Errorf("Expected only the first settlement to win")
+	}
+
+	if value, err := f.Wait(context.Background()); err != nil || value != 1 {
+		t.Errorf("Expected value to be 1, got %d (%v)", value, err)
+	}
+
+	if _, err := Rejected[int](errBoom).Wait(context.Background()); err != errBoom {
+		t.Errorf("Expected errBoom, got %v", err)
+	}
+}
+
+func TestWaitHonoursContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := New[int]().Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestThenAndCatch(t *testing.T) {
+	ctx := context.Background()
+
+	text := Then(ctx, Resolved(41), func(n int) (string, error) {
+		return strconv.Itoa(n + 1), nil
+	})
+
+	if value, _ := text.Wait(ctx); value != "42" {
+		t.Errorf("Expected value to be 42, got %q", value)
+	}
+
+	calls := 0
+	skipped := Then(ctx, Rejected[int](errBoom), func(n int) (int, error) {
+		calls++
+		return n, nil
+	})
+
+	if _, err := skipped.Wait(ctx); err != errBoom || calls != 0 {
+		t.Errorf("Expected the rejection to skip Then, got %v after %d calls", err, calls)
+	}
+
+	recovered := skipped.Catch(ctx, func(err error) (int, error) {
+		return -1, nil
+	})
+
+	if value, err := recovered.Wait(ctx); err != nil || value != -1 {
+		t.Errorf("Expected Catch to recover with -1, got %d (%v)", value, err)
+	}
+
+	if value, _ := Resolved(7).Catch(ctx, func(error) (int, error) { return 0, nil }).Wait(ctx); value != 7 {
+		t.Errorf("Expected Catch to pass 7 through, got %d", value)
+	}
+}
+
+func TestThenAndCatchHonourContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pending := New[int]()
+
+	then := Then(ctx, pending, func(n int) (int, error) {
+		return n, nil
+	})
+
+	caught := pending.Catch(ctx, func(err error) (int, error) {
+		return 0, nil
+	})
+
+	cancel()
+
+	for _, f := range []*Future[int]{then, caught} {
+		if _, err := f.Wait(context.Background()); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	ctx := context.Background()
+
+	values, err := All(ctx, after(20*time.Millisecond, 1, nil), after(0, 2, nil), Resolved(3)).Wait(ctx)
+
+	if err != nil || fmt.Sprint(values) != "[1 2 3]" {
+		t.Errorf("Expected [1 2 3], got %v (%v)", values, err)
+	}
+
+	if _, err := All(ctx, New[int](), Rejected[int](errBoom)).Wait(ctx); err != errBoom {
+		t.Errorf("Expected the first rejection, got %v", err)
+	}
+
+	if values, err := All[int](ctx).Wait(ctx); err != nil || len(values) != 0 {
+		t.Errorf("Expected no values, got %v (%v)", values, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err := All(cancelled, New[int]()).Wait(ctx); err != context.Canceled {
+		t.Errorf("Expected Canceled, got %v", err)
+	}
+}
+
+func TestAnyAndRace(t *testing.T) {
+	ctx := context.Background()
+
+	slow := after(30*time.Millisecond, "slow", nil)
+	failing := after(0, "", errBoom)
+
+	if value, err := Any(ctx, slow, failing).Wait(ctx); err != nil || value != "slow" {
+		t.Errorf("Expected Any to skip the rejection, got %q (%v)", value, err)
+	}
+
+	if _, err := Race(ctx, after(30*time.Millisecond, "slow", nil), after(0, "", errBoom)).Wait(ctx); err != errBoom {
+		t.Errorf("Expected Race to settle with the first rejection, got %v", err)
+	}
+
+	other := errors.New("other")
+
+	if _, err := Any(ctx, Rejected[int](errBoom), Rejected[int](other)).Wait(ctx); !errors.Is(err, errBoom) || !errors.Is(err, other) {
+		t.Errorf("Expected every error joined, got %v", err)
+	}
+
+	if _, err := Race[int](ctx).Wait(ctx); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+}
@@ -0,0 +1,10 @@
+package game
+
+type State[M any] interface {
+	Moves() []M
+	Play(move M) State[M]
+	IsTerminal() bool
+	// Evaluate scores the state from the point of view of the player to move
+	Evaluate() float64
+	Hash() uint64
+}
@@ -0,0 +1,175 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+type RolloutPolicy[M comparable] func(state State[M], random *rand.Rand) M
+
+func RandomRollout[M comparable](state State[M], random *rand.Rand) M {
+	moves := state.Moves()
+
+	return moves[random.Intn(len(moves))]
+}
+
+type mctsNode[M comparable] struct {
+	state    State[M]
+	move     M
+	parent   *mctsNode[M]
+	children []*mctsNode[M]
+	untried  []M
+	visits   float64
+	// total reward from the point of view of the player who made move
+	total float64
+}
+
+type MCTS[M comparable] struct {
+	Exploration  float64
+	RolloutDepth int
+	Workers      int
+	Rollout      RolloutPolicy[M]
+	seed         int64
+}
+
+func NewMCTS[M comparable](seed int64) *MCTS[M] {
+	return &MCTS[M]{
+		Exploration:  math.Sqrt2,
+		RolloutDepth: 1000,
+		Workers:      1,
+		Rollout:      RandomRollout[M],
+		seed:         seed,
+	}
+}
+
+func (m *MCTS[M]) Search(state State[M], iterations int) (best M) {
+	workers := m.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	roots := make([]*mctsNode[M], workers)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		share := iterations / workers
+		if i < iterations%workers {
+			share++
+		}
+
+		wg.Add(1)
+
+		go func(worker, share int) {
+			defer wg.Done()
+
+			random := rand.New(rand.NewSource(m.seed + int64(worker)))
+			roots[worker] = m.grow(state, share, random)
+		}(i, share)
+	}
+
+	wg.Wait()
+
+	// Root parallelization: merge the visit counts of every worker tree
+	visits := make(map[M]float64)
+	order := make([]M, 0)
+
+	for _, root := range roots {
+		for _, child := range root.children {
+			if _, seen := visits[child.move]; !seen {
+				order = append(order, child.move)
+			}
+
+			visits[child.move] += child.visits
+		}
+	}
+
+	mostVisited := -1.0
+
+	for _, move := range order {
+		if visits[move] > mostVisited {
+			best, mostVisited = move, visits[move]
+		}
+	}
+
+	return
+}
+
+func (m *MCTS[M]) grow(state State[M], iterations int, random *rand.Rand) *mctsNode[M] {
+	root := newMctsNode[M](state, nil)
+
+	for i := 0; i < iterations; i++ {
+		node := root
+
+		for len(node.untried) == 0 && len(node.children) > 0 {
+			node = m.selectChild(node)
+		}
+
+		if len(node.untried) > 0 {
+			index := random.Intn(len(node.untried))
+			move := node.untried[index]
+
+			node.untried[index] = node.untried[len(node.untried)-1]
+			node.untried = node.untried[:len(node.untried)-1]
+
+			child := newMctsNode(node.state.Play(move), node)
+			child.move = move
+			node.children = append(node.children, child)
+			node = child
+		}
+
+		reward := -m.simulate(node.state, random)
+
+		for ; node != nil; node = node.parent {
+			node.visits++
+			node.total += reward
+			reward = -reward
+		}
+	}
+
+	return root
+}
+
+func (m *MCTS[M]) selectChild(node *mctsNode[M]) (best *mctsNode[M]) {
+	bestScore := math.Inf(-1)
+	logVisits := math.Log(node.visits)
+
+	for _, child := range node.children {
+		score := child.total/child.visits + m.Exploration*math.Sqrt(logVisits/child.visits)
+
+		if score > bestScore {
+			best, bestScore = child, score
+		}
+	}
+
+	return
+}
+
+// simulate returns the rollout value from the point of view of the player to move in state
+func (m *MCTS[M]) simulate(state State[M], random *rand.Rand) float64 {
+	sign := 1.0
+
+	for depth := 0; depth < m.RolloutDepth && !state.IsTerminal(); depth++ {
+		if len(state.Moves()) == 0 {
+			break
+		}
+
+		state = state.Play(m.Rollout(state, random))
+		sign = -sign
+	}
+
+	return sign * state.Evaluate()
+}
+
+func newMctsNode[M comparable](state State[M], parent *mctsNode[M]) *mctsNode[M] {
+	node := mctsNode[M]{
+		state:  state,
+		parent: parent,
+	}
+
+	if !state.IsTerminal() {
+		node.untried = state.Moves()
+	}
+
+	return &node
+}
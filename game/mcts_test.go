@@ -0,0 +1,42 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMCTSFindsWinningMove(t *testing.T) {
+	mcts := NewMCTS[int](1)
+
+	for _, stones := range []int{5, 6, 7} {
+		move := mcts.Search(nim{stones: stones}, 5000)
+
+		if move != stones%4 {
+			t.Errorf("Expected move to be %d for %d stones, got %d", stones%4, stones, move)
+		}
+	}
+}
+
+func TestParallelMCTSFindsWinningMove(t *testing.T) {
+	mcts := NewMCTS[int](1)
+	mcts.Workers = 4
+
+	move := mcts.Search(nim{stones: 6}, 8000)
+
+	if move != 2 {
+		t.Errorf("Expected move to be 2, got %d", move)
+	}
+}
+
+func TestMCTSWithCustomRollout(t *testing.T) {
+	mcts := NewMCTS[int](1)
+	mcts.Rollout = func(state State[int], _ *rand.Rand) int {
+		return state.Moves()[0]
+	}
+
+	move := mcts.Search(nim{stones: 1}, 10)
+
+	if move != 1 {
+		t.Errorf("Expected move to be 1, got %d", move)
+	}
+}
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"math"
+	"time"
+
+	"algorithms/hashtable"
+)
+
+type bound uint8
+
+const (
+	exact bound = iota
+	lower
+	upper
+)
+
+type transposition[M any] struct {
+	depth   int
+	score   float64
+	bound   bound
+	move    M
+	hasMove bool
+}
+
+type Minimax[M comparable] struct {
+	table    *hashtable.HashTable[uint64, transposition[M]]
+	deadline time.Time
+	aborted  bool
+	Nodes    uint64
+}
+
+func NewMinimax[M comparable]() *Minimax[M] {
+	return &Minimax[M]{
+		table: hashtable.NewHashTable[uint64, transposition[M]](),
+	}
+}
+
+func (m *Minimax[M]) Reset() {
+	m.table = hashtable.NewHashTable[uint64, transposition[M]]()
+	m.Nodes = 0
+}
+
+func (m *Minimax[M]) Search(state State[M], depth int) (best M, score float64) {
+	m.deadline = time.Time{}
+	m.aborted = false
+
+	best, score, _ = m.searchRoot(state, depth)
+
+	return
+}
+
+func (m *Minimax[M]) IterativeDeepening(state State[M], maxDepth int, budget time.Duration) (best M, score float64, depth int) {
+	m.deadline = time.Now().Add(budget)
+	m.aborted = false
+
+	for d := 1; d <= maxDepth; d++ {
+		move, value, ok := m.searchRoot(state, d)
+
+		if m.aborted {
+			break
+		}
+
+		if ok {
+			best, score, depth = move, value, d
+		}
+	}
+
+	return
+}
+
+func (m *Minimax[M]) searchRoot(state State[M], depth int) (best M, score float64, ok bool) {
+	alpha, beta := math.Inf(-1), math.Inf(1)
+	score = math.Inf(-1)
+
+	for _, move := range m.orderedMoves(state) {
+		value := -m.negamax(state.Play(move), depth-1, -beta, -alpha)
+
+		if m.aborted {
+			return
+		}
+
+		if !ok || value > score {
+			best, score, ok = move, value, true
+		}
+
+		alpha = math.Max(alpha, value)
+	}
+
+	if ok {
+		m.table.Insert(state.Hash(), transposition[M]{
+			depth:   depth,
+			score:   score,
+			bound:   exact,
+			move:    best,
+			hasMove: true,
+		})
+	}
+
+	return
+}
+
+func (m *Minimax[M]) negamax(state State[M], depth int, alpha, beta float64) float64 {
+	m.Nodes++
+
+	if !m.deadline.IsZero() && m.Nodes&1023 == 0 && time.Now().After(m.deadline) {
+		m.aborted = true
+	}
+
+	if m.aborted {
+		return 0
+	}
+
+	if depth <= 0 || state.IsTerminal() {
+		return state.Evaluate()
+	}
+
+	originalAlpha := alpha
+	key := state.Hash()
+
+	if entry, found := m.lookup(key); found && entry.depth >= depth {
+		switch entry.bound {
+		case exact:
+			return entry.score
+		case lower:
+			alpha = math.Max(alpha, entry.score)
+		case upper:
+			beta = math.Min(beta, entry.score)
+		}
+
+		if alpha >= beta {
+			return entry.score
+		}
+	}
+
+	best := math.Inf(-1)
+	var bestMove M
+	hasMove := false
+
+	for _, move := range m.orderedMoves(state) {
+		value := -m.negamax(state.Play(move), depth-1, -beta, -alpha)
+
+		if m.aborted {
+			return 0
+		}
+
+		if !hasMove || value > best {
+			best, bestMove, hasMove = value, move, true
+		}
+
+		alpha = math.Max(alpha, value)
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	if !hasMove {
+		return state.Evaluate()
+	}
+
+	entry := transposition[M]{
+		depth:   depth,
+		score:   best,
+		bound:   exact,
+		move:    bestMove,
+		hasMove: true,
+	}
+
+	if best <= originalAlpha {
+		entry.bound = upper
+	} else if best >= beta {
+		entry.bound = lower
+	}
+
+	m.table.Insert(key, entry)
+
+	return best
+}
+
+// Try the best move of a previous search first to improve pruning
+func (m *Minimax[M]) orderedMoves(state State[M]) []M {
+	moves := state.Moves()
+
+	entry, found := m.lookup(state.Hash())
+
+	if !found || !entry.hasMove {
+		return moves
+	}
+
+	for i, move := range moves {
+		if move == entry.move {
+			moves[0], moves[i] = moves[i], moves[0]
+			break
+		}
+	}
+
+	return moves
+}
+
+func (m *Minimax[M]) lookup(key uint64) (transposition[M], bool) {
+	return m.table.TryGet(key)
+}
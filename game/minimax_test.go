@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// Players alternate taking one to three stones, whoever takes the last stone wins
+type nim struct {
+	stones int
+}
+
+func (n nim) Moves() []int {
+	moves := make([]int, 0, 3)
+
+	for take := 1; take <= 3 && take <= n.stones; take++ {
+		moves = append(moves, take)
+	}
+
+	return moves
+}
+
+func (n nim) Play(take int) State[int] {
+	return nim{stones: n.stones - take}
+}
+
+func (n nim) IsTerminal() bool {
+	return n.stones == 0
+}
+
+func (n nim) Evaluate() float64 {
+	if n.stones == 0 {
+		return -1
+	}
+
+	return 0
+}
+
+func (n nim) Hash() uint64 {
+	return uint64(n.stones)
+}
+
+func TestMinimaxFindsWinningMove(t *testing.T) {
+	for stones := 5; stones <= 11; stones++ {
+		if stones%4 == 0 {
+			continue
+		}
+
+		minimax := NewMinimax[int]()
+		move, score := minimax.Search(nim{stones: stones}, stones)
+
+		if move != stones%4 {
+			t.Errorf("Expected move to be %d for %d stones, got %d", stones%4, stones, move)
+		}
+
+		if score != 1 {
+			t.Errorf("Expected score to be 1 for %d stones, got %f", stones, score)
+		}
+	}
+}
+
+func TestMinimaxDetectsLosingPosition(t *testing.T) {
+	minimax := NewMinimax[int]()
+	_, score := minimax.Search(nim{stones: 12}, 12)
+
+	if score != -1 {
+		t.Errorf("Expected score to be -1, got %f", score)
+	}
+}
+
+func TestIterativeDeepeningReachesMaxDepth(t *testing.T) {
+	minimax := NewMinimax[int]()
+	move, score, depth := minimax.IterativeDeepening(nim{stones: 10}, 10, time.Second)
+
+	if depth != 10 {
+		t.Errorf("Expected depth to be 10, got %d", depth)
+	}
+
+	if move != 2 || score != 1 {
+		t.Errorf("Expected move 2 with score 1, got %d with %f", move, score)
+	}
+}
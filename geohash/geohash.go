@@ -0,0 +1,204 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+	// MaxPrecision is the longest hash whose 60 bits fit in a uint64
+	MaxPrecision = 12
+
+	// EarthRadius is the mean radius in meters used by Distance
+	EarthRadius = 6371008.8
+)
+
+// Box is the cell of a geohash, in degrees
+type Box struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+}
+
+func (b Box) Center() (lat, lon float64) {
+	return (b.MinLat + b.MaxLat) / 2, (b.MinLon + b.MaxLon) / 2
+}
+
+// Encode returns the geohash of the given precision in characters, whose
+// cell contains the point. Hashes sharing a prefix lie in the cell of that
+// prefix, so sorted hashes group nearby points.
+func Encode(lat, lon float64, precision int) string {
+	checkPrecision(precision)
+
+	if !(lat >= -90 && lat <= 90) || !(lon >= -180 && lon <= 180) {
+		msg := fmt.Sprintf("invalid coordinates: (%v, %v)", lat, lon)
+		panic(errors.New(msg))
+	}
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	hash := make([]byte, precision)
+	even := true
+
+	for i := range hash {
+		index := 0
+
+		for bit := 0; bit < 5; bit++ {
+			value, interval := lat, &latRange
+
+			if even {
+				value, interval = lon, &lonRange
+			}
+
+			middle := (interval[0] + interval[1]) / 2
+			index <<= 1
+
+			if value >= middle {
+				index |= 1
+				interval[0] = middle
+			} else {
+				interval[1] = middle
+			}
+
+			even = !even
+		}
+
+		hash[i] = alphabet[index]
+	}
+
+	return string(hash)
+}
+
+func checkPrecision(precision int) {
+	if precision <= 0 || precision > MaxPrecision {
+		msg := fmt.Sprintf("invalid precision: %d", precision)
+		panic(errors.New(msg))
+	}
+}
+
+// DecodeBox returns the cell of hash
+func DecodeBox(hash string) (Box, error) {
+	box := Box{MinLat: -90, MaxLat: 90, MinLon: -180, MaxLon: 180}
+	even := true
+
+	for i := 0; i < len(hash); i++ {
+		index := strings.IndexByte(alphabet, hash[i])
+
+		if index < 0 {
+			msg := fmt.Sprintf("invalid geohash character %q in %q", hash[i], hash)
+			return Box{}, errors.New(msg)
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			set := index>>bit&1 == 1
+
+			if even {
+				middle := (box.MinLon + box.MaxLon) / 2
+
+				if set {
+					box.MinLon = middle
+				} else {
+					box.MaxLon = middle
+				}
+			} else {
+				middle := (box.MinLat + box.MaxLat) / 2
+
+				if set {
+					box.MinLat = middle
+				} else {
+					box.MaxLat = middle
+				}
+			}
+
+			even = !even
+		}
+	}
+
+	return box, nil
+}
+
+// Decode returns the center of the cell of hash
+func Decode(hash string) (lat, lon float64, err error) {
+	box, err := DecodeBox(hash)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lat, lon = box.Center()
+
+	return lat, lon, nil
+}
+
+// Neighbor returns the hash of the same precision dlat cells north and dlon
+// cells east of hash, wrapping around the antimeridian. There is no neighbor
+// beyond a pole.
+func Neighbor(hash string, dlat, dlon int) (string, bool, error) {
+	box, err := DecodeBox(hash)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	lat, lon := box.Center()
+	lat += float64(dlat) * (box.MaxLat - box.MinLat)
+	lon += float64(dlon) * (box.MaxLon - box.MinLon)
+
+	if lat < -90 || lat > 90 {
+		return "", false, nil
+	}
+
+	lon = math.Mod(lon+180, 360)
+
+	if lon < 0 {
+		lon += 360
+	}
+
+	return Encode(lat, lon-180, len(hash)), true, nil
+}
+
+// Neighbors returns the up to 8 cells around hash, fewer next to a pole
+func Neighbors(hash string) ([]string, error) {
+	neighbors := make([]string, 0, 8)
+
+	for dlat := -1; dlat <= 1; dlat++ {
+		for dlon := -1; dlon <= 1; dlon++ {
+			if dlat == 0 && dlon == 0 {
+				continue
+			}
+
+			neighbor, found, err := Neighbor(hash, dlat, dlon)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				neighbors = append(neighbors, neighbor)
+			}
+		}
+	}
+
+	return neighbors, nil
+}
+
+// Distance is the great-circle distance in meters between two points
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := radians(lat1), radians(lat2)
+	dphi, dlambda := phi2-phi1, radians(lon2-lon1)
+
+	a := math.Sin(dphi/2)*math.Sin(dphi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dlambda/2)*math.Sin(dlambda/2)
+
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
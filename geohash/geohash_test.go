@@ -0,0 +1,159 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	if hash := Encode(57.64911, 10.40744, 11); hash != "u4pruydqqvj" {
+		t.Errorf("Expected hash to be u4pruydqqvj, got %s", hash)
+	}
+
+	if hash := Encode(-90, -180, 3); hash != "000" {
+		t.Errorf("Expected hash to be 000, got %s", hash)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	lat, lon, err := Decode("u4pruydqqvj")
+
+	if err != nil || math.Abs(lat-57.64911) > 1e-5 || math.Abs(lon-10.40744) > 1e-5 {
+		t.Errorf("Expected (57.64911, 10.40744), got (%v, %v) %v", lat, lon, err)
+	}
+
+	box, _ := DecodeBox("u4pruydqqvj")
+
+	if !(box.MinLat <= 57.64911 && 57.64911 <= box.MaxLat && box.MinLon <= 10.40744 && 10.40744 <= box.MaxLon) {
+		t.Errorf("Expected the box to contain the point, got %+v", box)
+	}
+
+	if _, _, err := Decode("u4a"); err == nil {
+		t.Errorf("Expected an error for an invalid character")
+	}
+}
+
+func TestInvalidPrecision(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Encode(0, 0, MaxPrecision+1)
+}
+
+func TestNeighbors(t *testing.T) {
+	neighbors, err := Neighbors("gbsuv")
+	expected := []string{"gbsut", "gbsuw", "gbsus", "gbsuy", "gbsuu", "gbsvh", "gbsvj", "gbsvn"}
+
+	slices.Sort(neighbors)
+	slices.Sort(expected)
+
+	if err != nil || !slices.Equal(neighbors, expected) {
+		t.Errorf("Expected %v, got %v (%v)", expected, neighbors, err)
+	}
+
+	if east, _, _ := Neighbor(Encode(0, 179.99, 4), 0, 1); east != Encode(0, -179.99, 4) {
+		t.Errorf("Expected the east neighbor to wrap around, got %s", east)
+	}
+
+	if _, found, _ := Neighbor(Encode(89.99, 0, 4), 1, 0); found {
+		t.Errorf("Expected no neighbor beyond the pole")
+	}
+
+	if polar, _ := Neighbors(Encode(89.99, 0, 4)); len(polar) != 5 {
+		t.Errorf("Expected 5 neighbors next to the pole, got %v", polar)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	// Paris to London
+	if d := Distance(48.8566, 2.3522, 51.5074, -0.1278); math.Abs(d-343_500) > 1000 {
+		t.Errorf("Expected about 343.5 km, got %v", d)
+	}
+}
+
+func TestCover(t *testing.T) {
+	cells := Cover(48.8566, 2.3522, 1000)
+
+	if len(cells) != 9 || len(cells[0]) != 5 {
+		t.Errorf("Expected 9 cells of precision 5, got %v", cells)
+	}
+
+	if cells := Cover(89.9, 0, 50_000); !slices.Equal(cells, []string{""}) {
+		t.Errorf("Expected a polar circle to match everything, got %v", cells)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	index := NewIndex[int](9)
+	lat, lon := 60.0, 179.95
+
+	type point struct{ lat, lon float64 }
+
+	points := make([]point, 2000)
+
+	for i := range points {
+		points[i] = point{lat + random.Float64()*0.4 - 0.2, lon + random.Float64()*0.4 - 0.2}
+
+		if points[i].lon > 180 {
+			points[i].lon -= 360
+		}
+
+		index.Add(points[i].lat, points[i].lon, i)
+	}
+
+	for _, radius := range []float64{100, 2000, 10_000} {
+		expected := make([]int, 0)
+
+		for i, p := range points {
+			if Distance(lat, lon, p.lat, p.lon) <= radius {
+				expected = append(expected, i)
+			}
+		}
+
+		found := index.Within(lat, lon, radius)
+		got := make([]int, len(found))
+
+		for i, p := range found {
+			got[i] = p.Value
+
+			if i > 0 && Distance(lat, lon, p.Lat, p.Lon) < Distance(lat, lon, found[i-1].Lat, found[i-1].Lon) {
+				t.Errorf("Expected points nearest first")
+			}
+		}
+
+		slices.Sort(got)
+
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected %d points within %vm, got %d", len(expected), radius, len(got))
+		}
+	}
+
+	if index.Len() != 2000 {
+		t.Errorf("Expected index to hold 2000 points, got %d", index.Len())
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	index := NewIndex[string](6)
+
+	for i := 0; i < 3; i++ {
+		index.Add(57.649, 10.407, fmt.Sprint(i))
+	}
+
+	index.Add(-33.86, 151.2, "sydney")
+
+	if points := index.WithPrefix("u4p"); len(points) != 3 {
+		t.Errorf("Expected 3 points in u4p, got %d", len(points))
+	}
+
+	if points := index.WithPrefix(""); len(points) != 4 {
+		t.Errorf("Expected every point for the empty prefix, got %d", len(points))
+	}
+}
@@ -0,0 +1,157 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Cover returns geohash prefixes whose cells together contain every point
+// within radius meters of the given one: the cell of the point and its
+// neighbors, at the longest precision whose cells span the radius in both
+// directions. When the circle reaches a pole or is too large for any
+// precision, Cover returns the empty prefix, matching everything.
+func Cover(lat, lon, radius float64) []string {
+	angle := radius / EarthRadius
+
+	if angle >= math.Pi/2-radians(math.Abs(lat)) {
+		return []string{""}
+	}
+
+	// Bounding box of a spherical cap: the widest longitude span is reached
+	// off the center parallel, towards the nearer pole
+	latSpan := degrees(angle)
+	lonSpan := degrees(math.Asin(math.Sin(angle) / math.Cos(radians(lat))))
+
+	for precision := MaxPrecision; precision > 0; precision-- {
+		lonBits := (5*precision + 1) / 2
+		latBits := 5 * precision / 2
+
+		if 180/math.Exp2(float64(latBits)) < latSpan || 360/math.Exp2(float64(lonBits)) < lonSpan {
+			continue
+		}
+
+		center := Encode(lat, lon, precision)
+		neighbors, _ := Neighbors(center)
+
+		return dedupe(append([]string{center}, neighbors...))
+	}
+
+	return []string{""}
+}
+
+func dedupe(hashes []string) []string {
+	sort.Strings(hashes)
+
+	unique := hashes[:0]
+
+	for i, hash := range hashes {
+		if i == 0 || hash != hashes[i-1] {
+			unique = append(unique, hash)
+		}
+	}
+
+	return unique
+}
+
+type Point[V any] struct {
+	Lat, Lon float64
+	Hash     string
+	Value    V
+}
+
+// Index keeps points sorted by geohash, so the points of a cell are the
+// contiguous run of hashes starting with its prefix
+type Index[V any] struct {
+	precision int
+	points    []Point[V]
+}
+
+func NewIndex[V any](precision int) *Index[V] {
+	checkPrecision(precision)
+
+	return &Index[V]{precision: precision, points: make([]Point[V], 0)}
+}
+
+func (x *Index[V]) Len() int {
+	return len(x.points)
+}
+
+func (x *Index[V]) Add(lat, lon float64, value V) {
+	hash := Encode(lat, lon, x.precision)
+
+	i := sort.Search(len(x.points), func(i int) bool {
+		return x.points[i].Hash > hash
+	})
+
+	x.points = append(x.points, Point[V]{})
+	copy(x.points[i+1:], x.points[i:])
+	x.points[i] = Point[V]{Lat: lat, Lon: lon, Hash: hash, Value: value}
+}
+
+// WithPrefix returns the points in the cell of prefix, in hash order
+func (x *Index[V]) WithPrefix(prefix string) []Point[V] {
+	points := make([]Point[V], 0)
+
+	x.scanPrefix(prefix, func(point Point[V]) {
+		points = append(points, point)
+	})
+
+	return points
+}
+
+func (x *Index[V]) scanPrefix(prefix string, f func(Point[V])) {
+	i := sort.Search(len(x.points), func(i int) bool {
+		return x.points[i].Hash >= prefix
+	})
+
+	for ; i < len(x.points) && strings.HasPrefix(x.points[i].Hash, prefix); i++ {
+		f(x.points[i])
+	}
+}
+
+// Within returns the points within radius meters of the given one, nearest
+// first. Only the cells returned by Cover are scanned.
+func (x *Index[V]) Within(lat, lon, radius float64) []Point[V] {
+	if radius < 0 {
+		msg := fmt.Sprintf("invalid radius: %v", radius)
+		panic(errors.New(msg))
+	}
+
+	prefixes := Cover(lat, lon, radius)
+
+	for i, prefix := range prefixes {
+		if len(prefix) > x.precision {
+			prefixes[i] = prefix[:x.precision]
+		}
+	}
+
+	type match struct {
+		point    Point[V]
+		distance float64
+	}
+
+	matches := make([]match, 0)
+
+	for _, prefix := range dedupe(prefixes) {
+		x.scanPrefix(prefix, func(point Point[V]) {
+			if distance := Distance(lat, lon, point.Lat, point.Lon); distance <= radius {
+				matches = append(matches, match{point: point, distance: distance})
+			}
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	points := make([]Point[V], len(matches))
+
+	for i, m := range matches {
+		points[i] = m.point
+	}
+
+	return points
+}
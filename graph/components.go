@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"iter"
+
+	"algorithms/hashtable"
+)
+
+// UnionFind tracks connected components of a stream of edges with union by
+// size and path halving. It stores one entry per node and nothing per edge,
+// so it can follow graphs whose edge list does not fit in memory.
+type UnionFind[N comparable] struct {
+	index  *hashtable.HashTable[N, int]
+	nodes  []N
+	parent []int
+	size   []int
+	count  int
+}
+
+func NewUnionFind[N comparable]() *UnionFind[N] {
+	return &UnionFind[N]{
+		index:  hashtable.NewHashTable[N, int](),
+		nodes:  make([]N, 0),
+		parent: make([]int, 0),
+		size:   make([]int, 0),
+	}
+}
+
+// StreamComponents consumes edges one at a time and returns the resulting
+// components
+func StreamComponents[N comparable](edges iter.Seq2[N, N]) *UnionFind[N] {
+	u := NewUnionFind[N]()
+
+	for from, to := range edges {
+		u.Union(from, to)
+	}
+
+	return u
+}
+
+// Add registers node as a component of its own when it is new
+func (u *UnionFind[N]) Add(node N) int {
+	return u.index.ComputeIfAbsent(node, func(node N) int {
+		u.nodes = append(u.nodes, node)
+		u.parent = append(u.parent, len(u.parent))
+		u.size = append(u.size, 1)
+		u.count++
+
+		return len(u.nodes) - 1
+	})
+}
+
+func (u *UnionFind[N]) root(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+
+	return i
+}
+
+// Union merges the components of a and b, reporting whether they were apart
+func (u *UnionFind[N]) Union(a, b N) bool {
+	x, y := u.root(u.Add(a)), u.root(u.Add(b))
+
+	if x == y {
+		return false
+	}
+
+	if u.size[x] < u.size[y] {
+		x, y = y, x
+	}
+
+	u.parent[y] = x
+	u.size[x] += u.size[y]
+	u.count--
+
+	return true
+}
+
+// Find returns the representative of the component of node
+func (u *UnionFind[N]) Find(node N) (N, bool) {
+	i, found := u.index.TryGet(node)
+
+	if !found {
+		return node, false
+	}
+
+	return u.nodes[u.root(i)], true
+}
+
+func (u *UnionFind[N]) Connected(a, b N) bool {
+	x, foundA := u.index.TryGet(a)
+	y, foundB := u.index.TryGet(b)
+
+	return foundA && foundB && u.root(x) == u.root(y)
+}
+
+// Count is the number of components
+func (u *UnionFind[N]) Count() int {
+	return u.count
+}
+
+// Components groups the nodes by component, in order of first appearance
+func (u *UnionFind[N]) Components() [][]N {
+	groups := make([][]N, 0, u.count)
+	slot := make([]int, len(u.nodes))
+
+	for i := range slot {
+		slot[i] = -1
+	}
+
+	for i, node := range u.nodes {
+		root := u.root(i)
+
+		if slot[root] < 0 {
+			slot[root] = len(groups)
+			groups = append(groups, nil)
+		}
+
+		groups[slot[root]] = append(groups[slot[root]], node)
+	}
+
+	return groups
+}
+
+// ConnectedComponents runs a breadth-first search from every unvisited node.
+// Edges of a directed graph are followed both ways, giving its weakly
+// connected components.
+func ConnectedComponents[N comparable](g *Graph[N]) [][]N {
+	visited := make([]bool, len(g.nodes))
+	components := make([][]N, 0)
+	queue := make([]int, 0)
+
+	for start := range g.nodes {
+		if visited[start] {
+			continue
+		}
+
+		component := make([]N, 0)
+		visited[start] = true
+		queue = append(queue[:0], start)
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, g.nodes[current])
+
+			for _, adjacent := range [][]int{g.out[current], g.in[current]} {
+				for _, next := range adjacent {
+					if !visited[next] {
+						visited[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+		}
+
+		components = append(components, component)
+	}
+
+	return components
+}
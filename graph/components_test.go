@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomGraph(directed bool, nodes, edges int, seed int64) *Graph[int] {
+	random := rand.New(rand.NewSource(seed))
+	g := newGraph[int](directed)
+
+	for i := 0; i < nodes; i++ {
+		g.AddNode(i)
+	}
+
+	for i := 0; i < edges; i++ {
+		g.AddEdge(random.Intn(nodes), random.Intn(nodes))
+	}
+
+	return g
+}
+
+func canonical(components [][]int) string {
+	for _, component := range components {
+		sort.Ints(component)
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+
+	return fmt.Sprint(components)
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := NewUndirected[int]()
+
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(4, 5)
+	g.AddNode(6)
+
+	if components := ConnectedComponents(g); canonical(components) != "[[1 2 3] [4 5] [6]]" {
+		t.Errorf("Expected [[1 2 3] [4 5] [6]], got %v", components)
+	}
+
+	d := NewDirected[int]()
+
+	d.AddEdge(1, 2)
+	d.AddEdge(3, 2)
+
+	if components := ConnectedComponents(d); len(components) != 1 {
+		t.Errorf("Expected one weakly connected component, got %v", components)
+	}
+}
+
+func TestUnionFindMatchesBFS(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		for _, directed := range []bool{false, true} {
+			g := randomGraph(directed, 200, 150, seed)
+			u := StreamComponents(g.Edges())
+
+			for _, node := range g.Nodes() {
+				u.Add(node)
+			}
+
+			expected := ConnectedComponents(g)
+
+			if u.Count() != len(expected) {
+				t.Errorf("Expected %d components, got %d", len(expected), u.Count())
+			}
+
+			if canonical(u.Components()) != canonical(expected) {
+				t.Errorf("Expected union-find and BFS components to agree")
+			}
+		}
+	}
+}
+
+func TestUnionFind(t *testing.T) {
+	u := NewUnionFind[string]()
+
+	if !u.Union("a", "b") || u.Union("b", "a") {
+		t.Errorf("Expected only the first Union to merge")
+	}
+
+	u.Union("c", "d")
+	u.Union("b", "d")
+	u.Add("e")
+
+	if !u.Connected("a", "c") || u.Connected("a", "e") || u.Connected("a", "z") {
+		t.Errorf("Expected a and c to be connected, but not a and e")
+	}
+
+	rootA, _ := u.Find("a")
+	rootD, _ := u.Find("d")
+
+	if rootA != rootD || u.Count() != 2 {
+		t.Errorf("Expected a single representative for a and d and 2 components, got %d", u.Count())
+	}
+
+	if _, found := u.Find("z"); found {
+		t.Errorf("Expected z to be unknown")
+	}
+}
@@ -0,0 +1,292 @@
+package graph
+
+import (
+	"iter"
+	"slices"
+)
+
+// SimpleCycles yields every simple cycle of g once, as the list of its nodes
+// starting from the earliest added one, using Johnson's algorithm: for each
+// start node s it searches the strongly connected component of s among the
+// nodes added after it, blocking nodes that cannot currently lead back to s.
+// The delay between two cycles is O(n + e). An undirected graph is searched
+// as a symmetric directed graph, dropping the two-node cycles formed by a
+// single edge and the reversed copy of every longer cycle.
+func SimpleCycles[N comparable](g *Graph[N]) iter.Seq[[]N] {
+	return func(yield func([]N) bool) {
+		j := johnson[N]{
+			graph:   g,
+			blocked: make([]bool, len(g.nodes)),
+			blocks:  make([][]int, len(g.nodes)),
+			yield:   yield,
+		}
+
+		for s := range g.nodes {
+			j.start = s
+			j.component = g.component(s)
+			j.circuit(s)
+
+			if j.stopped {
+				return
+			}
+
+			for v, member := range j.component {
+				if member {
+					j.blocked[v] = false
+					j.blocks[v] = j.blocks[v][:0]
+				}
+			}
+		}
+	}
+}
+
+type johnson[N comparable] struct {
+	graph     *Graph[N]
+	start     int
+	component []bool
+	blocked   []bool
+	blocks    [][]int
+	stack     []int
+	yield     func([]N) bool
+	stopped   bool
+}
+
+func (j *johnson[N]) circuit(v int) bool {
+	found := false
+
+	j.stack = append(j.stack, v)
+	j.blocked[v] = true
+
+	for _, w := range j.graph.out[v] {
+		if j.stopped {
+			break
+		}
+
+		if !j.component[w] {
+			continue
+		}
+
+		if w == j.start {
+			j.emit()
+			found = true
+		} else if !j.blocked[w] && j.circuit(w) {
+			found = true
+		}
+	}
+
+	if found {
+		j.unblock(v)
+	} else {
+		for _, w := range j.graph.out[v] {
+			if j.component[w] && !slices.Contains(j.blocks[w], v) {
+				j.blocks[w] = append(j.blocks[w], v)
+			}
+		}
+	}
+
+	j.stack = j.stack[:len(j.stack)-1]
+
+	return found
+}
+
+func (j *johnson[N]) unblock(v int) {
+	j.blocked[v] = false
+
+	for _, w := range j.blocks[v] {
+		if j.blocked[w] {
+			j.unblock(w)
+		}
+	}
+
+	j.blocks[v] = j.blocks[v][:0]
+}
+
+func (j *johnson[N]) emit() {
+	cycle := j.stack
+
+	if !j.graph.directed && len(cycle) > 1 && (len(cycle) == 2 || cycle[1] > cycle[len(cycle)-1]) {
+		return
+	}
+
+	if !j.yield(j.graph.toNodes(cycle)) {
+		j.stopped = true
+	}
+}
+
+// component marks the strongly connected component of s in the subgraph of
+// the nodes numbered s or higher, using Tarjan's algorithm
+func (g *Graph[N]) component(s int) []bool {
+	n := len(g.nodes)
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	stack := make([]int, 0)
+	members := make([]bool, n)
+	counter := 0
+
+	var visit func(v int)
+
+	visit = func(v int) {
+		counter++
+		index[v], low[v] = counter, counter
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.out[v] {
+			switch {
+			case w < s:
+			case index[w] == 0:
+				visit(w)
+				low[v] = min(low[v], low[w])
+			case onStack[w]:
+				low[v] = min(low[v], index[w])
+			}
+		}
+
+		if low[v] != index[v] {
+			return
+		}
+
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+
+			if v == s {
+				members[w] = true
+			}
+
+			if w == v {
+				return
+			}
+		}
+	}
+
+	visit(s)
+
+	return members
+}
+
+// ShortestCycle returns a cycle with the fewest edges, found by a
+// breadth-first search from every node in O(n(n + e))
+func ShortestCycle[N comparable](g *Graph[N]) (cycle []N, found bool) {
+	var best []int
+
+	if g.directed {
+		best = g.shortestDirectedCycle()
+	} else {
+		best = g.shortestUndirectedCycle()
+	}
+
+	if best == nil {
+		return nil, false
+	}
+
+	return g.toNodes(best), true
+}
+
+func (g *Graph[N]) shortestDirectedCycle() []int {
+	var best []int
+
+	n := len(g.nodes)
+	distance := make([]int, n)
+	parent := make([]int, n)
+
+	for s := range g.nodes {
+		for i := range distance {
+			distance[i] = -1
+		}
+
+		distance[s] = 0
+		queue := []int{s}
+
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+
+			if best != nil && distance[u]+1 >= len(best) {
+				break
+			}
+
+			for _, w := range g.out[u] {
+				if w == s {
+					best = path(parent, s, u)
+					break
+				}
+
+				if distance[w] < 0 {
+					distance[w] = distance[u] + 1
+					parent[w] = u
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+func (g *Graph[N]) shortestUndirectedCycle() []int {
+	for u := range g.nodes {
+		if g.edges.Contains([2]int{u, u}) {
+			return []int{u}
+		}
+	}
+
+	var best []int
+
+	n := len(g.nodes)
+	distance := make([]int, n)
+	parent := make([]int, n)
+
+	for r := range g.nodes {
+		for i := range distance {
+			distance[i] = -1
+		}
+
+		distance[r] = 0
+		parent[r] = -1
+		queue := []int{r}
+
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+
+			if best != nil && 2*distance[u]+1 >= len(best) {
+				break
+			}
+
+			for _, w := range g.out[u] {
+				switch {
+				case w == u || w == parent[u]:
+				case distance[w] < 0:
+					distance[w] = distance[u] + 1
+					parent[w] = u
+					queue = append(queue, w)
+				case best == nil || distance[u]+distance[w]+1 < len(best):
+					// Both paths leave r apart on a shortest cycle, otherwise
+					// the part after their meeting point would be shorter
+					back := path(parent, r, w)
+					slices.Reverse(back)
+					best = append(path(parent, r, u), back[:len(back)-1]...)
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+// path follows parent links from to back to from
+func path(parent []int, from, to int) []int {
+	nodes := make([]int, 0)
+
+	for v := to; v != from; v = parent[v] {
+		nodes = append(nodes, v)
+	}
+
+	nodes = append(nodes, from)
+	slices.Reverse(nodes)
+
+	return nodes
+}
@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func cycles(g *Graph[int]) []string {
+	found := make([]string, 0)
+
+	for cycle := range SimpleCycles(g) {
+		found = append(found, fmt.Sprint(cycle))
+	}
+
+	sort.Strings(found)
+
+	return found
+}
+
+func TestSimpleCyclesDirected(t *testing.T) {
+	g := NewDirected[int]()
+
+	for _, edge := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {2, 1}, {3, 4}, {4, 4}, {4, 5}} {
+		g.AddEdge(edge[0], edge[1])
+	}
+
+	if found := cycles(g); fmt.Sprint(found) != "[[1 2 3] [1 2] [4]]" {
+		t.Errorf("Expected [[1 2 3] [1 2] [4]], got %v", found)
+	}
+}
+
+func TestSimpleCyclesCompleteGraph(t *testing.T) {
+	g := NewDirected[int]()
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			if i != j {
+				g.AddEdge(i, j)
+			}
+		}
+	}
+
+	// A complete digraph on n nodes has sum over k of C(n, k) * (k-1)! cycles
+	if found := cycles(g); len(found) != 84 {
+		t.Errorf("Expected 84 cycles, got %d", len(found))
+	}
+}
+
+func TestSimpleCyclesUndirected(t *testing.T) {
+	g := NewUndirected[int]()
+
+	// Two triangles sharing the edge 2-3
+	for _, edge := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {2, 4}, {4, 3}} {
+		g.AddEdge(edge[0], edge[1])
+	}
+
+	if found := cycles(g); fmt.Sprint(found) != "[[1 2 3] [1 2 4 3] [2 3 4]]" {
+		t.Errorf("Expected [[1 2 3] [1 2 4 3] [2 3 4]], got %v", found)
+	}
+}
+
+func TestSimpleCyclesStopsEarly(t *testing.T) {
+	g := NewDirected[int]()
+
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			g.AddEdge(i, j)
+		}
+	}
+
+	count := 0
+
+	for range SimpleCycles(g) {
+		count++
+
+		if count == 3 {
+			break
+		}
+	}
+
+	if count != 3 {
+		t.Errorf("Expected to stop after 3 cycles, got %d", count)
+	}
+}
+
+func TestShortestCycle(t *testing.T) {
+	d := NewDirected[int]()
+
+	for _, edge := range [][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}, {2, 5}, {5, 6}, {6, 2}} {
+		d.AddEdge(edge[0], edge[1])
+	}
+
+	if cycle, found := ShortestCycle(d); !found || len(cycle) != 3 {
+		t.Errorf("Expected a cycle of 3 nodes, got %v", cycle)
+	}
+
+	u := NewUndirected[int]()
+
+	// A square with a pentagon attached to it
+	for _, edge := range [][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}, {4, 5}, {5, 6}, {6, 7}, {7, 8}, {8, 4}} {
+		u.AddEdge(edge[0], edge[1])
+	}
+
+	cycle, found := ShortestCycle(u)
+
+	if !found || len(cycle) != 4 {
+		t.Fatalf("Expected a cycle of 4 nodes, got %v", cycle)
+	}
+
+	for i := range cycle {
+		if !u.HasEdge(cycle[i], cycle[(i+1)%len(cycle)]) {
+			t.Errorf("Expected %v to be a cycle of the graph", cycle)
+		}
+	}
+
+	tree := NewUndirected[int]()
+	tree.AddEdge(1, 2)
+	tree.AddEdge(2, 3)
+
+	if cycle, found := ShortestCycle(tree); found {
+		t.Errorf("Expected a tree to have no cycle, got %v", cycle)
+	}
+
+	tree.AddEdge(3, 3)
+
+	if cycle, found := ShortestCycle(tree); !found || fmt.Sprint(cycle) != "[3]" {
+		t.Errorf("Expected the self-loop [3], got %v", cycle)
+	}
+}
+
+func TestShortestCycleMatchesEnumeration(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		for _, directed := range []bool{false, true} {
+			g := randomGraph(directed, 12, 16, seed)
+			shortest := 0
+
+			for cycle := range SimpleCycles(g) {
+				if shortest == 0 || len(cycle) < shortest {
+					shortest = len(cycle)
+				}
+			}
+
+			cycle, found := ShortestCycle(g)
+
+			if found != (shortest > 0) || len(cycle) != shortest {
+				t.Errorf("Expected a shortest cycle of %d nodes, got %v", shortest, cycle)
+			}
+
+			for i := range cycle {
+				if !g.HasEdge(cycle[i], cycle[(i+1)%len(cycle)]) {
+					t.Errorf("Expected %v to be a cycle of the graph", cycle)
+				}
+			}
+		}
+	}
+}
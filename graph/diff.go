@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GraphDiff lists what changed from one graph to another. Nodes follow the
+// insertion order of the graph they come from, and edges its Edges order.
+type GraphDiff[N comparable] struct {
+	AddedNodes   []N
+	RemovedNodes []N
+	AddedEdges   [][2]N
+	RemovedEdges [][2]N
+}
+
+// Empty reports whether both graphs had the same nodes and edges
+func (d GraphDiff[N]) Empty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0
+}
+
+// Diff compares the node and edge sets of a and b, which must both be
+// directed or both undirected. Graphs carry no node or edge attributes, so
+// there is nothing else to compare.
+func Diff[N comparable](a, b *Graph[N]) GraphDiff[N] {
+	if a.directed != b.directed {
+		msg := fmt.Sprintf("cannot diff a graph directed %v with one directed %v", a.directed, b.directed)
+		panic(errors.New(msg))
+	}
+
+	return GraphDiff[N]{
+		AddedNodes:   missingNodes(b, a),
+		RemovedNodes: missingNodes(a, b),
+		AddedEdges:   missingEdges(b, a),
+		RemovedEdges: missingEdges(a, b),
+	}
+}
+
+// missingNodes returns the nodes of g absent from other
+func missingNodes[N comparable](g, other *Graph[N]) []N {
+	nodes := make([]N, 0)
+
+	for _, node := range g.nodes {
+		if !other.Contains(node) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// missingEdges returns the edges of g absent from other
+func missingEdges[N comparable](g, other *Graph[N]) [][2]N {
+	edges := make([][2]N, 0)
+
+	for from, to := range g.Edges() {
+		if !other.HasEdge(from, to) {
+			edges = append(edges, [2]N{from, to})
+		}
+	}
+
+	return edges
+}
@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := NewUndirected[string]()
+	a.AddEdge("a", "b")
+	a.AddEdge("b", "c")
+	a.AddNode("d")
+
+	b := NewUndirected[string]()
+	b.AddEdge("b", "a")
+	b.AddEdge("c", "e")
+	b.AddNode("b")
+
+	diff := Diff(a, b)
+
+	if fmt.Sprint(diff.AddedNodes, diff.RemovedNodes) != "[e] [d]" {
+		t.Errorf("Expected [e] added and [d] removed, got %v and %v", diff.AddedNodes, diff.RemovedNodes)
+	}
+
+	if fmt.Sprint(diff.AddedEdges, diff.RemovedEdges) != "[[c e]] [[b c]]" {
+		t.Errorf("Expected [[c e]] added and [[b c]] removed, got %v and %v", diff.AddedEdges, diff.RemovedEdges)
+	}
+
+	if diff.Empty() || !Diff(a, a).Empty() {
+		t.Errorf("Expected only a graph compared with itself to have an empty diff")
+	}
+
+	d := NewDirected[string]()
+	d.AddEdge("b", "a")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Diff(a, d)
+}
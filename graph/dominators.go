@@ -0,0 +1,221 @@
+package graph
+
+// DominatorTree records for every node reachable from a root its immediate
+// dominator: the closest node that every path from the root to it goes
+// through.
+type DominatorTree[N comparable] struct {
+	graph    *Graph[N]
+	root     int
+	idom     []int
+	children [][]int
+	enter    []int
+	exit     []int
+}
+
+// Dominators builds the dominator tree of the nodes reachable from root with
+// the Lengauer-Tarjan algorithm in O(e log n). An undirected graph is treated
+// as a symmetric directed graph.
+func Dominators[N comparable](g *Graph[N], root N) *DominatorTree[N] {
+	r := g.mustIndex(root)
+	n := len(g.nodes)
+
+	predecessors := g.in
+
+	if !g.directed {
+		predecessors = g.out
+	}
+
+	// Nodes are numbered in depth-first order, the root first; every array
+	// below is indexed by those numbers
+	number := make([]int, n)
+	vertex := make([]int, 0, n)
+	parent := make([]int, 0, n)
+
+	for i := range number {
+		number[i] = -1
+	}
+
+	type frame struct {
+		node, next int
+	}
+
+	number[r] = 0
+	vertex = append(vertex, r)
+	parent = append(parent, -1)
+	stack := []frame{{r, 0}}
+
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+
+		if top.next == len(g.out[top.node]) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		w := g.out[top.node][top.next]
+		top.next++
+
+		if number[w] < 0 {
+			number[w] = len(vertex)
+			vertex = append(vertex, w)
+			parent = append(parent, number[top.node])
+			stack = append(stack, frame{w, 0})
+		}
+	}
+
+	k := len(vertex)
+	semi := make([]int, k)
+	idom := make([]int, k)
+	ancestor := make([]int, k)
+	label := make([]int, k)
+	bucket := make([][]int, k)
+
+	for v := range semi {
+		semi[v], ancestor[v], label[v] = v, -1, v
+	}
+
+	compress := func(v int) {
+		path := make([]int, 0)
+
+		for ; ancestor[ancestor[v]] >= 0; v = ancestor[v] {
+			path = append(path, v)
+		}
+
+		for i := len(path) - 1; i >= 0; i-- {
+			x := path[i]
+			a := ancestor[x]
+
+			if semi[label[a]] < semi[label[x]] {
+				label[x] = label[a]
+			}
+
+			ancestor[x] = ancestor[a]
+		}
+	}
+
+	eval := func(v int) int {
+		if ancestor[v] < 0 {
+			return v
+		}
+
+		compress(v)
+
+		return label[v]
+	}
+
+	for w := k - 1; w > 0; w-- {
+		for _, p := range predecessors[vertex[w]] {
+			if v := number[p]; v >= 0 {
+				if u := eval(v); semi[u] < semi[w] {
+					semi[w] = semi[u]
+				}
+			}
+		}
+
+		bucket[semi[w]] = append(bucket[semi[w]], w)
+		ancestor[w] = parent[w]
+
+		for _, v := range bucket[parent[w]] {
+			if u := eval(v); semi[u] < semi[v] {
+				idom[v] = u
+			} else {
+				idom[v] = parent[w]
+			}
+		}
+
+		bucket[parent[w]] = nil
+	}
+
+	for w := 1; w < k; w++ {
+		if idom[w] != semi[w] {
+			idom[w] = idom[idom[w]]
+		}
+	}
+
+	t := DominatorTree[N]{
+		graph:    g,
+		root:     r,
+		idom:     make([]int, n),
+		children: make([][]int, n),
+		enter:    make([]int, n),
+		exit:     make([]int, n),
+	}
+
+	for i := range t.idom {
+		t.idom[i] = -1
+	}
+
+	for w := 1; w < k; w++ {
+		node, dominator := vertex[w], vertex[idom[w]]
+		t.idom[node] = dominator
+		t.children[dominator] = append(t.children[dominator], node)
+	}
+
+	t.number()
+
+	return &t
+}
+
+// number labels the tree nodes with the interval of their depth-first visit,
+// so that a dominates b exactly when the interval of a contains that of b
+func (t *DominatorTree[N]) number() {
+	clock := 1
+	stack := []int{t.root}
+	next := make([]int, len(t.idom))
+
+	t.enter[t.root] = clock
+
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+
+		if next[v] == len(t.children[v]) {
+			clock++
+			t.exit[v] = clock
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		child := t.children[v][next[v]]
+		next[v]++
+		clock++
+		t.enter[child] = clock
+		stack = append(stack, child)
+	}
+}
+
+func (t *DominatorTree[N]) Root() N {
+	return t.graph.nodes[t.root]
+}
+
+// Reachable reports whether node can be reached from the root
+func (t *DominatorTree[N]) Reachable(node N) bool {
+	i, found := t.graph.index.TryGet(node)
+
+	return found && t.enter[i] > 0
+}
+
+// Idom returns the immediate dominator of node, which the root and the nodes
+// not reachable from it lack
+func (t *DominatorTree[N]) Idom(node N) (N, bool) {
+	i := t.graph.mustIndex(node)
+
+	if t.idom[i] < 0 {
+		var zero N
+		return zero, false
+	}
+
+	return t.graph.nodes[t.idom[i]], true
+}
+
+// Dominates reports whether every path from the root to b goes through a. A
+// reachable node dominates itself.
+func (t *DominatorTree[N]) Dominates(a, b N) bool {
+	i, j := t.graph.mustIndex(a), t.graph.mustIndex(b)
+
+	return t.enter[j] > 0 && t.enter[i] > 0 && t.enter[i] <= t.enter[j] && t.exit[j] <= t.exit[i]
+}
+
+// Children returns the nodes node immediately dominates
+func (t *DominatorTree[N]) Children(node N) []N {
+	return t.graph.toNodes(t.children[t.graph.mustIndex(node)])
+}
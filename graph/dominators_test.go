@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDominatorsLengauerTarjanExample(t *testing.T) {
+	g := NewDirected[string]()
+
+	edges := [][2]string{
+		{"R", "A"}, {"R", "B"}, {"R", "C"}, {"A", "D"}, {"B", "A"}, {"B", "D"},
+		{"B", "E"}, {"C", "F"}, {"C", "G"}, {"D", "L"}, {"E", "H"}, {"F", "I"},
+		{"G", "I"}, {"G", "J"}, {"H", "E"}, {"H", "K"}, {"I", "K"}, {"J", "I"},
+		{"K", "I"}, {"K", "R"}, {"L", "H"},
+	}
+
+	for _, edge := range edges {
+		g.AddEdge(edge[0], edge[1])
+	}
+
+	g.AddNode("Z")
+
+	tree := Dominators(g, "R")
+
+	expected := map[string]string{
+		"A": "R", "B": "R", "C": "R", "D": "R", "E": "R", "F": "C", "G": "C",
+		"H": "R", "I": "R", "J": "G", "K": "R", "L": "D",
+	}
+
+	for node, dominator := range expected {
+		if idom, found := tree.Idom(node); !found || idom != dominator {
+			t.Errorf("Expected idom of %s to be %s, got %s", node, dominator, idom)
+		}
+	}
+
+	if _, found := tree.Idom("R"); found {
+		t.Errorf("Expected the root to have no dominator")
+	}
+
+	if _, found := tree.Idom("Z"); found || tree.Reachable("Z") {
+		t.Errorf("Expected Z to be unreachable")
+	}
+
+	if !tree.Dominates("C", "J") || !tree.Dominates("J", "J") || tree.Dominates("G", "I") || tree.Dominates("R", "Z") {
+		t.Errorf("Expected C to dominate J but not G to dominate I")
+	}
+
+	if children := tree.Children("C"); fmt.Sprint(children) != "[F G]" {
+		t.Errorf("Expected [F G], got %v", children)
+	}
+
+	if tree.Root() != "R" {
+		t.Errorf("Expected root to be R, got %s", tree.Root())
+	}
+}
+
+// reachableWithout lists the nodes reachable from root when skip is removed
+func reachableWithout(g *Graph[int], root, skip int) map[int]bool {
+	seen := map[int]bool{root: true}
+	stack := []int{root}
+
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, w := range g.Neighbours(v) {
+			if w != skip && !seen[w] {
+				seen[w] = true
+				stack = append(stack, w)
+			}
+		}
+	}
+
+	return seen
+}
+
+func TestDominatorsMatchBruteForce(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for round := 0; round < 50; round++ {
+		g := NewDirected[int]()
+		n := 2 + random.IntN(15)
+
+		for v := 0; v < n; v++ {
+			g.AddNode(v)
+		}
+
+		for e := random.IntN(3 * n); e > 0; e-- {
+			g.AddEdge(random.IntN(n), random.IntN(n))
+		}
+
+		tree := Dominators(g, 0)
+		reachable := reachableWithout(g, 0, -1)
+
+		for a := 0; a < n; a++ {
+			without := reachableWithout(g, 0, a)
+
+			for b := 0; b < n; b++ {
+				dominates := reachable[a] && reachable[b] && (a == b || a == 0 || !without[b])
+
+				if tree.Dominates(a, b) != dominates {
+					t.Fatalf("Expected Dominates(%d, %d) to be %v in %v", a, b, dominates, g.Nodes())
+				}
+			}
+		}
+	}
+}
+
+func TestDominatorsMissingRoot(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Dominators(NewDirected[int](), 1)
+}
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"algorithms/hashtable"
+)
+
+// Graph is a simple graph over comparable node IDs. Nodes are numbered in
+// insertion order and the algorithms work on those numbers internally.
+// Adding an edge twice has no effect.
+type Graph[N comparable] struct {
+	directed bool
+	nodes    []N
+	index    *hashtable.HashTable[N, int]
+	out      [][]int
+	in       [][]int
+	edges    *hashtable.Set[[2]int]
+	size     int
+}
+
+func NewDirected[N comparable]() *Graph[N] {
+	return newGraph[N](true)
+}
+
+func NewUndirected[N comparable]() *Graph[N] {
+	return newGraph[N](false)
+}
+
+func newGraph[N comparable](directed bool) *Graph[N] {
+	return &Graph[N]{
+		directed: directed,
+		nodes:    make([]N, 0),
+		index:    hashtable.NewHashTable[N, int](),
+		out:      make([][]int, 0),
+		in:       make([][]int, 0),
+		edges:    hashtable.NewSet[[2]int](),
+	}
+}
+
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// AddNode returns the number of node, adding it first when needed
+func (g *Graph[N]) AddNode(node N) int {
+	return g.index.ComputeIfAbsent(node, func(node N) int {
+		g.nodes = append(g.nodes, node)
+		g.out = append(g.out, nil)
+		g.in = append(g.in, nil)
+
+		return len(g.nodes) - 1
+	})
+}
+
+func (g *Graph[N]) AddEdge(from, to N) {
+	u, v := g.AddNode(from), g.AddNode(to)
+
+	if !g.edges.Add([2]int{u, v}) {
+		return
+	}
+
+	g.size++
+	g.out[u] = append(g.out[u], v)
+
+	if g.directed {
+		g.in[v] = append(g.in[v], u)
+		return
+	}
+
+	if u != v {
+		g.edges.Add([2]int{v, u})
+		g.out[v] = append(g.out[v], u)
+	}
+}
+
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	u, found := g.index.TryGet(from)
+
+	if !found {
+		return false
+	}
+
+	v, found := g.index.TryGet(to)
+
+	return found && g.edges.Contains([2]int{u, v})
+}
+
+func (g *Graph[N]) Contains(node N) bool {
+	return g.index.Contains(node)
+}
+
+// Nodes returns the nodes in insertion order
+func (g *Graph[N]) Nodes() []N {
+	return append([]N(nil), g.nodes...)
+}
+
+// Neighbours returns the successors of node
+func (g *Graph[N]) Neighbours(node N) []N {
+	return g.toNodes(g.out[g.mustIndex(node)])
+}
+
+// Edges yields every edge once, an undirected edge from its earlier added end
+func (g *Graph[N]) Edges() iter.Seq2[N, N] {
+	return func(yield func(N, N) bool) {
+		for u, adjacent := range g.out {
+			for _, v := range adjacent {
+				if (g.directed || u <= v) && !yield(g.nodes[u], g.nodes[v]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *Graph[N]) Order() int {
+	return len(g.nodes)
+}
+
+// Size is the number of edges
+func (g *Graph[N]) Size() int {
+	return g.size
+}
+
+func (g *Graph[N]) mustIndex(node N) int {
+	index, found := g.index.TryGet(node)
+
+	if !found {
+		msg := fmt.Sprintf("node not found: %v", node)
+		panic(errors.New(msg))
+	}
+
+	return index
+}
+
+func (g *Graph[N]) toNodes(indices []int) []N {
+	nodes := make([]N, len(indices))
+
+	for i, index := range indices {
+		nodes[i] = g.nodes[index]
+	}
+
+	return nodes
+}
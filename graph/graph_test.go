@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAddEdge(t *testing.T) {
+	g := NewUndirected[string]()
+
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "a")
+	g.AddEdge("b", "c")
+
+	if g.Order() != 3 || g.Size() != 2 {
+		t.Errorf("Expected 3 nodes and 2 edges, got %d and %d", g.Order(), g.Size())
+	}
+
+	if !g.HasEdge("c", "b") || g.HasEdge("a", "c") || g.HasEdge("a", "z") {
+		t.Errorf("Expected undirected edges to be symmetric")
+	}
+
+	if neighbours := g.Neighbours("b"); fmt.Sprint(neighbours) != "[a c]" {
+		t.Errorf("Expected [a c], got %v", neighbours)
+	}
+
+	d := NewDirected[int]()
+	d.AddEdge(1, 2)
+
+	if d.HasEdge(2, 1) || len(d.Neighbours(2)) != 0 {
+		t.Errorf("Expected directed edges to be one way")
+	}
+
+	edges := 0
+
+	for range g.Edges() {
+		edges++
+	}
+
+	if edges != 2 {
+		t.Errorf("Expected Edges to yield 2 edges, got %d", edges)
+	}
+}
+
+func TestNeighboursOfMissingNode(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewDirected[int]().Neighbours(1)
+}
@@ -0,0 +1,145 @@
+package graph
+
+// Bisection splits the nodes of a graph into two halves, Cut counts the edges
+// running between them
+type Bisection[N comparable] struct {
+	A   []N
+	B   []N
+	Cut int
+}
+
+// KernighanLin bisects g into halves of equal size, give or take one node,
+// with few edges between them. Starting from the split of the nodes in
+// insertion order, each pass tentatively swaps the pair of nodes with the
+// best gain until every node was moved once, then keeps the prefix of swaps
+// with the highest total gain. It stops when a pass brings no improvement or
+// after passes passes, when passes is positive. Edges of a directed graph
+// are counted regardless of their direction. Each pass takes O(n^3).
+func KernighanLin[N comparable](g *Graph[N], passes int) Bisection[N] {
+	n := len(g.nodes)
+	side := make([]bool, n)
+
+	for i := (n + 1) / 2; i < n; i++ {
+		side[i] = true
+	}
+
+	for pass := 0; passes <= 0 || pass < passes; pass++ {
+		if !g.improve(side) {
+			break
+		}
+	}
+
+	bisection := Bisection[N]{A: make([]N, 0), B: make([]N, 0)}
+
+	for i, node := range g.nodes {
+		if side[i] {
+			bisection.B = append(bisection.B, node)
+		} else {
+			bisection.A = append(bisection.A, node)
+		}
+
+		for _, j := range g.out[i] {
+			if side[i] != side[j] && (g.directed || i < j) {
+				bisection.Cut++
+			}
+		}
+	}
+
+	return bisection
+}
+
+// adjacent lists every edge end of node, both ways for a directed graph
+func (g *Graph[N]) adjacent(node int, f func(int)) {
+	for _, next := range g.out[node] {
+		f(next)
+	}
+
+	for _, previous := range g.in[node] {
+		f(previous)
+	}
+}
+
+func (g *Graph[N]) weight(a, b int) int {
+	weight := 0
+
+	if g.edges.Contains([2]int{a, b}) {
+		weight++
+	}
+
+	if g.directed && g.edges.Contains([2]int{b, a}) {
+		weight++
+	}
+
+	return weight
+}
+
+// improve runs one Kernighan-Lin pass and reports whether it reduced the cut
+func (g *Graph[N]) improve(side []bool) bool {
+	n := len(side)
+	difference := make([]int, n)
+	locked := make([]bool, n)
+
+	for i := range side {
+		g.adjacent(i, func(j int) {
+			switch {
+			case i == j:
+			case side[i] != side[j]:
+				difference[i]++
+			default:
+				difference[i]--
+			}
+		})
+	}
+
+	swaps := make([][2]int, 0, n/2)
+	best, bestCount, total := 0, 0, 0
+
+	for len(swaps) < n/2 {
+		pair, gain := [2]int{-1, -1}, 0
+
+		for a := range side {
+			if locked[a] || side[a] {
+				continue
+			}
+
+			for b := range side {
+				if locked[b] || !side[b] {
+					continue
+				}
+
+				candidate := difference[a] + difference[b] - 2*g.weight(a, b)
+
+				if pair[0] < 0 || candidate > gain {
+					pair, gain = [2]int{a, b}, candidate
+				}
+			}
+		}
+
+		locked[pair[0]], locked[pair[1]] = true, true
+		swaps = append(swaps, pair)
+		total += gain
+
+		if total > best {
+			best, bestCount = total, len(swaps)
+		}
+
+		// Edges to a moved node flip between internal and external
+		for _, moved := range pair {
+			g.adjacent(moved, func(j int) {
+				switch {
+				case locked[j]:
+				case side[j] == side[moved]:
+					difference[j] += 2
+				default:
+					difference[j] -= 2
+				}
+			})
+		}
+	}
+
+	for _, pair := range swaps[:bestCount] {
+		side[pair[0]], side[pair[1]] = true, false
+	}
+
+	return bestCount > 0
+}
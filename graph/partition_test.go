@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKernighanLinSeparatesCliques(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	g := NewUndirected[int]()
+
+	// Interleave the two cliques so the initial split is poor
+	for _, node := range random.Perm(16) {
+		g.AddNode(node)
+	}
+
+	for clique := 0; clique < 16; clique += 8 {
+		for i := clique; i < clique+8; i++ {
+			for j := i + 1; j < clique+8; j++ {
+				g.AddEdge(i, j)
+			}
+		}
+	}
+
+	g.AddEdge(0, 8)
+
+	bisection := KernighanLin(g, 0)
+
+	if bisection.Cut != 1 {
+		t.Errorf("Expected cut to be 1, got %d", bisection.Cut)
+	}
+
+	if len(bisection.A) != 8 || len(bisection.B) != 8 {
+		t.Errorf("Expected halves of 8 nodes, got %d and %d", len(bisection.A), len(bisection.B))
+	}
+
+	for _, half := range [][]int{bisection.A, bisection.B} {
+		for _, node := range half {
+			if node/8 != half[0]/8 {
+				t.Errorf("Expected every half to be a single clique, got %v", half)
+				break
+			}
+		}
+	}
+}
+
+func TestKernighanLinNeverWorsensCut(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		g := randomGraph(seed%2 == 0, 30, 60, seed)
+
+		half := (g.Order() + 1) / 2
+		initial := 0
+
+		for from, to := range g.Edges() {
+			if (from < half) != (to < half) {
+				initial++
+			}
+		}
+
+		improved := KernighanLin(g, 0)
+
+		if improved.Cut > initial {
+			t.Errorf("Expected cut to drop from %d, got %d", initial, improved.Cut)
+		}
+
+		if len(improved.A)-len(improved.B) > 1 || len(improved.A) < len(improved.B) {
+			t.Errorf("Expected balanced halves, got %d and %d", len(improved.A), len(improved.B))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// ErdosRenyi returns a G(n, p) random graph over the nodes 0 to n-1, where
+// each possible edge but self-loops is present independently with
+// probability p. The same seed always yields the same graph. Absent edges
+// are skipped geometrically, so sparse graphs take time proportional to
+// their size rather than to n².
+func ErdosRenyi(n int, p float64, directed bool, seed int64) *Graph[int] {
+	if n < 0 {
+		msg := fmt.Sprintf("invalid number of nodes: %d", n)
+		panic(errors.New(msg))
+	}
+
+	if !(p >= 0 && p <= 1) {
+		msg := fmt.Sprintf("invalid edge probability: %v", p)
+		panic(errors.New(msg))
+	}
+
+	g := newGraph[int](directed)
+
+	for v := 0; v < n; v++ {
+		g.AddNode(v)
+	}
+
+	if p == 0 {
+		return g
+	}
+
+	// Row v holds the candidate neighbours of v: the nodes below it when
+	// undirected, every other node when directed
+	row := func(v int) int {
+		if directed {
+			return n - 1
+		}
+
+		return v
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	skip := math.Log(1 - p)
+
+	for v, w := 0, -1; v < n; {
+		w += 1 + int(math.Floor(math.Log(1-random.Float64())/skip))
+
+		for v < n && w >= row(v) {
+			w -= row(v)
+			v++
+		}
+
+		if v == n {
+			break
+		}
+
+		if directed && w >= v {
+			g.AddEdge(v, w+1)
+		} else {
+			g.AddEdge(v, w)
+		}
+	}
+
+	return g
+}
+
+// BarabasiAlbert returns an undirected scale-free graph over the nodes 0 to
+// n-1 grown by preferential attachment. It starts from a clique on the
+// first m+1 nodes and links every later node to m distinct earlier ones,
+// each picked with probability proportional to its degree. The same seed
+// always yields the same graph.
+func BarabasiAlbert(n, m int, seed int64) *Graph[int] {
+	if m < 1 || n <= m {
+		msg := fmt.Sprintf("invalid preferential attachment: %d nodes, %d edges per node", n, m)
+		panic(errors.New(msg))
+	}
+
+	g := newGraph[int](false)
+	random := rand.New(rand.NewSource(seed))
+
+	// Every node appears here once per edge it has, so a uniform pick from
+	// it is a pick weighted by degree
+	endpoints := make([]int, 0, 2*m*n)
+
+	for v := 0; v <= m; v++ {
+		g.AddNode(v)
+
+		for w := 0; w < v; w++ {
+			g.AddEdge(v, w)
+			endpoints = append(endpoints, v, w)
+		}
+	}
+
+	targets := make([]int, 0, m)
+
+	for v := m + 1; v < n; v++ {
+		targets = targets[:0]
+
+		for len(targets) < m {
+			w := endpoints[random.Intn(len(endpoints))]
+
+			if !g.HasEdge(v, w) {
+				g.AddEdge(v, w)
+				targets = append(targets, w)
+			}
+		}
+
+		for _, w := range targets {
+			endpoints = append(endpoints, v, w)
+		}
+	}
+
+	return g
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestErdosRenyi(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		n, p := 200, 0.05
+		g := ErdosRenyi(n, p, directed, 7)
+
+		pairs := float64(n * (n - 1))
+
+		if !directed {
+			pairs /= 2
+		}
+
+		expected := pairs * p
+		deviation := math.Sqrt(pairs * p * (1 - p))
+
+		if g.Order() != n || math.Abs(float64(g.Size())-expected) > 5*deviation {
+			t.Errorf("Expected %d nodes and about %.0f edges, got %d and %d", n, expected, g.Order(), g.Size())
+		}
+
+		for from, to := range g.Edges() {
+			if from == to {
+				t.Errorf("Expected no self-loops, got %d", from)
+			}
+		}
+
+		if fmt.Sprint(collectEdges(g)) != fmt.Sprint(collectEdges(ErdosRenyi(n, p, directed, 7))) {
+			t.Errorf("Expected the same seed to yield the same graph")
+		}
+	}
+}
+
+func collectEdges(g *Graph[int]) [][2]int {
+	edges := make([][2]int, 0)
+
+	for from, to := range g.Edges() {
+		edges = append(edges, [2]int{from, to})
+	}
+
+	return edges
+}
+
+func TestErdosRenyiExtremes(t *testing.T) {
+	if g := ErdosRenyi(10, 0, false, 1); g.Order() != 10 || g.Size() != 0 {
+		t.Errorf("Expected 10 nodes and no edges, got %d and %d", g.Order(), g.Size())
+	}
+
+	if g := ErdosRenyi(10, 1, false, 1); g.Size() != 45 {
+		t.Errorf("Expected 45 edges, got %d", g.Size())
+	}
+
+	if g := ErdosRenyi(10, 1, true, 1); g.Size() != 90 {
+		t.Errorf("Expected 90 edges, got %d", g.Size())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	ErdosRenyi(10, 1.5, false, 1)
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	n, m := 500, 3
+	g := BarabasiAlbert(n, m, 42)
+
+	if expected := m*(m+1)/2 + (n-m-1)*m; g.Order() != n || g.Size() != expected {
+		t.Errorf("Expected %d nodes and %d edges, got %d and %d", n, expected, g.Order(), g.Size())
+	}
+
+	highest := 0
+
+	for v := 0; v < n; v++ {
+		degree := len(g.Neighbours(v))
+
+		if degree < m {
+			t.Errorf("Expected degree of %d to be at least %d, got %d", v, m, degree)
+		}
+
+		highest = max(highest, degree)
+	}
+
+	// Preferential attachment grows hubs far above the average degree of 2m
+	if highest < 5*m {
+		t.Errorf("Expected a hub of degree at least %d, got %d", 5*m, highest)
+	}
+
+	if fmt.Sprint(collectEdges(g)) != fmt.Sprint(collectEdges(BarabasiAlbert(n, m, 42))) {
+		t.Errorf("Expected the same seed to yield the same graph")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	BarabasiAlbert(3, 3, 1)
+}
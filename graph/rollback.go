@@ -0,0 +1,239 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/hashtable"
+)
+
+// RollbackUnionFind is a disjoint set whose unions can be undone in reverse
+// order. It uses union by rank without path compression, so that every union
+// changes a single parent and finding a root stays logarithmic.
+type RollbackUnionFind[N comparable] struct {
+	index   *hashtable.HashTable[N, int]
+	nodes   []N
+	parent  []int
+	rank    []int
+	count   int
+	history []unionRecord
+}
+
+type unionRecord struct {
+	child      int
+	raisedRank bool
+}
+
+func NewRollbackUnionFind[N comparable]() *RollbackUnionFind[N] {
+	return &RollbackUnionFind[N]{
+		index:   hashtable.NewHashTable[N, int](),
+		nodes:   make([]N, 0),
+		parent:  make([]int, 0),
+		rank:    make([]int, 0),
+		history: make([]unionRecord, 0),
+	}
+}
+
+// Add registers node as a component of its own when it is new. Nodes are
+// never removed by Rollback.
+func (u *RollbackUnionFind[N]) Add(node N) int {
+	return u.index.ComputeIfAbsent(node, func(node N) int {
+		u.nodes = append(u.nodes, node)
+		u.parent = append(u.parent, len(u.parent))
+		u.rank = append(u.rank, 0)
+		u.count++
+
+		return len(u.nodes) - 1
+	})
+}
+
+func (u *RollbackUnionFind[N]) root(i int) int {
+	for u.parent[i] != i {
+		i = u.parent[i]
+	}
+
+	return i
+}
+
+// Union merges the components of a and b, reporting whether they were apart
+func (u *RollbackUnionFind[N]) Union(a, b N) bool {
+	x, y := u.root(u.Add(a)), u.root(u.Add(b))
+
+	if x == y {
+		return false
+	}
+
+	if u.rank[x] < u.rank[y] {
+		x, y = y, x
+	}
+
+	record := unionRecord{child: y, raisedRank: u.rank[x] == u.rank[y]}
+
+	u.parent[y] = x
+	u.count--
+
+	if record.raisedRank {
+		u.rank[x]++
+	}
+
+	u.history = append(u.history, record)
+
+	return true
+}
+
+// Snapshot marks the current state for a later Rollback
+func (u *RollbackUnionFind[N]) Snapshot() int {
+	return len(u.history)
+}
+
+// Rollback undoes every union made since snapshot was taken
+func (u *RollbackUnionFind[N]) Rollback(snapshot int) {
+	if snapshot < 0 || snapshot > len(u.history) {
+		msg := fmt.Sprintf("invalid snapshot: %d", snapshot)
+		panic(errors.New(msg))
+	}
+
+	for len(u.history) > snapshot {
+		record := u.history[len(u.history)-1]
+		u.history = u.history[:len(u.history)-1]
+
+		x := u.parent[record.child]
+		u.parent[record.child] = record.child
+		u.count++
+
+		if record.raisedRank {
+			u.rank[x]--
+		}
+	}
+}
+
+// Find returns the representative of the component of node
+func (u *RollbackUnionFind[N]) Find(node N) (N, bool) {
+	i, found := u.index.TryGet(node)
+
+	if !found {
+		return node, false
+	}
+
+	return u.nodes[u.root(i)], true
+}
+
+func (u *RollbackUnionFind[N]) Connected(a, b N) bool {
+	x, foundA := u.index.TryGet(a)
+	y, foundB := u.index.TryGet(b)
+
+	return foundA && foundB && u.root(x) == u.root(y)
+}
+
+// Count is the number of components
+func (u *RollbackUnionFind[N]) Count() int {
+	return u.count
+}
+
+type ConnectivityOp uint8
+
+const (
+	LinkOp ConnectivityOp = iota
+	CutOp
+	QueryOp
+)
+
+// ConnectivityEvent links or cuts the undirected edge between From and To,
+// or asks whether they are connected
+type ConnectivityEvent[N comparable] struct {
+	Op   ConnectivityOp
+	From N
+	To   N
+}
+
+// OfflineConnectivity answers the queries of a sequence of edge insertions
+// and deletions, in order. Each edge is alive during an interval of events;
+// the intervals are spread over a segment tree on time, and a depth-first
+// walk of the tree unions the edges of a node on the way down and rolls them
+// back on the way up. It runs in O((n + q) log q log n) for q events.
+func OfflineConnectivity[N comparable](events []ConnectivityEvent[N]) []bool {
+	size := max(len(events), 1)
+	tree := make([][][2]N, 4*size)
+	open := hashtable.NewHashTable[[2]N, []int]()
+	u := NewRollbackUnionFind[N]()
+
+	var add func(node, low, high, from, to int, edge [2]N)
+
+	add = func(node, low, high, from, to int, edge [2]N) {
+		if to <= low || high <= from {
+			return
+		}
+
+		if from <= low && high <= to {
+			tree[node] = append(tree[node], edge)
+			return
+		}
+
+		middle := (low + high) / 2
+		add(2*node, low, middle, from, to, edge)
+		add(2*node+1, middle, high, from, to, edge)
+	}
+
+	for i, event := range events {
+		u.Add(event.From)
+		u.Add(event.To)
+
+		edge := [2]N{event.From, event.To}
+
+		switch event.Op {
+		case LinkOp:
+			starts, _ := open.TryGet(edge)
+			open.Insert(edge, append(starts, i))
+
+		case CutOp:
+			starts, found := open.TryGet(edge)
+
+			if !found || len(starts) == 0 {
+				edge = [2]N{event.To, event.From}
+				starts, found = open.TryGet(edge)
+			}
+
+			if !found || len(starts) == 0 {
+				msg := fmt.Sprintf("cut of a missing edge at event %d", i)
+				panic(errors.New(msg))
+			}
+
+			add(1, 0, size, starts[len(starts)-1], i, edge)
+			open.Insert(edge, starts[:len(starts)-1])
+		}
+	}
+
+	for edge, starts := range open.All() {
+		for _, start := range starts {
+			add(1, 0, size, start, size, edge)
+		}
+	}
+
+	answers := make([]bool, 0)
+
+	var walk func(node, low, high int)
+
+	walk = func(node, low, high int) {
+		snapshot := u.Snapshot()
+
+		for _, edge := range tree[node] {
+			u.Union(edge[0], edge[1])
+		}
+
+		if high-low == 1 {
+			if low < len(events) && events[low].Op == QueryOp {
+				answers = append(answers, u.Connected(events[low].From, events[low].To))
+			}
+		} else {
+			middle := (low + high) / 2
+			walk(2*node, low, middle)
+			walk(2*node+1, middle, high)
+		}
+
+		u.Rollback(snapshot)
+	}
+
+	walk(1, 0, size)
+
+	return answers
+}
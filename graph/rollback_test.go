@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRollbackUnionFind(t *testing.T) {
+	u := NewRollbackUnionFind[string]()
+
+	u.Union("a", "b")
+	snapshot := u.Snapshot()
+
+	u.Union("c", "d")
+	u.Union("b", "d")
+
+	if !u.Connected("a", "c") || u.Count() != 1 {
+		t.Errorf("Expected a single component, got %d", u.Count())
+	}
+
+	u.Rollback(snapshot)
+
+	if u.Connected("a", "c") || !u.Connected("a", "b") || u.Count() != 3 {
+		t.Errorf("Expected the unions after the snapshot to be undone, got %d components", u.Count())
+	}
+
+	u.Rollback(0)
+
+	if u.Connected("a", "b") || u.Count() != 4 {
+		t.Errorf("Expected every union to be undone, got %d components", u.Count())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	u.Rollback(1)
+}
+
+func TestOfflineConnectivity(t *testing.T) {
+	events := []ConnectivityEvent[int]{
+		{LinkOp, 1, 2},
+		{LinkOp, 2, 3},
+		{QueryOp, 1, 3},
+		{CutOp, 3, 2},
+		{QueryOp, 1, 3},
+		{QueryOp, 1, 2},
+	}
+
+	answers := OfflineConnectivity(events)
+
+	if len(answers) != 3 || !answers[0] || answers[1] || !answers[2] {
+		t.Errorf("Expected [true false true], got %v", answers)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	OfflineConnectivity([]ConnectivityEvent[int]{{CutOp, 1, 2}})
+}
+
+func TestOfflineConnectivityMatchesRecomputing(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		random := rand.New(rand.NewSource(seed))
+		events := make([]ConnectivityEvent[int], 0)
+		edges := make([][2]int, 0)
+		expected := make([]bool, 0)
+
+		for i := 0; i < 300; i++ {
+			a, b := random.Intn(20), random.Intn(20)
+
+			switch {
+			case random.Intn(3) == 0 && len(edges) > 0:
+				j := random.Intn(len(edges))
+				events = append(events, ConnectivityEvent[int]{CutOp, edges[j][1], edges[j][0]})
+				edges = append(edges[:j], edges[j+1:]...)
+
+			case random.Intn(2) == 0:
+				events = append(events, ConnectivityEvent[int]{LinkOp, a, b})
+				edges = append(edges, [2]int{a, b})
+
+			default:
+				events = append(events, ConnectivityEvent[int]{QueryOp, a, b})
+				u := NewUnionFind[int]()
+				u.Add(a)
+				u.Add(b)
+
+				for _, edge := range edges {
+					u.Union(edge[0], edge[1])
+				}
+
+				expected = append(expected, u.Connected(a, b))
+			}
+		}
+
+		answers := OfflineConnectivity(events)
+
+		if len(answers) != len(expected) {
+			t.Fatalf("Expected %d answers, got %d", len(expected), len(answers))
+		}
+
+		for i := range answers {
+			if answers[i] != expected[i] {
+				t.Errorf("Expected answer %d to be %v with seed %d, got %v", i, expected[i], seed, answers[i])
+			}
+		}
+	}
+}
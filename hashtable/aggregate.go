@@ -0,0 +1,31 @@
+package hashtable
+
+import "algorithms/iterator"
+
+// Aggregate groups the elements of src by keyFn in a single pass. The first
+// element of a group starts from init(key), and every element is folded into
+// its group's running aggregate with combine.
+func Aggregate[E any, K comparable, A any](
+	src iterator.Iterator[E],
+	keyFn func(E) K,
+	init func(K) A,
+	combine func(A, E) A,
+	opts ...Option,
+) *HashTable[K, A] {
+	table := NewHashTableWithOptions[K, A](opts...)
+
+	src.Range(func(element E) bool {
+		key := keyFn(element)
+		hash, index := table.Hash(key)
+
+		if node := table.find(hash, index, key); node != nil {
+			node.entry.Value = combine(node.entry.Value, element)
+		} else {
+			table.insertNode(table.newNode(hash, key, combine(init(key), element)), index)
+		}
+
+		return true
+	})
+
+	return table
+}
@@ -0,0 +1,64 @@
+package hashtable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"algorithms/iterator"
+)
+
+func TestAggregate(t *testing.T) {
+	words := iterator.NewList[string]()
+
+	for _, word := range strings.Fields("apple avocado banana blueberry cherry apricot") {
+		words.Append(word)
+	}
+
+	counts := Aggregate(words,
+		func(word string) byte { return word[0] },
+		func(byte) int { return 0 },
+		func(count int, _ string) int { return count + 1 },
+	)
+
+	expected := map[byte]int{'a': 3, 'b': 2, 'c': 1}
+
+	if counts.Size() != uint32(len(expected)) {
+		t.Errorf("Expected %d groups, got %d", len(expected), counts.Size())
+	}
+
+	for key, count := range expected {
+		if value := counts.Get(key); value != count {
+			t.Errorf("Expected %c to count %d, got %d", key, count, value)
+		}
+	}
+
+	grouped := Aggregate(words,
+		func(word string) int { return len(word) },
+		func(int) []string { return nil },
+		func(group []string, word string) []string { return append(group, word) },
+	)
+
+	if group := grouped.Get(6); fmt.Sprint(group) != "[banana cherry]" {
+		t.Errorf("Expected [banana cherry], got %v", group)
+	}
+}
+
+func TestAggregateInitReceivesKey(t *testing.T) {
+	numbers := iterator.NewList[int]()
+
+	for i := 1; i <= 10; i++ {
+		numbers.Append(i)
+	}
+
+	sums := Aggregate(numbers,
+		func(n int) int { return n % 2 },
+		func(parity int) int { return parity * 1000 },
+		func(sum, n int) int { return sum + n },
+		WithCapacity(2),
+	)
+
+	if sums.Get(0) != 30 || sums.Get(1) != 1025 {
+		t.Errorf("Expected sums to be 30 and 1025, got %d and %d", sums.Get(0), sums.Get(1))
+	}
+}
@@ -0,0 +1,61 @@
+package hashtable
+
+// InsertMany inserts every entry, later entries overwriting earlier ones with
+// the same key. The bucket array is grown once up front to hold the whole
+// batch, so no resize or incremental migration happens along the way.
+func (h *HashTable[K, V]) InsertMany(entries []Entry[K, V]) {
+	expected := uint64(h.sizeItems) + uint64(len(entries))
+
+	if h.bound != nil {
+		expected = min(expected, uint64(h.bound.maxEntries))
+	}
+
+	h.reserve(expected)
+
+	for _, entry := range entries {
+		hash, index := h.Hash(entry.Key)
+		h.insertNode(h.newNode(hash, entry.Key, entry.Value), index)
+	}
+}
+
+// reserve grows the bucket array to the length Resize would reach for n
+// entries and finishes the migration at once
+func (h *HashTable[K, V]) reserve(n uint64) {
+	length := uint64(h.actualBucketLength)
+
+	for float64(n) > float64(length)*h.loadFactor && length < 1<<31 {
+		length <<= 1
+	}
+
+	if length > uint64(h.actualBucketLength) {
+		h.rehash(uint32(length))
+	}
+
+	h.finishMigration()
+}
+
+// GetMany looks up every key like TryGet, returning the entries found in the
+// order of keys and the keys that are missing. The default function of a
+// table created by NewHashTableWithDefault is not applied.
+func (h *HashTable[K, V]) GetMany(keys []K) (found []Entry[K, V], missing []K) {
+	found = make([]Entry[K, V], 0, len(keys))
+	missing = make([]K, 0)
+
+	for _, key := range keys {
+		hash, index := h.Hash(key)
+		node := h.find(hash, index, key)
+
+		if node == nil {
+			missing = append(missing, key)
+			continue
+		}
+
+		if h.bound != nil {
+			h.bound.policy.Accessed(key)
+		}
+
+		found = append(found, node.entry)
+	}
+
+	return found, missing
+}
@@ -0,0 +1,73 @@
+package hashtable
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInsertMany(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+	hashTable.Insert(-1, -1)
+
+	entries := make([]Entry[int, int], 10000)
+
+	for i := range entries {
+		entries[i] = Entry[int, int]{Key: i, Value: i * 2}
+	}
+
+	entries = append(entries, Entry[int, int]{Key: 0, Value: 42})
+	resizes := hashTable.Stats().Resizes
+
+	hashTable.InsertMany(entries)
+
+	if hashTable.Size() != 10001 {
+		t.Errorf("Expected size to be 10001, got %d", hashTable.Size())
+	}
+
+	if got := hashTable.Stats().Resizes - resizes; got != 1 {
+		t.Errorf("Expected a single resize for the batch, got %d", got)
+	}
+
+	if hashTable.Get(0) != 42 || hashTable.Get(9999) != 19998 || hashTable.Get(-1) != -1 {
+		t.Errorf("Expected every entry to be inserted, the last duplicate winning")
+	}
+}
+
+func TestInsertManyBounded(t *testing.T) {
+	evicted := 0
+	hashTable := NewHashTableWithOptions[int, int](WithMaxEntries(10), WithEviction(EvictFIFO), OnEvict(func(int, int) {
+		evicted++
+	}))
+
+	entries := make([]Entry[int, int], 100)
+
+	for i := range entries {
+		entries[i] = Entry[int, int]{Key: i, Value: i}
+	}
+
+	hashTable.InsertMany(entries)
+
+	if hashTable.Size() != 10 || evicted != 90 || !hashTable.Contains(99) || hashTable.Contains(89) {
+		t.Errorf("Expected the last 10 entries to remain, got size %d after %d evictions", hashTable.Size(), evicted)
+	}
+}
+
+func TestGetMany(t *testing.T) {
+	hashTable := NewHashTableWithDefault(func(key string) int { return -1 })
+	hashTable.InsertMany([]Entry[string, int]{{"a", 1}, {"b", 2}, {"c", 3}})
+
+	found, missing := hashTable.GetMany([]string{"c", "x", "a", "y"})
+	expected := []Entry[string, int]{{"c", 3}, {"a", 1}}
+
+	if !slices.Equal(found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+
+	if !slices.Equal(missing, []string{"x", "y"}) {
+		t.Errorf("Expected [x y] to be missing, got %v", missing)
+	}
+
+	if hashTable.Contains("x") {
+		t.Errorf("Expected GetMany not to insert defaults")
+	}
+}
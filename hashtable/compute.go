@@ -0,0 +1,92 @@
+package hashtable
+
+// GetOrInsert returns the value stored for key, inserting defaultFn() first
+// when the key is missing. The key is hashed once.
+func (h *HashTable[K, V]) GetOrInsert(key K, defaultFn func() V) V {
+	return h.ComputeIfAbsent(key, func(K) V {
+		return defaultFn()
+	})
+}
+
+// ComputeIfAbsent returns the value stored for key, storing fn(key) first
+// when the key is missing. fn may write to the table, for instance to memoize
+// a recursive function, so the key is looked up again once it returns: a
+// value stored for key meanwhile wins over the one fn computed.
+func (h *HashTable[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	hash, index := h.Hash(key)
+
+	if node := h.find(hash, index, key); node != nil {
+		if h.bound != nil {
+			h.bound.policy.Accessed(key)
+		}
+
+		return node.entry.Value
+	}
+
+	return h.insertComputed(key, hash, fn(key))
+}
+
+// insertComputed inserts a value computed by a callback that may have resized
+// the table or inserted key, unless key is present by now. Only the index is
+// derived again from the hash, since a resize changes it.
+func (h *HashTable[K, V]) insertComputed(key K, hash uint64, value V) V {
+	index := h.generateIndex(hash)
+
+	if node := h.find(hash, index, key); node != nil {
+		return node.entry.Value
+	}
+
+	h.insertNode(h.newNode(hash, key, value), index)
+
+	return value
+}
+
+// ComputeIfPresent replaces the value of an existing key with fn(key, value)
+// and reports whether the key was found. The key is looked up again after fn
+// in case fn wrote to the table, and stored again if fn deleted it.
+func (h *HashTable[K, V]) ComputeIfPresent(key K, fn func(key K, value V) V) (value V, found bool) {
+	hash, index := h.Hash(key)
+
+	node := h.find(hash, index, key)
+
+	if node == nil {
+		return
+	}
+
+	value = fn(key, node.entry.Value)
+	index = h.generateIndex(hash)
+
+	if node := h.find(hash, index, key); node != nil {
+		node.entry.Value = value
+
+		if h.bound != nil {
+			h.bound.policy.Accessed(key)
+		}
+	} else {
+		h.insertNode(h.newNode(hash, key, value), index)
+	}
+
+	return value, true
+}
+
+// Update replaces the value of an existing key with fn(value) and reports
+// whether the key was found. The key is hashed and looked up once and its
+// node updated in place, so unlike ComputeIfPresent fn must not write to the
+// table.
+func (h *HashTable[K, V]) Update(key K, fn func(value V) V) bool {
+	hash, index := h.Hash(key)
+
+	node := h.find(hash, index, key)
+
+	if node == nil {
+		return false
+	}
+
+	node.entry.Value = fn(node.entry.Value)
+
+	if h.bound != nil {
+		h.bound.policy.Accessed(key)
+	}
+
+	return true
+}
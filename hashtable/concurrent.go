@@ -0,0 +1,197 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type shard[K comparable, V any] struct {
+	mutex sync.RWMutex
+	table *HashTable[K, V]
+}
+
+// ConcurrentHashTable spreads keys over independently locked shards so that
+// goroutines working on different shards never contend.
+type ConcurrentHashTable[K comparable, V any] struct {
+	shards []*shard[K, V]
+	hasher Hasher[K]
+}
+
+func NewConcurrentHashTable[K comparable, V any](shards int) *ConcurrentHashTable[K, V] {
+	if shards <= 0 {
+		msg := fmt.Sprintf("invalid shard count: %d", shards)
+		panic(errors.New(msg))
+	}
+
+	table := ConcurrentHashTable[K, V]{
+		shards: make([]*shard[K, V], shards),
+		hasher: newDefaultHasher[K](),
+	}
+
+	for i := range table.shards {
+		table.shards[i] = &shard[K, V]{table: NewHashTable[K, V]()}
+	}
+
+	return &table
+}
+
+func (c *ConcurrentHashTable[K, V]) shardFor(key K) *shard[K, V] {
+	// Use the high bits so shard choice is independent of the bucket index
+	return c.shards[(c.hasher.Hash(key)>>32)%uint64(len(c.shards))]
+}
+
+func (c *ConcurrentHashTable[K, V]) Insert(key K, value V) {
+	s := c.shardFor(key)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.table.Insert(key, value)
+}
+
+func (c *ConcurrentHashTable[K, V]) Get(key K) V {
+	value, found := c.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return value
+}
+
+func (c *ConcurrentHashTable[K, V]) TryGet(key K) (V, bool) {
+	s := c.shardFor(key)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.table.TryGet(key)
+}
+
+func (c *ConcurrentHashTable[K, V]) Delete(key K) bool {
+	s := c.shardFor(key)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, found := s.table.Delete(key)
+
+	return found
+}
+
+func (c *ConcurrentHashTable[K, V]) Size() uint32 {
+	var size uint32
+
+	for _, s := range c.shards {
+		s.mutex.RLock()
+		size += s.table.Size()
+		s.mutex.RUnlock()
+	}
+
+	return size
+}
+
+// Iter copies one shard at a time, so it never observes a partially applied
+// write but may mix states of different shards.
+func (c *ConcurrentHashTable[K, V]) Iter() <-chan Entry[K, V] {
+	iterator := make(chan Entry[K, V])
+
+	go func() {
+		for _, s := range c.shards {
+			s.mutex.RLock()
+			entries := make([]Entry[K, V], 0, s.table.Size())
+
+			for key, value := range s.table.All() {
+				entries = append(entries, Entry[K, V]{Key: key, Value: value})
+			}
+
+			s.mutex.RUnlock()
+
+			for _, entry := range entries {
+				iterator <- entry
+			}
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func (c *ConcurrentHashTable[K, V]) ForEach(f func(Entry[K, V])) {
+	for entry := range c.Iter() {
+		f(entry)
+	}
+}
+
+// Range visits the shards in turn while holding each one's read lock, so
+// unlike ForEach it copies nothing but f must not write to the table.
+func (c *ConcurrentHashTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	for _, s := range c.shards {
+		s.mutex.RLock()
+		more := s.table.rangeEntries(f)
+		s.mutex.RUnlock()
+
+		if !more {
+			return
+		}
+	}
+}
+
+// LoadOrStore returns the value of key when present, otherwise it stores
+// value and returns it, reporting whether the value was loaded
+func (c *ConcurrentHashTable[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := c.shardFor(key)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if actual, loaded = s.table.TryGet(key); loaded {
+		return actual, true
+	}
+
+	s.table.Insert(key, value)
+
+	return value, false
+}
+
+// CompareAndSwap replaces the value of key with new if it equals old. Like
+// sync.Map, values are compared as interfaces, which panics when their
+// dynamic type is not comparable.
+func (c *ConcurrentHashTable[K, V]) CompareAndSwap(key K, old, new V) bool {
+	s := c.shardFor(key)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	value, found := s.table.TryGet(key)
+
+	if !found || any(value) != any(old) {
+		return false
+	}
+
+	s.table.Insert(key, new)
+
+	return true
+}
+
+// CompareAndDelete deletes key if its value equals old, compared like
+// CompareAndSwap does
+func (c *ConcurrentHashTable[K, V]) CompareAndDelete(key K, old V) bool {
+	s := c.shardFor(key)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	value, found := s.table.TryGet(key)
+
+	if !found || any(value) != any(old) {
+		return false
+	}
+
+	s.table.Delete(key)
+
+	return true
+}
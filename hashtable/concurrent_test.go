@@ -0,0 +1,131 @@
+package hashtable
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentInsertGetDelete(t *testing.T) {
+	table := NewConcurrentHashTable[string, int](16)
+	wg := sync.WaitGroup{}
+
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("%d-%d", worker, i)
+				table.Insert(key, i)
+
+				if value, found := table.TryGet(key); !found || value != i {
+					t.Errorf("Expected value to be %d, got %d", i, value)
+					return
+				}
+
+				if i%2 == 0 && !table.Delete(key) {
+					t.Errorf("Expected %s to be deleted", key)
+					return
+				}
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	if table.Size() != 8*250 {
+		t.Errorf("Expected size to be 2000, got %d", table.Size())
+	}
+
+	counter := 0
+	for range table.Iter() {
+		counter++
+	}
+
+	if counter != 8*250 {
+		t.Errorf("Expected counter to be 2000, got %d", counter)
+	}
+}
+
+func TestConcurrentGetPanicsOnMissingKey(t *testing.T) {
+	table := NewConcurrentHashTable[string, int](4)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	table.Get("foo")
+}
+
+func TestConcurrentLoadOrStore(t *testing.T) {
+	table := NewConcurrentHashTable[string, int](4)
+	wg := sync.WaitGroup{}
+	stored := make([]bool, 8)
+
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			actual, loaded := table.LoadOrStore("key", worker)
+			stored[worker] = !loaded
+
+			if !loaded && actual != worker {
+				t.Errorf("Expected the stored value to be %d, got %d", worker, actual)
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	winners := 0
+
+	for worker, won := range stored {
+		if won {
+			winners++
+
+			if table.Get("key") != worker {
+				t.Errorf("Expected the value of the only store to remain, got %d", table.Get("key"))
+			}
+		}
+	}
+
+	if winners != 1 {
+		t.Errorf("Expected exactly one store, got %d", winners)
+	}
+}
+
+func TestConcurrentCompareAndDelete(t *testing.T) {
+	table := NewConcurrentHashTable[string, int](4)
+	table.Insert("key", 1)
+
+	if table.CompareAndDelete("key", 2) || table.CompareAndDelete("missing", 0) {
+		t.Errorf("Expected a mismatched value or missing key not to be deleted")
+	}
+
+	if !table.CompareAndSwap("key", 1, 2) || table.CompareAndSwap("key", 1, 3) {
+		t.Errorf("Expected only the swap from the current value to succeed")
+	}
+
+	if !table.CompareAndDelete("key", 2) || table.Size() != 0 {
+		t.Errorf("Expected key to be deleted")
+	}
+}
+
+func TestConcurrentCompareAndDeletePanicsOnIncomparableValues(t *testing.T) {
+	table := NewConcurrentHashTable[string, any](4)
+	table.Insert("key", []int{1})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	table.CompareAndDelete("key", []int{1})
+}
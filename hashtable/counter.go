@@ -0,0 +1,128 @@
+package hashtable
+
+import (
+	"container/heap"
+	"iter"
+	"sort"
+)
+
+// Counter tallies occurrences of keys. A key whose count drops to zero is
+// removed.
+type Counter[K comparable] struct {
+	table *HashTable[K, int]
+	total int
+}
+
+func NewCounter[K comparable](opts ...Option) *Counter[K] {
+	rejectBound(applyOptions(opts), "Counter")
+
+	return &Counter[K]{
+		table: NewHashTableWithOptions[K, int](opts...),
+	}
+}
+
+// Add changes the count of key by delta and returns the new count
+func (c *Counter[K]) Add(key K, delta int) int {
+	hash, index := c.table.Hash(key)
+	c.total += delta
+
+	node := c.table.find(hash, index, key)
+
+	if node == nil {
+		if delta != 0 {
+			c.table.insertNode(c.table.newNode(hash, key, delta), index)
+		}
+
+		return delta
+	}
+
+	node.entry.Value += delta
+	count := node.entry.Value
+
+	if count == 0 {
+		c.table.Delete(key)
+	}
+
+	return count
+}
+
+func (c *Counter[K]) Count(key K) int {
+	count, _ := c.table.TryGet(key)
+
+	return count
+}
+
+// Total is the sum of all counts
+func (c *Counter[K]) Total() int {
+	return c.total
+}
+
+// Len is the number of keys with a non zero count
+func (c *Counter[K]) Len() uint32 {
+	return c.table.Size()
+}
+
+func (c *Counter[K]) Clear() {
+	c.table.Clear()
+	c.total = 0
+}
+
+func (c *Counter[K]) All() iter.Seq2[K, int] {
+	return c.table.All()
+}
+
+// TopN returns the n keys with the highest counts in descending order of
+// count, keeping only n candidates in a heap while scanning
+func (c *Counter[K]) TopN(n int) []Entry[K, int] {
+	if n <= 0 {
+		return []Entry[K, int]{}
+	}
+
+	candidates := make(countHeap[K], 0, n)
+
+	c.table.Range(func(entry Entry[K, int]) bool {
+		if len(candidates) < n {
+			heap.Push(&candidates, entry)
+		} else if entry.Value > candidates[0].Value {
+			candidates[0] = entry
+			heap.Fix(&candidates, 0)
+		}
+
+		return true
+	})
+
+	top := []Entry[K, int](candidates)
+
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Value > top[j].Value
+	})
+
+	return top
+}
+
+// countHeap is a min-heap on counts, its root is the weakest candidate
+type countHeap[K comparable] []Entry[K, int]
+
+func (h countHeap[K]) Len() int {
+	return len(h)
+}
+
+func (h countHeap[K]) Less(i, j int) bool {
+	return h[i].Value < h[j].Value
+}
+
+func (h countHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *countHeap[K]) Push(x any) {
+	*h = append(*h, x.(Entry[K, int]))
+}
+
+func (h *countHeap[K]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return entry
+}
@@ -0,0 +1,67 @@
+package hashtable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCounterAddAndCount(t *testing.T) {
+	counter := NewCounter[string]()
+
+	for _, word := range strings.Fields("a b a c a b") {
+		counter.Add(word, 1)
+	}
+
+	if counter.Count("a") != 3 || counter.Count("b") != 2 || counter.Count("z") != 0 {
+		t.Errorf("Expected counts 3, 2 and 0, got %d, %d and %d", counter.Count("a"), counter.Count("b"), counter.Count("z"))
+	}
+
+	if counter.Total() != 6 {
+		t.Errorf("Expected total to be 6, got %d", counter.Total())
+	}
+
+	if count := counter.Add("c", -1); count != 0 || counter.Len() != 2 {
+		t.Errorf("Expected c to be removed at zero, got count %d and %d keys", count, counter.Len())
+	}
+
+	counter.Clear()
+
+	if counter.Total() != 0 || counter.Len() != 0 {
+		t.Errorf("Expected an empty counter after Clear")
+	}
+}
+
+func TestCounterTopN(t *testing.T) {
+	counter := NewCounter[int]()
+
+	for i := 0; i < 100; i++ {
+		counter.Add(i, i%10)
+	}
+
+	top := counter.TopN(3)
+
+	if len(top) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(top))
+	}
+
+	for _, entry := range top {
+		if entry.Value != 9 || entry.Key%10 != 9 {
+			t.Errorf("Expected a key ending in 9 with count 9, got %v", entry)
+		}
+	}
+
+	words := NewCounter[string]()
+
+	for _, word := range strings.Fields("x y y z z z") {
+		words.Add(word, 1)
+	}
+
+	if top := words.TopN(10); fmt.Sprint(top) != "[{z 3} {y 2} {x 1}]" {
+		t.Errorf("Expected [{z 3} {y 2} {x 1}], got %v", top)
+	}
+
+	if top := words.TopN(0); len(top) != 0 {
+		t.Errorf("Expected no entries, got %v", top)
+	}
+}
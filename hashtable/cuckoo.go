@@ -0,0 +1,346 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+type cuckooSlot[K comparable, V any] struct {
+	entry Entry[K, V]
+	hash  uint64
+	used  bool
+}
+
+// CuckooTable keeps every key in one of exactly two slots, one per half,
+// so lookups and deletes inspect at most two slots. An insert evicts the
+// occupant of its slot and moves it to its alternative, and when the chain
+// of evictions runs too long both positions are recomputed with new seeds.
+// Both slots derive from the one hash of the key, so keys beyond two sharing
+// their full hash, which no seed separates, are kept in a stash that lookups
+// scan linearly. It stays empty unless keys collide that way.
+type CuckooTable[K comparable, V any] struct {
+	halves [2][]cuckooSlot[K, V]
+	stash  []cuckooSlot[K, V]
+	seeds  [2]uint64
+	mask   uint64
+	size   uint32
+	hasher Hasher[K]
+}
+
+// cuckooMaxKicks bounds the evictions of a single insert before rehashing
+const cuckooMaxKicks = 64
+
+// cuckooMaxRehashes bounds the seed changes of a rehash, after which the
+// entries still homeless go to the stash
+const cuckooMaxRehashes = 32
+
+func NewCuckooTable[K comparable, V any](opts ...Option) *CuckooTable[K, V] {
+	o := applyOptions(opts)
+	rejectBound(o, "CuckooTable")
+
+	table := CuckooTable[K, V]{
+		seeds:  [2]uint64{IntHash(uint64(1)), IntHash(uint64(2))},
+		hasher: resolveHasher[K](o),
+	}
+
+	// Two halves filled to 50% at most
+	length := uint64(4)
+
+	for uint64(o.capacity) > length {
+		length <<= 1
+	}
+
+	table.allocate(length)
+
+	return &table
+}
+
+func (c *CuckooTable[K, V]) allocate(length uint64) {
+	c.halves[0] = make([]cuckooSlot[K, V], length)
+	c.halves[1] = make([]cuckooSlot[K, V], length)
+	c.mask = length - 1
+	c.size = 0
+}
+
+func (c *CuckooTable[K, V]) index(half int, hash uint64) uint64 {
+	return IntHash(hash^c.seeds[half]) & c.mask
+}
+
+func (c *CuckooTable[K, V]) find(key K, hash uint64) *cuckooSlot[K, V] {
+	for half := range c.halves {
+		slot := &c.halves[half][c.index(half, hash)]
+
+		if slot.used && slot.hash == hash && slot.entry.Key == key {
+			return slot
+		}
+	}
+
+	for i := range c.stash {
+		if c.stash[i].hash == hash && c.stash[i].entry.Key == key {
+			return &c.stash[i]
+		}
+	}
+
+	return nil
+}
+
+// crowded reports whether both slots of hash hold keys with that same hash,
+// so that a third one could never be placed
+func (c *CuckooTable[K, V]) crowded(hash uint64) bool {
+	for half := range c.halves {
+		if slot := &c.halves[half][c.index(half, hash)]; !slot.used || slot.hash != hash {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *CuckooTable[K, V]) Insert(key K, value V) {
+	hash := c.hasher.Hash(key)
+
+	if slot := c.find(key, hash); slot != nil {
+		slot.entry.Value = value
+		return
+	}
+
+	if uint64(c.size) >= c.mask+1 {
+		c.rehash(uint64(len(c.halves[0]))<<1, cuckooSlot[K, V]{})
+	}
+
+	candidate := cuckooSlot[K, V]{
+		entry: Entry[K, V]{Key: key, Value: value},
+		hash:  hash,
+		used:  true,
+	}
+
+	if c.crowded(hash) {
+		c.stash = append(c.stash, candidate)
+		c.size++
+
+		return
+	}
+
+	homeless, placed := c.place(candidate)
+
+	if !placed {
+		c.rehash(uint64(len(c.halves[0])), homeless)
+	}
+}
+
+// place runs the eviction chain and returns the entry left without a slot
+// when it gives up
+func (c *CuckooTable[K, V]) place(candidate cuckooSlot[K, V]) (cuckooSlot[K, V], bool) {
+	for half := range c.halves {
+		if slot := &c.halves[half][c.index(half, candidate.hash)]; !slot.used {
+			*slot = candidate
+			c.size++
+			return candidate, true
+		}
+	}
+
+	half := 0
+
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		slot := &c.halves[half][c.index(half, candidate.hash)]
+
+		if !slot.used {
+			*slot = candidate
+			c.size++
+			return candidate, true
+		}
+
+		*slot, candidate = candidate, *slot
+		half ^= 1
+	}
+
+	return candidate, false
+}
+
+// rehash draws new seeds and reinserts every entry plus the pending one,
+// doubling the halves whenever a round of seeds fails as well. The entries
+// beyond two per full hash go straight to the stash, and so do those still
+// homeless once cuckooMaxRehashes rounds have failed.
+func (c *CuckooTable[K, V]) rehash(length uint64, pending cuckooSlot[K, V]) {
+	entries := make([]cuckooSlot[K, V], 0, c.size+1)
+	stash := make([]cuckooSlot[K, V], 0)
+	shared := make(map[uint64]int)
+
+	add := func(entry cuckooSlot[K, V]) {
+		if shared[entry.hash] == 2 {
+			stash = append(stash, entry)
+			return
+		}
+
+		shared[entry.hash]++
+		entries = append(entries, entry)
+	}
+
+	for half := range c.halves {
+		for _, slot := range c.halves[half] {
+			if slot.used {
+				add(slot)
+			}
+		}
+	}
+
+	for _, slot := range c.stash {
+		add(slot)
+	}
+
+	if pending.used {
+		add(pending)
+	}
+
+	for attempt := 1; ; attempt++ {
+		c.seeds[0] = IntHash(c.seeds[0] + uint64(attempt))
+		c.seeds[1] = IntHash(c.seeds[1] - uint64(attempt))
+		c.allocate(length)
+		c.stash = append(c.stash[:0], stash...)
+		c.size = uint32(len(c.stash))
+
+		placed := true
+
+		for _, entry := range entries {
+			homeless, ok := c.place(entry)
+
+			if ok {
+				continue
+			}
+
+			if attempt < cuckooMaxRehashes {
+				placed = false
+				break
+			}
+
+			c.stash = append(c.stash, homeless)
+			c.size++
+		}
+
+		if placed {
+			return
+		}
+
+		if attempt%4 == 0 {
+			length <<= 1
+		}
+	}
+}
+
+func (c *CuckooTable[K, V]) Get(key K) (value V) {
+	value, found := c.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return
+}
+
+func (c *CuckooTable[K, V]) TryGet(key K) (value V, found bool) {
+	slot := c.find(key, c.hasher.Hash(key))
+
+	if slot == nil {
+		return
+	}
+
+	return slot.entry.Value, true
+}
+
+func (c *CuckooTable[K, V]) Contains(key K) bool {
+	return c.find(key, c.hasher.Hash(key)) != nil
+}
+
+func (c *CuckooTable[K, V]) Delete(key K) (value V, found bool) {
+	slot := c.find(key, c.hasher.Hash(key))
+
+	if slot == nil {
+		return
+	}
+
+	value = slot.entry.Value
+	*slot = cuckooSlot[K, V]{}
+	c.size--
+
+	// A slot of the stash is removed rather than left unused
+	for i := range c.stash {
+		if !c.stash[i].used {
+			c.stash = append(c.stash[:i], c.stash[i+1:]...)
+			break
+		}
+	}
+
+	return value, true
+}
+
+func (c *CuckooTable[K, V]) Size() uint32 {
+	return c.size
+}
+
+func (c *CuckooTable[K, V]) Clear() {
+	for half := range c.halves {
+		for i := range c.halves[half] {
+			c.halves[half][i] = cuckooSlot[K, V]{}
+		}
+	}
+
+	c.stash = nil
+	c.size = 0
+}
+
+func (c *CuckooTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for half := range c.halves {
+			for i := range c.halves[half] {
+				slot := &c.halves[half][i]
+
+				if slot.used && !yield(slot.entry.Key, slot.entry.Value) {
+					return
+				}
+			}
+		}
+
+		for _, slot := range c.stash {
+			if !yield(slot.entry.Key, slot.entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+func (c *CuckooTable[K, V]) Keys() iter.Seq[K] {
+	return keysOf(c.All())
+}
+
+func (c *CuckooTable[K, V]) Values() iter.Seq[V] {
+	return valuesOf(c.All())
+}
+
+func (c *CuckooTable[K, V]) Iter() <-chan Entry[K, V] {
+	return entriesOf(c.All())
+}
+
+func (c *CuckooTable[K, V]) ForEach(f func(Entry[K, V])) {
+	c.Range(func(entry Entry[K, V]) bool {
+		f(entry)
+		return true
+	})
+}
+
+func (c *CuckooTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	for half := range c.halves {
+		for i := range c.halves[half] {
+			if c.halves[half][i].used && !f(c.halves[half][i].entry) {
+				return
+			}
+		}
+	}
+
+	for _, slot := range c.stash {
+		if !f(slot.entry) {
+			return
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCuckooInsertGetDelete(t *testing.T) {
+	table := NewCuckooTable[string, int]()
+
+	for i := 0; i < 10_000; i++ {
+		table.Insert(fmt.Sprint(i), i)
+	}
+
+	if table.Size() != 10_000 {
+		t.Errorf("Expected size to be 10000, got %d", table.Size())
+	}
+
+	for i := 0; i < 10_000; i += 2 {
+		if value, found := table.Delete(fmt.Sprint(i)); !found || value != i {
+			t.Errorf("Expected to delete %d, got %d (found %v)", i, value, found)
+		}
+	}
+
+	for i := 0; i < 10_000; i++ {
+		value, found := table.TryGet(fmt.Sprint(i))
+
+		if found != (i%2 == 1) || (found && value != i) {
+			t.Errorf("Expected %d to be found %v, got %d (found %v)", i, i%2 == 1, value, found)
+		}
+	}
+}
+
+func TestCuckooKeysLiveInOneOfTwoSlots(t *testing.T) {
+	table := NewCuckooTable[int, int](WithCapacity(1000))
+
+	for i := 0; i < 1000; i++ {
+		table.Insert(i, i)
+		table.Insert(i, -i)
+	}
+
+	if table.Size() != 1000 {
+		t.Errorf("Expected size to be 1000, got %d", table.Size())
+	}
+
+	for i := 0; i < 1000; i++ {
+		hash := table.hasher.Hash(i)
+		first := table.halves[0][table.index(0, hash)]
+		second := table.halves[1][table.index(1, hash)]
+
+		if !(first.used && first.entry.Key == i) && !(second.used && second.entry.Key == i) {
+			t.Errorf("Expected %d in one of its two slots", i)
+		}
+
+		if value := table.Get(i); value != -i {
+			t.Errorf("Expected value to be %d, got %d", -i, value)
+		}
+	}
+}
+
+func TestCuckooRehashesOnCycles(t *testing.T) {
+	// Only 16 distinct hashes for 8 keys in halves of 4 slots forces cycles
+	table := NewCuckooTable[int, int](WithHasher[int](HasherFunc[int](func(key int) uint64 {
+		return uint64(key % 16)
+	})))
+
+	for i := 0; i < 8; i++ {
+		table.Insert(i, i)
+	}
+
+	for i := 0; i < 8; i++ {
+		if value := table.Get(i); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+
+	count := 0
+
+	for range table.Keys() {
+		count++
+	}
+
+	if count != 8 {
+		t.Errorf("Expected 8 keys, got %d", count)
+	}
+}
+
+func TestCuckooStashesKeysSharingHashes(t *testing.T) {
+	table := NewCuckooTable[string, int](WithHasher[string](constantHasher[string]{}))
+
+	for i := 0; i < 10; i++ {
+		table.Insert(fmt.Sprint(i), i)
+	}
+
+	for i := 100; i < 1100; i++ {
+		table.Insert(fmt.Sprint(i), i)
+	}
+
+	if table.Size() != 1010 || len(table.stash) != 1008 {
+		t.Errorf("Expected 1010 keys with 1008 stashed, got %d and %d", table.Size(), len(table.stash))
+	}
+
+	if value, found := table.Delete("5"); !found || value != 5 || table.Contains("5") || table.Size() != 1009 {
+		t.Errorf("Expected 5 to be deleted from the stash")
+	}
+
+	count := 0
+
+	for key, value := range table.All() {
+		if key != fmt.Sprint(value) {
+			t.Errorf("Expected %s to map to itself, got %d", key, value)
+		}
+
+		count++
+	}
+
+	if count != 1009 {
+		t.Errorf("Expected 1009 keys, got %d", count)
+	}
+}
+
+func TestCuckooStashWithDistinctHashes(t *testing.T) {
+	// Three keys per hash: one of each trio must be stashed
+	table := NewCuckooTable[int, int](WithHasher[int](HasherFunc[int](func(key int) uint64 {
+		return uint64(key / 3)
+	})))
+
+	for i := 0; i < 3000; i++ {
+		table.Insert(i, i)
+	}
+
+	for i := 0; i < 3000; i++ {
+		if value, found := table.TryGet(i); !found || value != i {
+			t.Fatalf("Expected %d to be found, got %d", i, value)
+		}
+	}
+
+	if table.Size() != 3000 || len(table.stash) != 1000 {
+		t.Errorf("Expected 3000 keys with 1000 stashed, got %d and %d", table.Size(), len(table.stash))
+	}
+}
@@ -0,0 +1,264 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// EvictionPolicy picks the entry a table bounded by WithMaxEntries drops
+// when an insert takes it over the limit. The table reports every key it
+// adds, reads through Get or TryGet, overwrites and removes.
+type EvictionPolicy[K comparable] interface {
+	Inserted(key K)
+	Accessed(key K)
+	Removed(key K)
+	Victim() K
+}
+
+type Eviction int
+
+const (
+	EvictLRU Eviction = iota
+	EvictFIFO
+	EvictRandom
+)
+
+// WithMaxEntries bounds a HashTable to n entries, evicting one according to
+// its eviction policy, LRU unless set otherwise, whenever an insert adds the
+// n+1th. Reads update the policy, so a bounded table must not be shared by
+// concurrent readers. Only HashTable supports it: the other tables, and the
+// structures built on a HashTable that keep state of their own about every
+// entry, panic when given it or the other eviction options.
+func WithMaxEntries(n uint32) Option {
+	return func(o *options) {
+		if n == 0 {
+			msg := fmt.Sprintf("invalid max entries: %d", n)
+			panic(errors.New(msg))
+		}
+
+		o.maxEntries = n
+	}
+}
+
+func WithEviction(eviction Eviction) Option {
+	return func(o *options) {
+		o.eviction = eviction
+	}
+}
+
+// WithEvictionPolicy plugs in a custom policy. The policy holds the state of
+// a single table, so it must not be passed to several.
+func WithEvictionPolicy[K comparable](policy EvictionPolicy[K]) Option {
+	return func(o *options) {
+		o.evictionPolicy = policy
+	}
+}
+
+// OnEvict registers a callback invoked with every entry evicted to respect
+// WithMaxEntries, but not with entries deleted explicitly
+func OnEvict[K comparable, V any](f func(K, V)) Option {
+	return func(o *options) {
+		o.onEvict = f
+	}
+}
+
+type bound[K comparable, V any] struct {
+	maxEntries uint32
+	policy     EvictionPolicy[K]
+	onEvict    func(K, V)
+}
+
+func resolveBound[K comparable, V any](o options) *bound[K, V] {
+	if o.maxEntries == 0 {
+		return nil
+	}
+
+	b := bound[K, V]{maxEntries: o.maxEntries}
+
+	switch policy := o.evictionPolicy.(type) {
+	case nil:
+		b.policy = newEvictionPolicy[K](o.eviction)
+	case EvictionPolicy[K]:
+		b.policy = policy
+	default:
+		msg := fmt.Sprintf("eviction policy %T does not track keys of type %T", o.evictionPolicy, *new(K))
+		panic(errors.New(msg))
+	}
+
+	if o.onEvict != nil {
+		onEvict, ok := o.onEvict.(func(K, V))
+
+		if !ok {
+			msg := fmt.Sprintf("eviction callback %T does not take entries of type %T", o.onEvict, Entry[K, V]{})
+			panic(errors.New(msg))
+		}
+
+		b.onEvict = onEvict
+	}
+
+	return &b
+}
+
+// rejectBound panics when options meant for a bounded HashTable reach a table
+// that cannot evict
+func rejectBound(o options, table string) {
+	if o.maxEntries != 0 || o.evictionPolicy != nil || o.onEvict != nil {
+		msg := fmt.Sprintf("%s does not support WithMaxEntries, WithEvictionPolicy or OnEvict", table)
+		panic(errors.New(msg))
+	}
+}
+
+func newEvictionPolicy[K comparable](eviction Eviction) EvictionPolicy[K] {
+	switch eviction {
+	case EvictLRU:
+		return &orderPolicy[K]{order: NewOrderedHashTable[K, struct{}](WithAccessOrder()), accessOrder: true}
+	case EvictFIFO:
+		return &orderPolicy[K]{order: NewOrderedHashTable[K, struct{}]()}
+	case EvictRandom:
+		return &randomPolicy[K]{index: NewHashTable[K, int]()}
+	}
+
+	msg := fmt.Sprintf("invalid eviction: %d", eviction)
+	panic(errors.New(msg))
+}
+
+// inserted updates the policy after an insert and evicts while the table is
+// over its limit
+func (h *HashTable[K, V]) inserted(key K, added bool) {
+	if !added {
+		h.bound.policy.Accessed(key)
+		return
+	}
+
+	h.bound.policy.Inserted(key)
+
+	for h.sizeItems > h.bound.maxEntries {
+		victim := h.bound.policy.Victim()
+		value, found := h.Delete(victim)
+
+		if !found {
+			msg := fmt.Sprintf("eviction policy chose a missing key: %v", victim)
+			panic(errors.New(msg))
+		}
+
+		if h.bound.onEvict != nil {
+			h.bound.onEvict(victim, value)
+		}
+	}
+}
+
+// clone copies the policy state for Clone, which only the built-in policies
+// and custom policies with a Clone method support
+func (b *bound[K, V]) clone() *bound[K, V] {
+	clone := *b
+
+	cloner, ok := b.policy.(interface{ Clone() EvictionPolicy[K] })
+
+	if !ok {
+		msg := fmt.Sprintf("eviction policy %T cannot be cloned", b.policy)
+		panic(errors.New(msg))
+	}
+
+	clone.policy = cloner.Clone()
+
+	return &clone
+}
+
+// orderPolicy evicts the eldest key in insertion order, or in access order
+// for LRU
+type orderPolicy[K comparable] struct {
+	order       *OrderedHashTable[K, struct{}]
+	accessOrder bool
+}
+
+func (p *orderPolicy[K]) Inserted(key K) {
+	p.order.Insert(key, struct{}{})
+}
+
+func (p *orderPolicy[K]) Accessed(key K) {
+	if p.accessOrder {
+		p.order.TryGet(key)
+	}
+}
+
+func (p *orderPolicy[K]) Removed(key K) {
+	p.order.Delete(key)
+}
+
+func (p *orderPolicy[K]) Victim() K {
+	eldest, _ := p.order.Eldest()
+
+	return eldest.Key
+}
+
+func (p *orderPolicy[K]) Clone() EvictionPolicy[K] {
+	clone := orderPolicy[K]{accessOrder: p.accessOrder}
+
+	if p.accessOrder {
+		clone.order = NewOrderedHashTable[K, struct{}](WithAccessOrder())
+	} else {
+		clone.order = NewOrderedHashTable[K, struct{}]()
+	}
+
+	p.order.Range(func(entry Entry[K, struct{}]) bool {
+		clone.order.Insert(entry.Key, entry.Value)
+		return true
+	})
+
+	return &clone
+}
+
+// randomPolicy evicts a uniformly random key other than the newest one
+type randomPolicy[K comparable] struct {
+	keys   []K
+	index  *HashTable[K, int]
+	newest K
+}
+
+func (p *randomPolicy[K]) Inserted(key K) {
+	p.index.Insert(key, len(p.keys))
+	p.keys = append(p.keys, key)
+	p.newest = key
+}
+
+func (p *randomPolicy[K]) Accessed(K) {}
+
+func (p *randomPolicy[K]) Removed(key K) {
+	i, found := p.index.Delete(key)
+
+	if !found {
+		return
+	}
+
+	last := len(p.keys) - 1
+
+	if i != last {
+		p.keys[i] = p.keys[last]
+		p.index.Insert(p.keys[i], i)
+	}
+
+	var zero K
+	p.keys[last] = zero
+	p.keys = p.keys[:last]
+}
+
+func (p *randomPolicy[K]) Victim() K {
+	for {
+		victim := p.keys[rand.IntN(len(p.keys))]
+
+		if victim != p.newest || len(p.keys) == 1 {
+			return victim
+		}
+	}
+}
+
+func (p *randomPolicy[K]) Clone() EvictionPolicy[K] {
+	clone := randomPolicy[K]{
+		keys:   append([]K(nil), p.keys...),
+		index:  p.index.Clone(),
+		newest: p.newest,
+	}
+
+	return &clone
+}
@@ -0,0 +1,236 @@
+package hashtable
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMaxEntriesLRU(t *testing.T) {
+	evicted := make([]string, 0)
+
+	table := NewHashTableWithOptions[string, int](WithMaxEntries(2), OnEvict(func(key string, value int) {
+		evicted = append(evicted, fmt.Sprintf("%s=%d", key, value))
+	}))
+
+	table.Insert("a", 1)
+	table.Insert("b", 2)
+	table.Get("a")
+	table.Insert("c", 3)
+
+	if table.Contains("b") || !table.Contains("a") || table.Size() != 2 {
+		t.Errorf("Expected b to be evicted as least recently used")
+	}
+
+	table.Insert("a", 10)
+	table.Insert("d", 4)
+
+	if fmt.Sprint(evicted) != "[b=2 c=3]" {
+		t.Errorf("Expected [b=2 c=3] to be evicted, got %v", evicted)
+	}
+
+	table.Delete("a")
+
+	if len(evicted) != 2 {
+		t.Errorf("Expected explicit deletes not to be reported, got %v", evicted)
+	}
+}
+
+func TestMaxEntriesFIFO(t *testing.T) {
+	table := NewHashTableWithOptions[int, int](WithMaxEntries(3), WithEviction(EvictFIFO))
+
+	for i := 0; i < 3; i++ {
+		table.Insert(i, i)
+	}
+
+	table.Get(0)
+	table.Insert(0, 100)
+	table.Insert(3, 3)
+
+	if table.Contains(0) {
+		t.Errorf("Expected the first inserted key to be evicted despite being read")
+	}
+
+	table.Delete(1)
+	table.Insert(4, 4)
+
+	if keys := slices.Sorted(table.Keys()); fmt.Sprint(keys) != "[2 3 4]" {
+		t.Errorf("Expected [2 3 4], got %v", keys)
+	}
+
+	table.Insert(5, 5)
+
+	if table.Contains(2) {
+		t.Errorf("Expected 2 to be evicted next")
+	}
+}
+
+func TestMaxEntriesRandom(t *testing.T) {
+	table := NewHashTableWithOptions[int, int](WithMaxEntries(100), WithEviction(EvictRandom))
+
+	for i := 0; i < 1000; i++ {
+		table.Insert(i, i)
+
+		if !table.Contains(i) {
+			t.Fatalf("Expected the newest key %d never to be evicted", i)
+		}
+	}
+
+	if table.Size() != 100 {
+		t.Errorf("Expected size to be 100, got %d", table.Size())
+	}
+
+	table.RemoveIf(func(entry Entry[int, int]) bool { return entry.Key%2 == 0 })
+
+	for i := 1000; i < 1200; i++ {
+		table.Insert(i, i)
+	}
+
+	if table.Size() != 100 {
+		t.Errorf("Expected size to be 100, got %d", table.Size())
+	}
+}
+
+// smallestFirst evicts the smallest key
+type smallestFirst struct {
+	keys map[int]bool
+}
+
+func (p *smallestFirst) Inserted(key int) { p.keys[key] = true }
+func (p *smallestFirst) Accessed(int)     {}
+func (p *smallestFirst) Removed(key int)  { delete(p.keys, key) }
+
+func (p *smallestFirst) Victim() int {
+	victim := -1
+
+	for key := range p.keys {
+		if victim < 0 || key < victim {
+			victim = key
+		}
+	}
+
+	return victim
+}
+
+func TestEvictionPolicy(t *testing.T) {
+	policy := &smallestFirst{keys: map[int]bool{}}
+	table := NewHashTableWithOptions[int, string](WithMaxEntries(2), WithEvictionPolicy[int](policy))
+
+	table.Insert(5, "five")
+	table.Insert(1, "one")
+	table.Insert(9, "nine")
+
+	if table.Contains(1) || len(policy.keys) != 2 {
+		t.Errorf("Expected the custom policy to evict 1")
+	}
+
+	table.Clear()
+
+	if len(policy.keys) != 0 {
+		t.Errorf("Expected Clear to be reported to the policy, got %v", policy.keys)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	table.Clone()
+}
+
+func TestMaxEntriesClone(t *testing.T) {
+	table := NewHashTableWithOptions[int, int](WithMaxEntries(2))
+
+	table.Insert(1, 1)
+	table.Insert(2, 2)
+
+	clone := table.Clone()
+	clone.Get(1)
+	clone.Insert(3, 3)
+	table.Insert(3, 3)
+
+	if !clone.Contains(1) || clone.Contains(2) {
+		t.Errorf("Expected the clone to evict 2")
+	}
+
+	if table.Contains(1) || !table.Contains(2) {
+		t.Errorf("Expected the original to evict 1 with its own recency")
+	}
+}
+
+func TestMaxEntriesGobDecode(t *testing.T) {
+	source := NewHashTable[int, int]()
+
+	for i := 0; i < 10; i++ {
+		source.Insert(i, i)
+	}
+
+	data, _ := source.GobEncode()
+	table := NewHashTableWithOptions[int, int](WithMaxEntries(4))
+	table.Insert(100, 100)
+
+	if err := table.GobDecode(data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if table.Size() != 4 || table.Contains(100) {
+		t.Errorf("Expected the decoded table to hold 4 entries, got %d", table.Size())
+	}
+
+	table.Insert(200, 200)
+
+	if table.Size() != 4 {
+		t.Errorf("Expected the limit to survive decoding, got size %d", table.Size())
+	}
+}
+
+func TestInvalidEvictionOptions(t *testing.T) {
+	cases := map[string]func(){
+		"zero max entries": func() { NewHashTableWithOptions[int, int](WithMaxEntries(0)) },
+		"policy key type": func() {
+			NewHashTableWithOptions[string, int](WithMaxEntries(1), WithEvictionPolicy[int](&smallestFirst{}))
+		},
+		"callback type":    func() { NewHashTableWithOptions[int, int](WithMaxEntries(1), OnEvict(func(string, int) {})) },
+		"unknown eviction": func() { NewHashTableWithOptions[int, int](WithMaxEntries(1), WithEviction(Eviction(7))) },
+	}
+
+	for name, f := range cases {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected %s to panic", name)
+				}
+			}()
+
+			f()
+		}()
+	}
+}
+
+func TestEvictionOptionsRejectedByOtherTables(t *testing.T) {
+	cases := map[string]func(){
+		"ordered max entries": func() { NewOrderedHashTable[int, int](WithMaxEntries(2)) },
+		"ordered callback":    func() { NewOrderedHashTable[int, int](OnEvict(func(int, int) {})) },
+		"expiring":            func() { NewExpiringHashTable[int, int](WithMaxEntries(2)) },
+		"multimap":            func() { NewMultiMap[int, int](WithMaxEntries(2)) },
+		"counter":             func() { NewCounter[int](WithMaxEntries(2)) },
+		"set":                 func() { NewSet[int](WithEvictionPolicy[int](&smallestFirst{})) },
+		"robin hood":          func() { NewRobinHoodTable[int, int](WithMaxEntries(2)) },
+		"cuckoo":              func() { NewCuckooTable[int, int](WithMaxEntries(2)) },
+		"hopscotch":           func() { NewHopscotchTable[int, int](WithMaxEntries(2)) },
+		"swiss":               func() { NewSwissTable[int, int](WithMaxEntries(2)) },
+	}
+
+	for name, f := range cases {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected %s to panic", name)
+				}
+			}()
+
+			f()
+		}()
+	}
+}
@@ -0,0 +1,291 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type expiringValue[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func (e expiringValue[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// ExpiringHashTable hides entries once their TTL elapses. Expired entries are
+// dropped lazily when read and in bulk by Purge, which the optional janitor
+// goroutine calls periodically between Start and Stop. It is safe for
+// concurrent use.
+type ExpiringHashTable[K comparable, V any] struct {
+	mutex     sync.Mutex
+	table     *HashTable[K, expiringValue[V]]
+	now       func() time.Time
+	stop      chan struct{}
+	done      chan struct{}
+	evictions chan Entry[K, V]
+}
+
+// evictionBuffer is how many expired entries Evictions holds for a slow
+// receiver before the operations dropping them start to wait
+const evictionBuffer = 64
+
+func NewExpiringHashTable[K comparable, V any](opts ...Option) *ExpiringHashTable[K, V] {
+	rejectBound(applyOptions(opts), "ExpiringHashTable")
+
+	return &ExpiringHashTable[K, V]{
+		table: NewHashTableWithOptions[K, expiringValue[V]](opts...),
+		now:   time.Now,
+	}
+}
+
+func (e *ExpiringHashTable[K, V]) Insert(key K, value V) {
+	e.store(key, expiringValue[V]{value: value})
+}
+
+func (e *ExpiringHashTable[K, V]) InsertWithTTL(key K, value V, ttl time.Duration) {
+	e.store(key, expiringValue[V]{
+		value:     value,
+		expiresAt: e.now().Add(ttl),
+	})
+}
+
+func (e *ExpiringHashTable[K, V]) store(key K, value expiringValue[V]) {
+	e.mutex.Lock()
+
+	var expired []Entry[K, V]
+
+	// Overwriting an expired entry drops it as surely as a purge would
+	if e.evictions != nil {
+		if stored, found := e.table.TryGet(key); found && stored.expired(e.now()) {
+			expired = append(expired, Entry[K, V]{Key: key, Value: stored.value})
+		}
+	}
+
+	e.table.Insert(key, value)
+	evictions := e.evictions
+	e.mutex.Unlock()
+
+	notify(evictions, expired)
+}
+
+func (e *ExpiringHashTable[K, V]) Get(key K) V {
+	value, found := e.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return value
+}
+
+func (e *ExpiringHashTable[K, V]) TryGet(key K) (value V, found bool) {
+	e.mutex.Lock()
+
+	stored, found := e.table.TryGet(key)
+
+	if !found {
+		e.mutex.Unlock()
+		return
+	}
+
+	if stored.expired(e.now()) {
+		e.table.Delete(key)
+		evictions := e.evictions
+		e.mutex.Unlock()
+
+		notify(evictions, []Entry[K, V]{{Key: key, Value: stored.value}})
+
+		return value, false
+	}
+
+	e.mutex.Unlock()
+
+	return stored.value, true
+}
+
+func (e *ExpiringHashTable[K, V]) TTL(key K) (ttl time.Duration, found bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	stored, found := e.table.TryGet(key)
+	now := e.now()
+
+	if !found || stored.expired(now) {
+		return 0, false
+	}
+
+	if stored.expiresAt.IsZero() {
+		return 0, true
+	}
+
+	return stored.expiresAt.Sub(now), true
+}
+
+func (e *ExpiringHashTable[K, V]) Delete(key K) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	_, found := e.table.Delete(key)
+
+	return found
+}
+
+// Size counts stored entries, including expired ones not purged yet
+func (e *ExpiringHashTable[K, V]) Size() uint32 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.table.Size()
+}
+
+func (e *ExpiringHashTable[K, V]) Purge() int {
+	e.mutex.Lock()
+
+	now := e.now()
+	evictions := e.evictions
+
+	var expired []Entry[K, V]
+
+	removed := e.table.RemoveIf(func(entry Entry[K, expiringValue[V]]) bool {
+		if !entry.Value.expired(now) {
+			return false
+		}
+
+		if evictions != nil {
+			expired = append(expired, Entry[K, V]{Key: entry.Key, Value: entry.Value.value})
+		}
+
+		return true
+	})
+
+	e.mutex.Unlock()
+
+	notify(evictions, expired)
+
+	return removed
+}
+
+// Evictions returns a channel receiving every entry the table drops because
+// its TTL elapsed, whether on a read, an overwrite or a purge, from the first
+// call on. Every call returns the same channel and it is never closed. The
+// entries are sent after the table is unlocked, so a receiver may use the
+// table, but once the channel's buffer fills the operation dropping an entry
+// waits for it to be received: whoever subscribes must keep draining it.
+func (e *ExpiringHashTable[K, V]) Evictions() <-chan Entry[K, V] {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.evictions == nil {
+		e.evictions = make(chan Entry[K, V], evictionBuffer)
+	}
+
+	return e.evictions
+}
+
+func notify[K comparable, V any](evictions chan<- Entry[K, V], expired []Entry[K, V]) {
+	if evictions == nil {
+		return
+	}
+
+	for _, entry := range expired {
+		evictions <- entry
+	}
+}
+
+// Start launches the janitor goroutine purging expired entries every interval
+func (e *ExpiringHashTable[K, V]) Start(interval time.Duration) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.stop != nil {
+		return
+	}
+
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+
+	go e.janitor(interval, e.stop, e.done)
+}
+
+// Stop halts the janitor and waits for it to exit
+func (e *ExpiringHashTable[K, V]) Stop() {
+	e.mutex.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.mutex.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+func (e *ExpiringHashTable[K, V]) janitor(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.Purge()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Iter yields a snapshot of the live entries taken when it is called
+func (e *ExpiringHashTable[K, V]) Iter() <-chan Entry[K, V] {
+	e.mutex.Lock()
+
+	now := e.now()
+	entries := make([]Entry[K, V], 0, e.table.Size())
+
+	for entry := range e.table.Iter() {
+		if !entry.Value.expired(now) {
+			entries = append(entries, Entry[K, V]{Key: entry.Key, Value: entry.Value.value})
+		}
+	}
+
+	e.mutex.Unlock()
+
+	iterator := make(chan Entry[K, V])
+
+	go func() {
+		for _, entry := range entries {
+			iterator <- entry
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func (e *ExpiringHashTable[K, V]) ForEach(f func(Entry[K, V])) {
+	for entry := range e.Iter() {
+		f(entry)
+	}
+}
+
+// Range skips expired entries and holds the lock throughout, so unlike
+// ForEach it copies nothing but f must not call back into the table.
+func (e *ExpiringHashTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	now := e.now()
+
+	e.table.Range(func(entry Entry[K, expiringValue[V]]) bool {
+		return entry.Value.expired(now) || f(Entry[K, V]{Key: entry.Key, Value: entry.Value.value})
+	})
+}
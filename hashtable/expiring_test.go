@@ -0,0 +1,176 @@
+package hashtable
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func newExpiringWithClock() (*ExpiringHashTable[string, string], *fakeClock) {
+	clock := &fakeClock{current: time.Unix(0, 0)}
+	hashTable := NewExpiringHashTable[string, string]()
+	hashTable.now = clock.now
+
+	return hashTable, clock
+}
+
+func TestExpiredEntriesAreHidden(t *testing.T) {
+	hashTable, clock := newExpiringWithClock()
+
+	hashTable.InsertWithTTL("foo", "bar", time.Minute)
+	hashTable.Insert("baz", "qux")
+
+	if value, found := hashTable.TryGet("foo"); !found || value != "bar" {
+		t.Errorf("Expected value to be 'bar', got %s", value)
+	}
+
+	clock.current = clock.current.Add(time.Minute)
+
+	if _, found := hashTable.TryGet("foo"); found {
+		t.Errorf("Expected 'foo' to be expired")
+	}
+
+	if value, found := hashTable.TryGet("baz"); !found || value != "qux" {
+		t.Errorf("Expected entries without TTL to never expire")
+	}
+
+	if hashTable.Size() != 1 {
+		t.Errorf("Expected expired entry to be dropped on read, got size %d", hashTable.Size())
+	}
+}
+
+func TestPurgeAndIterSkipExpiredEntries(t *testing.T) {
+	hashTable, clock := newExpiringWithClock()
+
+	hashTable.InsertWithTTL("a", "1", time.Second)
+	hashTable.InsertWithTTL("b", "2", time.Hour)
+	hashTable.InsertWithTTL("c", "3", time.Second)
+
+	clock.current = clock.current.Add(time.Minute)
+
+	counter := 0
+	hashTable.ForEach(func(entry Entry[string, string]) {
+		if entry.Key != "b" {
+			t.Errorf("Expected only 'b' to be live, got %s", entry.Key)
+		}
+
+		counter++
+	})
+
+	if counter != 1 {
+		t.Errorf("Expected counter to be 1, got %d", counter)
+	}
+
+	if purged := hashTable.Purge(); purged != 2 {
+		t.Errorf("Expected 2 purged entries, got %d", purged)
+	}
+
+	if ttl, _ := hashTable.TTL("b"); ttl != time.Hour-time.Minute {
+		t.Errorf("Expected TTL to be 59m, got %s", ttl)
+	}
+}
+
+func TestJanitorPurgesInBackground(t *testing.T) {
+	hashTable := NewExpiringHashTable[string, int]()
+
+	hashTable.InsertWithTTL("foo", 1, time.Millisecond)
+	hashTable.Start(time.Millisecond)
+	defer hashTable.Stop()
+
+	deadline := time.Now().Add(time.Second)
+
+	for hashTable.Size() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected janitor to purge the expired entry")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStopWithoutStart(t *testing.T) {
+	hashTable := NewExpiringHashTable[string, int]()
+
+	hashTable.Stop()
+	hashTable.Start(time.Hour)
+	hashTable.Stop()
+	hashTable.Stop()
+}
+
+func TestEvictionsReportExpiredEntries(t *testing.T) {
+	hashTable, clock := newExpiringWithClock()
+
+	hashTable.InsertWithTTL("a", "1", time.Minute)
+	hashTable.InsertWithTTL("b", "2", time.Minute)
+	hashTable.InsertWithTTL("c", "3", time.Minute)
+	hashTable.Insert("d", "4")
+
+	// Entries dropped before subscribing are not reported
+	clock.current = clock.current.Add(time.Minute)
+	hashTable.TryGet("a")
+
+	evictions := hashTable.Evictions()
+
+	if hashTable.Evictions() != evictions {
+		t.Errorf("Expected every call to return the same channel")
+	}
+
+	hashTable.TryGet("b")
+	hashTable.InsertWithTTL("c", "5", time.Minute)
+	hashTable.InsertWithTTL("e", "6", time.Minute)
+	clock.current = clock.current.Add(time.Minute)
+
+	if removed := hashTable.Purge(); removed != 2 {
+		t.Errorf("Expected 2 entries to be purged, got %d", removed)
+	}
+
+	received := make([]string, 0)
+
+	for len(evictions) > 0 {
+		entry := <-evictions
+		received = append(received, entry.Key+entry.Value)
+	}
+
+	sort.Strings(received)
+
+	if fmt.Sprint(received) != "[b2 c3 c5 e6]" {
+		t.Errorf("Expected [b2 c3 c5 e6], got %v", received)
+	}
+}
+
+func TestEvictionsReceiverMayUseTheTable(t *testing.T) {
+	hashTable, clock := newExpiringWithClock()
+	evictions := hashTable.Evictions()
+
+	for i := 0; i < 2*evictionBuffer; i++ {
+		hashTable.InsertWithTTL(string(rune('a'+i)), "x", time.Second)
+	}
+
+	clock.current = clock.current.Add(time.Second)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 2*evictionBuffer; i++ {
+			<-evictions
+			hashTable.Size()
+		}
+	}()
+
+	hashTable.Purge()
+	<-done
+
+	if hashTable.Size() != 0 {
+		t.Errorf("Expected size to be 0, got %d", hashTable.Size())
+	}
+}
@@ -0,0 +1,190 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrFull = errors.New("hashtable: table is full")
+
+type fixedSlot[K comparable, V any] struct {
+	entry Entry[K, V]
+	hash  uint64
+	used  bool
+}
+
+// FixedHashTable is an open-addressing table with linear probing whose slots
+// are allocated once by the constructor. Deletions shift the following probe
+// run backwards, so no tombstones accumulate and no operation allocates.
+type FixedHashTable[K comparable, V any] struct {
+	slots    []fixedSlot[K, V]
+	mask     uint64
+	capacity uint32
+	size     uint32
+	hash     func(K) uint64
+}
+
+func NewFixedHashTable[K comparable, V any](capacity uint32, hash func(K) uint64) *FixedHashTable[K, V] {
+	if capacity == 0 {
+		msg := fmt.Sprintf("invalid capacity: %d", capacity)
+		panic(errors.New(msg))
+	}
+
+	// Keep the load factor at or below 75% when the table is full
+	length := uint64(1)
+	for length*3 < uint64(capacity)*4 {
+		length <<= 1
+	}
+
+	return &FixedHashTable[K, V]{
+		slots:    make([]fixedSlot[K, V], length),
+		mask:     length - 1,
+		capacity: capacity,
+		hash:     hash,
+	}
+}
+
+func (f *FixedHashTable[K, V]) find(key K, hash uint64) (index uint64, found bool) {
+	index = hash & f.mask
+
+	for f.slots[index].used {
+		if f.slots[index].hash == hash && f.slots[index].entry.Key == key {
+			return index, true
+		}
+
+		index = (index + 1) & f.mask
+	}
+
+	return index, false
+}
+
+func (f *FixedHashTable[K, V]) Insert(key K, value V) error {
+	hash := f.hash(key)
+	index, found := f.find(key, hash)
+
+	if found {
+		f.slots[index].entry.Value = value
+		return nil
+	}
+
+	if f.size == f.capacity {
+		return ErrFull
+	}
+
+	f.slots[index] = fixedSlot[K, V]{
+		entry: Entry[K, V]{Key: key, Value: value},
+		hash:  hash,
+		used:  true,
+	}
+	f.size++
+
+	return nil
+}
+
+func (f *FixedHashTable[K, V]) Get(key K) (value V, found bool) {
+	index, found := f.find(key, f.hash(key))
+
+	if !found {
+		return
+	}
+
+	return f.slots[index].entry.Value, true
+}
+
+func (f *FixedHashTable[K, V]) Delete(key K) bool {
+	index, found := f.find(key, f.hash(key))
+
+	if !found {
+		return false
+	}
+
+	// Backward shift: move later entries of the probe run into the hole when
+	// the hole lies between their home slot and their current slot
+	hole := index
+	next := (hole + 1) & f.mask
+
+	for f.slots[next].used {
+		home := f.slots[next].hash & f.mask
+
+		if (next-home)&f.mask >= (next-hole)&f.mask {
+			f.slots[hole] = f.slots[next]
+			hole = next
+		}
+
+		next = (next + 1) & f.mask
+	}
+
+	f.slots[hole] = fixedSlot[K, V]{}
+	f.size--
+
+	return true
+}
+
+func (f *FixedHashTable[K, V]) Size() uint32 {
+	return f.size
+}
+
+func (f *FixedHashTable[K, V]) Capacity() uint32 {
+	return f.capacity
+}
+
+func (f *FixedHashTable[K, V]) Clear() {
+	for i := range f.slots {
+		f.slots[i] = fixedSlot[K, V]{}
+	}
+
+	f.size = 0
+}
+
+func (f *FixedHashTable[K, V]) ForEach(fn func(Entry[K, V])) {
+	f.Range(func(entry Entry[K, V]) bool {
+		fn(entry)
+		return true
+	})
+}
+
+func (f *FixedHashTable[K, V]) Range(fn func(Entry[K, V]) bool) {
+	for i := range f.slots {
+		if f.slots[i].used && !fn(f.slots[i].entry) {
+			return
+		}
+	}
+}
+
+func (f *FixedHashTable[K, V]) Iter() <-chan Entry[K, V] {
+	iterator := make(chan Entry[K, V])
+
+	go func() {
+		f.ForEach(func(entry Entry[K, V]) {
+			iterator <- entry
+		})
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+// StringHash is an allocation free FNV-1a hash for string keys
+func StringHash(s string) uint64 {
+	hash := uint64(14695981039346656037)
+
+	for i := 0; i < len(s); i++ {
+		hash ^= uint64(s[i])
+		hash *= 1099511628211
+	}
+
+	return hash
+}
+
+// IntHash mixes integer keys with the splitmix64 finalizer
+func IntHash[I ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](i I) uint64 {
+	x := uint64(i)
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
@@ -0,0 +1,92 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFixedInsertAndGet(t *testing.T) {
+	table := NewFixedHashTable[string, int](100, StringHash)
+
+	for i := 0; i < 100; i++ {
+		if err := table.Insert(fmt.Sprint(i), i); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if value, ok := table.Get(fmt.Sprint(i)); !ok || value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+
+	if _, ok := table.Get("missing"); ok {
+		t.Errorf("Expected missing key to not be found")
+	}
+}
+
+func TestFixedReturnsErrFullWhenCapacityIsReached(t *testing.T) {
+	table := NewFixedHashTable[int, int](2, IntHash[int])
+
+	table.Insert(1, 1)
+	table.Insert(2, 2)
+
+	if err := table.Insert(3, 3); err != ErrFull {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+
+	if err := table.Insert(2, 20); err != nil {
+		t.Errorf("Expected overwriting an existing key to succeed, got %s", err)
+	}
+}
+
+func TestFixedDeleteKeepsProbeRunsReachable(t *testing.T) {
+	// A constant hash forces every key into a single probe run
+	table := NewFixedHashTable[int, int](8, func(int) uint64 { return 0 })
+
+	for i := 0; i < 8; i++ {
+		table.Insert(i, i)
+	}
+
+	if !table.Delete(3) {
+		t.Errorf("Expected key 3 to be deleted")
+	}
+
+	if table.Delete(3) {
+		t.Errorf("Expected second delete to report nothing removed")
+	}
+
+	for i := 0; i < 8; i++ {
+		_, ok := table.Get(i)
+
+		if ok != (i != 3) {
+			t.Errorf("Expected presence of key %d to be %v", i, i != 3)
+		}
+	}
+
+	if table.Size() != 7 {
+		t.Errorf("Expected size to be 7, got %d", table.Size())
+	}
+}
+
+func TestFixedDoesNotAllocate(t *testing.T) {
+	table := NewFixedHashTable[int, int](1024, IntHash[int])
+
+	allocations := testing.AllocsPerRun(10, func() {
+		for i := 0; i < 1000; i++ {
+			table.Insert(i, i)
+		}
+
+		for i := 0; i < 1000; i++ {
+			table.Get(i)
+		}
+
+		for i := 0; i < 1000; i++ {
+			table.Delete(i)
+		}
+	})
+
+	if allocations != 0 {
+		t.Errorf("Expected no allocations, got %f", allocations)
+	}
+}
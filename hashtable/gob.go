@@ -0,0 +1,149 @@
+package hashtable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+var errInvalidGob = errors.New("hashtable: invalid gob data")
+
+// hashSamples is how many stored hashes are checked against the decoding
+// table's hasher before trusting the saved bucket layout
+const hashSamples = 16
+
+type gobTable[K comparable, V any] struct {
+	Length       uint32
+	MinLength    uint32
+	LoadFactor   float64
+	ShrinkFactor float64
+	Keys         []K
+	Values       []V
+	Hashes       []uint64
+}
+
+// GobEncode saves the entries together with their hashes and the bucket
+// layout so decoding can skip re-hashing. A resize in progress is saved as
+// already completed.
+func (h *HashTable[K, V]) GobEncode() ([]byte, error) {
+	snapshot := gobTable[K, V]{
+		Length:       h.actualBucketLength,
+		MinLength:    h.minLength,
+		LoadFactor:   h.loadFactor,
+		ShrinkFactor: h.shrinkFactor,
+		Keys:         make([]K, 0, h.sizeItems),
+		Values:       make([]V, 0, h.sizeItems),
+		Hashes:       make([]uint64, 0, h.sizeItems),
+	}
+
+	for _, buckets := range h.bucketArrays() {
+		for _, node := range buckets {
+			for ; node != nil; node = node.next {
+				snapshot.Keys = append(snapshot.Keys, node.entry.Key)
+				snapshot.Values = append(snapshot.Values, node.entry.Value)
+				snapshot.Hashes = append(snapshot.Hashes, node.hash)
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(snapshot); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// GobDecode replaces the contents of the table. An initialized table keeps
+// its hasher, default provider and entry limit, a zero table gets the
+// default hasher. The saved layout is reused
+// when the hasher reproduces the stored hashes, otherwise every entry is
+// inserted again.
+func (h *HashTable[K, V]) GobDecode(data []byte) error {
+	var snapshot gobTable[K, V]
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot); err != nil {
+		return err
+	}
+
+	// Resizes double from MinLength, so a MinLength of 0 would never grow
+	// and one that is not a power of two could never be shrunk back to. The
+	// factors follow the same rules as in NewHashTableWithOptions, which set
+	// operations call again with them.
+	valid := snapshot.Length > 0 &&
+		snapshot.LoadFactor > 0 && snapshot.LoadFactor < 1 &&
+		snapshot.MinLength > 0 && snapshot.MinLength&(snapshot.MinLength-1) == 0 &&
+		snapshot.MinLength <= snapshot.Length &&
+		snapshot.ShrinkFactor >= 0 && snapshot.ShrinkFactor < snapshot.LoadFactor/2 &&
+		len(snapshot.Values) == len(snapshot.Keys) &&
+		len(snapshot.Hashes) == len(snapshot.Keys)
+
+	if !valid {
+		return errInvalidGob
+	}
+
+	hasher := h.hasher
+
+	if hasher == nil {
+		hasher = newDefaultHasher[K]()
+	}
+
+	// A bounded table must report the entries it drops to its policy
+	if h.bound != nil {
+		h.Clear()
+	}
+
+	*h = HashTable[K, V]{
+		minLength:    snapshot.MinLength,
+		loadFactor:   snapshot.LoadFactor,
+		shrinkFactor: snapshot.ShrinkFactor,
+		hasher:       hasher,
+		defaultFn:    h.defaultFn,
+		bound:        h.bound,
+	}
+
+	h.resetBucket(snapshot.Length)
+
+	if h.bound != nil || !h.hasherMatches(snapshot) {
+		for i, key := range snapshot.Keys {
+			h.Insert(key, snapshot.Values[i])
+		}
+
+		return nil
+	}
+
+	for i, key := range snapshot.Keys {
+		node := &Node[K, V]{
+			hash: snapshot.Hashes[i],
+			entry: Entry[K, V]{
+				Key:   key,
+				Value: snapshot.Values[i],
+			},
+		}
+
+		index := h.generateIndex(node.hash)
+
+		if h.buckets[index] == nil {
+			h.actualBucketSize++
+		}
+
+		node.next = h.buckets[index]
+		h.buckets[index] = node
+		h.sizeItems++
+	}
+
+	return nil
+}
+
+func (h *HashTable[K, V]) hasherMatches(snapshot gobTable[K, V]) bool {
+	step := len(snapshot.Keys)/hashSamples + 1
+
+	for i := 0; i < len(snapshot.Keys); i += step {
+		if h.generateHash(snapshot.Keys[i]) != snapshot.Hashes[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package hashtable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func roundTrip[K comparable, V any](t *testing.T, source *HashTable[K, V], target *HashTable[K, V]) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(source); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := gob.NewDecoder(&buffer).Decode(target); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestGobRoundTripKeepsLayout(t *testing.T) {
+	source := NewHashTable[string, int]()
+
+	for i := 0; i < 500; i++ {
+		source.Insert(fmt.Sprint(i), i)
+	}
+
+	var target HashTable[string, int]
+	roundTrip(t, source, &target)
+
+	if target.Size() != source.Size() {
+		t.Errorf("Expected size to be %d, got %d", source.Size(), target.Size())
+	}
+
+	if target.actualBucketLength != source.actualBucketLength || target.migrating() {
+		t.Errorf("Expected %d settled buckets, got %d (migrating %v)", source.actualBucketLength, target.actualBucketLength, target.migrating())
+	}
+
+	for i := 0; i < 500; i++ {
+		if value := target.Get(fmt.Sprint(i)); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+
+	target.Insert("extra", -1)
+
+	if target.Get("extra") != -1 {
+		t.Errorf("Expected the decoded table to accept inserts")
+	}
+}
+
+func TestGobDecodeRehashesWithDifferentHasher(t *testing.T) {
+	source := NewHashTable[string, int]()
+
+	for i := 0; i < 100; i++ {
+		source.Insert(fmt.Sprint(i), i)
+	}
+
+	target := NewHashTableWithOptions[string, int](WithHasher[string](HasherFunc[string](func(key string) uint64 {
+		return StringHash(key) ^ 0x5bd1e995
+	})))
+	target.Insert("stale", 0)
+
+	roundTrip(t, source, target)
+
+	if target.Size() != 100 || target.Contains("stale") {
+		t.Errorf("Expected the decoded entries to replace the table, got size %d", target.Size())
+	}
+
+	for i := 0; i < 100; i++ {
+		if value := target.Get(fmt.Sprint(i)); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+}
+
+func TestGobDecodeRejectsInvalidData(t *testing.T) {
+	var target HashTable[string, int]
+
+	if err := target.GobDecode([]byte("garbage")); err == nil {
+		t.Errorf("Expected an error for invalid data")
+	}
+}
+
+func TestGobDecodeRejectsInvalidSizing(t *testing.T) {
+	valid := gobTable[string, int]{Length: 8, MinLength: 2, LoadFactor: 0.5, ShrinkFactor: 0.125}
+
+	cases := map[string]func(*gobTable[string, int]){
+		"zero min length":            func(s *gobTable[string, int]) { s.MinLength = 0 },
+		"min length not a power":     func(s *gobTable[string, int]) { s.MinLength = 3 },
+		"min length over length":     func(s *gobTable[string, int]) { s.MinLength = 16 },
+		"negative shrink factor":     func(s *gobTable[string, int]) { s.ShrinkFactor = -0.1 },
+		"shrink factor at load":      func(s *gobTable[string, int]) { s.ShrinkFactor = 0.5 },
+		"shrink factor over half":    func(s *gobTable[string, int]) { s.ShrinkFactor = 0.3 },
+		"shrink factor not a number": func(s *gobTable[string, int]) { s.ShrinkFactor = math.NaN() },
+	}
+
+	for name, corrupt := range cases {
+		snapshot := valid
+		corrupt(&snapshot)
+
+		var buffer bytes.Buffer
+		gob.NewEncoder(&buffer).Encode(snapshot)
+
+		var target HashTable[string, int]
+
+		if err := target.GobDecode(buffer.Bytes()); err != errInvalidGob {
+			t.Errorf("Expected %s to be rejected, got %v", name, err)
+		}
+	}
+
+	var buffer bytes.Buffer
+	gob.NewEncoder(&buffer).Encode(valid)
+
+	var target HashTable[string, int]
+
+	if err := target.GobDecode(buffer.Bytes()); err != nil {
+		t.Errorf("Expected valid sizing to be accepted, got %v", err)
+	}
+
+	// The decoded factors must be accepted again by the constructor
+	target.Union(NewHashTable[string, int]())
+}
@@ -0,0 +1,195 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+type Hasher[K any] interface {
+	Hash(key K) uint64
+}
+
+type HasherFunc[K any] func(key K) uint64
+
+func (f HasherFunc[K]) Hash(key K) uint64 {
+	return f(key)
+}
+
+// DefaultHasher returns the hasher tables use for K when none is configured,
+// for structures outside this package that hash the same keys
+func DefaultHasher[K any]() Hasher[K] {
+	return newDefaultHasher[K]()
+}
+
+// newDefaultHasher picks a specialized hash once per key type. Keys whose
+// underlying type is a string, integer, float or bool are hashed straight from
+// memory; any other type is walked by reflection, see valueHash. Every variant
+// is stateless and therefore safe for concurrent use.
+func newDefaultHasher[K any]() Hasher[K] {
+	var zero K
+	kind := reflect.Invalid
+
+	if t := reflect.TypeOf(zero); t != nil {
+		kind = t.Kind()
+	}
+
+	switch kind {
+	case reflect.String:
+		return HasherFunc[K](func(key K) uint64 {
+			return StringHash(*(*string)(unsafe.Pointer(&key)))
+		})
+	case reflect.Int:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*int)(unsafe.Pointer(&key)))
+		})
+	case reflect.Int8:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*int8)(unsafe.Pointer(&key)))
+		})
+	case reflect.Int16:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*int16)(unsafe.Pointer(&key)))
+		})
+	case reflect.Int32:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*int32)(unsafe.Pointer(&key)))
+		})
+	case reflect.Int64:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*int64)(unsafe.Pointer(&key)))
+		})
+	case reflect.Uint:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*uint)(unsafe.Pointer(&key)))
+		})
+	case reflect.Uint8:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*uint8)(unsafe.Pointer(&key)))
+		})
+	case reflect.Uint16:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*uint16)(unsafe.Pointer(&key)))
+		})
+	case reflect.Uint32:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*uint32)(unsafe.Pointer(&key)))
+		})
+	case reflect.Uint64:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*uint64)(unsafe.Pointer(&key)))
+		})
+	case reflect.Uintptr:
+		return HasherFunc[K](func(key K) uint64 {
+			return IntHash(*(*uintptr)(unsafe.Pointer(&key)))
+		})
+	case reflect.Float32:
+		return HasherFunc[K](func(key K) uint64 {
+			return floatHash(float64(*(*float32)(unsafe.Pointer(&key))))
+		})
+	case reflect.Float64:
+		return HasherFunc[K](func(key K) uint64 {
+			return floatHash(*(*float64)(unsafe.Pointer(&key)))
+		})
+	case reflect.Bool:
+		return HasherFunc[K](func(key K) uint64 {
+			if *(*bool)(unsafe.Pointer(&key)) {
+				return IntHash(1)
+			}
+
+			return IntHash(0)
+		})
+	}
+
+	return HasherFunc[K](func(key K) uint64 {
+		return valueHash(reflect.ValueOf(&key).Elem(), hashSeed)
+	})
+}
+
+const hashSeed = 0x9e3779b97f4a7c15
+
+func mixHash(state, x uint64) uint64 {
+	return IntHash(state^x) + hashSeed
+}
+
+// valueHash folds v into state field by field, consistently with ==: exported
+// or not, every field counts, pointers and channels hash by address and
+// interfaces by their dynamic value. Slices and maps, which only appear in
+// keys of structures that are not tables, hash by content.
+func valueHash(v reflect.Value, state uint64) uint64 {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return mixHash(state, 1)
+		}
+
+		return mixHash(state, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mixHash(state, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mixHash(state, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return mixHash(state, floatHash(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return mixHash(mixHash(state, floatHash(real(c))), floatHash(imag(c)))
+	case reflect.String:
+		return mixHash(state, StringHash(v.String()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mixHash(state, uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return mixHash(state, 0)
+		}
+
+		elem := v.Elem()
+
+		return valueHash(elem, mixHash(state, StringHash(elem.Type().String())))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			state = valueHash(v.Index(i), state)
+		}
+
+		return state
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// Blank fields are skipped by == too
+			if v.Type().Field(i).Name != "_" {
+				state = valueHash(v.Field(i), state)
+			}
+		}
+
+		return state
+	case reflect.Slice:
+		state = mixHash(state, uint64(v.Len()))
+
+		for i := 0; i < v.Len(); i++ {
+			state = valueHash(v.Index(i), state)
+		}
+
+		return state
+	case reflect.Map:
+		// Sum the entries so that iteration order does not matter
+		sum := uint64(0)
+
+		for iter := v.MapRange(); iter.Next(); {
+			sum += valueHash(iter.Value(), valueHash(iter.Key(), hashSeed))
+		}
+
+		return mixHash(mixHash(state, uint64(v.Len())), sum)
+	}
+
+	msg := fmt.Sprintf("cannot hash keys of type %s", v.Type())
+	panic(errors.New(msg))
+}
+
+func floatHash(f float64) uint64 {
+	// 0 and -0 compare equal so they must hash alike
+	if f == 0 {
+		f = 0
+	}
+
+	return IntHash(math.Float64bits(f))
+}
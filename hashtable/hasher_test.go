@@ -0,0 +1,121 @@
+package hashtable
+
+import (
+	"math"
+	"testing"
+)
+
+type userID string
+
+type point struct {
+	X, Y int
+}
+
+func TestDefaultHasherSupportsNamedPrimitiveTypes(t *testing.T) {
+	hashTable := NewHashTable[userID, int]()
+
+	hashTable.Insert("alice", 1)
+	hashTable.Insert("bob", 2)
+
+	if value := hashTable.Get("bob"); value != 2 {
+		t.Errorf("Expected value to be 2, got %d", value)
+	}
+}
+
+func TestDefaultHasherTreatsZeroAndNegativeZeroAlike(t *testing.T) {
+	hashTable := NewHashTable[float64, string]()
+
+	hashTable.Insert(0, "zero")
+
+	if value, found := hashTable.TryGet(math.Copysign(0, -1)); !found || value != "zero" {
+		t.Errorf("Expected -0 to find the entry stored under 0")
+	}
+}
+
+func TestDefaultHasherFallsBackForStructs(t *testing.T) {
+	hashTable := NewHashTable[point, string]()
+
+	hashTable.Insert(point{1, 2}, "a")
+	hashTable.Insert(point{2, 1}, "b")
+
+	if value := hashTable.Get(point{1, 2}); value != "a" {
+		t.Errorf("Expected value to be 'a', got %s", value)
+	}
+
+	if hashTable.Size() != 2 {
+		t.Errorf("Expected size to be 2, got %d", hashTable.Size())
+	}
+}
+
+func TestDefaultHasherIsDeterministic(t *testing.T) {
+	a := newDefaultHasher[string]()
+	b := newDefaultHasher[string]()
+
+	if a.Hash("foo") != b.Hash("foo") {
+		t.Errorf("Expected hashers to agree on the same key")
+	}
+
+	if a.Hash("foo") == a.Hash("bar") {
+		t.Errorf("Expected different keys to hash differently")
+	}
+}
+
+func TestDefaultHasherDoesNotAllocateForPrimitives(t *testing.T) {
+	strings := newDefaultHasher[string]()
+	ints := newDefaultHasher[int64]()
+
+	allocations := testing.AllocsPerRun(100, func() {
+		strings.Hash("some key")
+		ints.Hash(42)
+	})
+
+	if allocations != 0 {
+		t.Errorf("Expected no allocations, got %f", allocations)
+	}
+}
+
+type cell struct {
+	row, column int
+	label       string
+	_           int
+}
+
+func TestDefaultHasherSeesUnexportedFields(t *testing.T) {
+	hasher := newDefaultHasher[cell]()
+	seen := make(map[uint64]bool)
+
+	for row := 0; row < 10; row++ {
+		for column := 0; column < 10; column++ {
+			seen[hasher.Hash(cell{row: row, column: column})] = true
+		}
+	}
+
+	if len(seen) != 100 {
+		t.Errorf("Expected 100 distinct hashes, got %d", len(seen))
+	}
+
+	if hasher.Hash(cell{label: "a"}) != hasher.Hash(cell{label: "a"}) {
+		t.Errorf("Expected equal keys to hash alike")
+	}
+}
+
+func TestDefaultHasherInterfaceKeys(t *testing.T) {
+	hashTable := NewHashTable[any, int]()
+
+	hashTable.Insert(1, 1)
+	hashTable.Insert("1", 2)
+	hashTable.Insert(point{1, 1}, 3)
+	hashTable.Insert(nil, 4)
+
+	if hashTable.Get(1) != 1 || hashTable.Get("1") != 2 || hashTable.Get(point{1, 1}) != 3 || hashTable.Get(nil) != 4 {
+		t.Errorf("Expected every key to find its value")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	newDefaultHasher[any]().Hash(func() {})
+}
@@ -1,13 +1,12 @@
 package hashtable
 
 import (
-	"bytes"
-	"encoding/gob"
 	"errors"
 	"fmt"
-	"hash"
-	"hash/fnv"
+	"iter"
+	"unsafe"
 
+	"algorithms/arena"
 	"algorithms/iterator"
 )
 
@@ -18,67 +17,209 @@ type Node[K, V any] struct {
 }
 
 type Entry[K, V any] struct {
-	Key   K
-	Value V
+	Key   K `json:"key"`
+	Value V `json:"value"`
 }
 
-type HashTable[K, V any] struct {
+type HashTable[K comparable, V any] struct {
 	actualBucketLength uint32
 	actualBucketSize   uint32
 	sizeItems          uint32
+	growAt             uint32
+	shrinkAt           uint32
+	minLength          uint32
+	loadFactor         float64
+	shrinkFactor       float64
+	resizes            uint32
+	free               *Node[K, V]
+	arena              *arena.Arena[Node[K, V]]
+	freeSize           uint32
+	trees              map[uint32]*chainTree[K, V]
+	oldBuckets         []*Node[K, V]
+	oldBucketSize      uint32
+	migrateIndex       uint32
 	buckets            []*Node[K, V]
-	hasher             hash.Hash64
+	hasher             Hasher[K]
+	defaultFn          func(K) V
+	bound              *bound[K, V]
 }
 
-func NewHashTable[K, V any]() *HashTable[K, V] {
-	hashTable := HashTable[K, V]{
-		actualBucketLength: 2,
-		actualBucketSize:   0,
-		sizeItems:          0,
-		hasher:             fnv.New64(),
-	}
+func NewHashTable[K comparable, V any]() *HashTable[K, V] {
+	return NewHashTableWithOptions[K, V]()
+}
 
-	hashTable.buckets = make([]*Node[K, V], hashTable.actualBucketLength)
+// NewHashTableWithDefault returns a table whose Get inserts and returns
+// defaultFn(key) for a missing key instead of panicking. TryGet and Contains
+// are unaffected.
+func NewHashTableWithDefault[K comparable, V any](defaultFn func(K) V, opts ...Option) *HashTable[K, V] {
+	hashTable := NewHashTableWithOptions[K, V](opts...)
+	hashTable.defaultFn = defaultFn
 
-	return &hashTable
+	return hashTable
 }
 
 func (h *HashTable[K, V]) isFull() bool {
-	return h.actualBucketSize > (h.actualBucketLength >> 1)
+	return h.actualBucketSize > h.growAt
 }
 
 func (h *HashTable[K, V]) resetBucket(newLength uint32) {
 	h.actualBucketLength = newLength
+	h.growAt = uint32(float64(newLength) * h.loadFactor)
+	h.shrinkAt = uint32(float64(newLength) * h.shrinkFactor)
 	h.buckets = make([]*Node[K, V], h.actualBucketLength)
+	h.trees = nil
 	h.actualBucketSize = 0
 	h.sizeItems = 0
 }
 
+// Resize doubles the bucket array. Entries are moved over incrementally by
+// the following writes, see rehash.
 func (h *HashTable[K, V]) Resize() {
-	// Copy all nodes to the tempBucket
-	tempBucket := make([]*Node[K, V], h.actualBucketLength)
-	copy(tempBucket, h.buckets)
+	h.rehash(h.actualBucketLength << 1)
+}
 
-	h.resetBucket(h.actualBucketLength << 1)
+// migrateStep is how many old buckets each write moves to the new array
+const migrateStep = 2
 
-	// Insert all nodes from the tempBucket to the new bucket
-	for _, node := range tempBucket {
-		if node == nil {
-			continue
+// rehash swaps in a bucket array of newLength and keeps the current one
+// around until every chain has been migrated, so no single operation pays
+// for moving the whole table.
+func (h *HashTable[K, V]) rehash(newLength uint32) {
+	h.finishMigration()
+	h.resizes++
+
+	h.oldBuckets = h.buckets
+	h.oldBucketSize = h.actualBucketSize
+	h.migrateIndex = 0
+
+	size := h.sizeItems
+	h.resetBucket(newLength)
+	h.sizeItems = size
+
+	if h.oldBucketSize == 0 {
+		h.oldBuckets = nil
+	}
+}
+
+func (h *HashTable[K, V]) migrating() bool {
+	return h.oldBuckets != nil
+}
+
+// migrateBucket relinks one old chain into the new array
+func (h *HashTable[K, V]) migrateBucket(oldIndex uint32) {
+	node := h.oldBuckets[oldIndex]
+
+	if node == nil {
+		return
+	}
+
+	h.oldBuckets[oldIndex] = nil
+	h.oldBucketSize--
+
+	for node != nil {
+		next := node.next
+		index := h.generateIndex(node.hash)
+
+		if h.buckets[index] == nil {
+			h.actualBucketSize++
 		}
 
-		for {
-			h.Insert(node.entry.Key, node.entry.Value)
+		node.next = h.buckets[index]
+		h.buckets[index] = node
 
-			if node.next == nil {
-				break
-			}
+		if tree := h.trees[index]; tree != nil {
+			tree.insert(node)
+		}
+
+		node = next
+	}
+}
+
+// migrateFor moves the old chain that may hold hash, then advances the
+// sequential sweep by migrateStep buckets
+func (h *HashTable[K, V]) migrateFor(hash uint64) {
+	if !h.migrating() {
+		return
+	}
 
-			node = node.next
+	h.migrateBucket(uint32(hash % uint64(len(h.oldBuckets))))
+
+	for i := 0; i < migrateStep && h.migrating(); i++ {
+		h.migrateBucket(h.migrateIndex)
+		h.migrateIndex++
+
+		if h.migrateIndex == uint32(len(h.oldBuckets)) || h.oldBucketSize == 0 {
+			h.oldBuckets = nil
 		}
 	}
 }
 
+// bucketArrays returns the arrays holding entries, the old one included
+// while a resize is in progress
+func (h *HashTable[K, V]) bucketArrays() [][]*Node[K, V] {
+	if h.migrating() {
+		return [][]*Node[K, V]{h.buckets, h.oldBuckets}
+	}
+
+	return [][]*Node[K, V]{h.buckets}
+}
+
+func (h *HashTable[K, V]) finishMigration() {
+	for h.migrating() {
+		h.migrateFor(0)
+	}
+}
+
+// newNode takes a node from the free list when one is available
+func (h *HashTable[K, V]) newNode(hash uint64, key K, value V) *Node[K, V] {
+	node := h.free
+
+	if node == nil && h.arena != nil {
+		node = h.arena.New()
+	} else if node == nil {
+		node = &Node[K, V]{}
+	} else {
+		h.free = node.next
+		h.freeSize--
+	}
+
+	node.hash = hash
+	node.entry.Key = key
+	node.entry.Value = value
+	node.next = nil
+
+	return node
+}
+
+// release keeps removed nodes for reuse, up to one per bucket
+func (h *HashTable[K, V]) release(node *Node[K, V]) {
+	if h.freeSize >= h.actualBucketLength {
+		return
+	}
+
+	*node = Node[K, V]{next: h.free}
+	h.free = node
+	h.freeSize++
+}
+
+// shrink rebuilds into the smallest bucket array that holds the remaining
+// entries once occupancy falls under the shrink threshold
+func (h *HashTable[K, V]) shrink() {
+	if h.migrating() || h.actualBucketLength <= h.minLength || h.actualBucketSize >= h.shrinkAt {
+		return
+	}
+
+	length := h.minLength
+
+	for float64(h.sizeItems) > float64(length)*h.loadFactor {
+		length <<= 1
+	}
+
+	if length < h.actualBucketLength {
+		h.rehash(length)
+	}
+}
+
 func (h HashTable[K, V]) Hash(key K) (hash uint64, index uint32) {
 
 	hash = h.generateHash(key)
@@ -88,15 +229,7 @@ func (h HashTable[K, V]) Hash(key K) (hash uint64, index uint32) {
 }
 
 func (h HashTable[K, V]) generateHash(key K) (hash uint64) {
-	defer h.hasher.Reset()
-
-	keyBuffer := bytes.Buffer{}
-	gob.NewEncoder(&keyBuffer).Encode(key)
-
-	h.hasher.Write(keyBuffer.Bytes())
-	hash = uint64(h.hasher.Sum64())
-
-	return
+	return h.hasher.Hash(key)
 }
 
 func (h *HashTable[K, V]) generateIndex(hash uint64) uint32 {
@@ -106,22 +239,28 @@ func (h *HashTable[K, V]) generateIndex(hash uint64) uint32 {
 func (h *HashTable[K, V]) Insert(key K, value V) {
 	hash, index := h.Hash(key)
 
-	newNode := &Node[K, V]{
-		hash: hash,
-		entry: Entry[K, V]{
-			Key:   key,
-			Value: value,
-		},
-	}
-
-	h.insertNode(newNode, index)
+	h.insertNode(h.newNode(hash, key, value), index)
 }
 
 func (h *HashTable[K, V]) insertNode(newNode *Node[K, V], index uint32) {
+	h.migrateFor(newNode.hash)
+
+	key, size := newNode.entry.Key, h.sizeItems
+
 	if h.buckets[index] == nil {
 		h.buckets[index] = newNode
 		h.actualBucketSize++
 		h.sizeItems++
+	} else if tree := h.trees[index]; tree != nil {
+		if node := tree.find(newNode.hash, newNode.entry.Key); node != nil {
+			node.entry = newNode.entry
+			h.release(newNode)
+		} else {
+			newNode.next = h.buckets[index]
+			h.buckets[index] = newNode
+			tree.insert(newNode)
+			h.sizeItems++
+		}
 	} else {
 		h.HandleColision(newNode, h.buckets[index], index)
 	}
@@ -129,12 +268,19 @@ func (h *HashTable[K, V]) insertNode(newNode *Node[K, V], index uint32) {
 	if h.isFull() {
 		h.Resize()
 	}
+
+	if h.bound != nil {
+		h.inserted(key, h.sizeItems > size)
+	}
 }
 
 func (h *HashTable[K, V]) HandleColision(newNode *Node[K, V], colidedNode *Node[K, V], index uint32) {
+	length := 1
+
 	for {
-		if colidedNode.hash == newNode.hash {
+		if colidedNode.hash == newNode.hash && colidedNode.entry.Key == newNode.entry.Key {
 			colidedNode.entry = newNode.entry
+			h.release(newNode)
 			return
 		}
 
@@ -143,72 +289,353 @@ func (h *HashTable[K, V]) HandleColision(newNode *Node[K, V], colidedNode *Node[
 		}
 
 		colidedNode = colidedNode.next
+		length++
 	}
 
 	colidedNode.next = newNode
 	h.sizeItems++
+
+	if length+1 >= treeifyThreshold {
+		if h.trees == nil {
+			h.trees = make(map[uint32]*chainTree[K, V])
+		}
+
+		h.trees[index] = newChainTree(h.buckets[index])
+	}
 }
 
 func (h *HashTable[K, V]) Get(key K) (value V) {
-
 	hash, index := h.Hash(key)
-	var node = h.buckets[index]
 
-	if node == nil {
+	if node := h.find(hash, index, key); node != nil {
+		if h.bound != nil {
+			h.bound.policy.Accessed(key)
+		}
+
+		return node.entry.Value
+	}
+
+	if h.defaultFn == nil {
 		msg := fmt.Sprintf("key not found: %v", key)
 		panic(errors.New(msg))
 	}
 
-	for {
-		if node.hash == hash {
-			return node.entry.Value
+	return h.insertComputed(key, hash, h.defaultFn(key))
+}
+
+func (h *HashTable[K, V]) TryGet(key K) (value V, found bool) {
+	hash, index := h.Hash(key)
+
+	if node := h.find(hash, index, key); node != nil {
+		if h.bound != nil {
+			h.bound.policy.Accessed(key)
 		}
 
-		if node.next == nil {
-			break
+		return node.entry.Value, true
+	}
+
+	return
+}
+
+// find looks in the old array too while a resize is in progress. It never
+// migrates so that concurrent readers stay read-only.
+func (h *HashTable[K, V]) find(hash uint64, index uint32, key K) *Node[K, V] {
+	if len(h.trees) > 0 {
+		if tree := h.trees[index]; tree != nil {
+			if node := tree.find(hash, key); node != nil {
+				return node
+			}
+
+			return h.findOld(hash, key)
+		}
+	}
+
+	for node := h.buckets[index]; node != nil; node = node.next {
+		if node.hash == hash && node.entry.Key == key {
+			return node
+		}
+	}
+
+	return h.findOld(hash, key)
+}
+
+func (h *HashTable[K, V]) findOld(hash uint64, key K) *Node[K, V] {
+	if !h.migrating() {
+		return nil
+	}
+
+	oldIndex := hash % uint64(len(h.oldBuckets))
+
+	for node := h.oldBuckets[oldIndex]; node != nil; node = node.next {
+		if node.hash == hash && node.entry.Key == key {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func (h *HashTable[K, V]) Contains(key K) bool {
+	hash, index := h.Hash(key)
+
+	return h.find(hash, index, key) != nil
+}
+
+func (h *HashTable[K, V]) Delete(key K) (value V, found bool) {
+	hash, index := h.Hash(key)
+	h.migrateFor(hash)
+
+	removed := h.unlinkIf(index, func(node *Node[K, V]) bool {
+		if node.hash != hash || node.entry.Key != key {
+			return false
 		}
 
-		node = node.next
+		value = node.entry.Value
+		return true
+	})
+
+	if removed == 0 {
+		return
+	}
+
+	h.shrink()
+
+	return value, true
+}
+
+func (h *HashTable[K, V]) DeleteAll(keys []K) int {
+	keysByIndex := make(map[uint32][]K)
+
+	for _, key := range keys {
+		hash, index := h.Hash(key)
+		h.migrateFor(hash)
+		keysByIndex[index] = append(keysByIndex[index], key)
+	}
+
+	removed := 0
+
+	for index, keys := range keysByIndex {
+		removed += h.unlinkIf(index, func(node *Node[K, V]) bool {
+			for _, key := range keys {
+				if node.entry.Key == key {
+					return true
+				}
+			}
+
+			return false
+		})
+	}
+
+	h.shrink()
+
+	return removed
+}
+
+func (h *HashTable[K, V]) RemoveIf(f func(Entry[K, V]) bool) int {
+	h.finishMigration()
+
+	removed := 0
+
+	for index := range h.buckets {
+		removed += h.unlinkIf(uint32(index), func(node *Node[K, V]) bool {
+			return f(node.entry)
+		})
+	}
+
+	h.shrink()
+
+	return removed
+}
+
+func (h *HashTable[K, V]) unlinkIf(index uint32, f func(*Node[K, V]) bool) int {
+	removed := 0
+	link := &h.buckets[index]
+	tree := h.trees[index]
+
+	for *link != nil {
+		if node := *link; f(node) {
+			*link = node.next
+
+			if tree != nil {
+				tree.remove(node)
+			}
+
+			if h.bound != nil {
+				h.bound.policy.Removed(node.entry.Key)
+			}
+
+			h.release(node)
+			removed++
+			continue
+		}
+
+		link = &(*link).next
+	}
+
+	if tree != nil && tree.size < untreeifyThreshold {
+		delete(h.trees, index)
+	}
+
+	if removed > 0 {
+		h.sizeItems -= uint32(removed)
+
+		if h.buckets[index] == nil {
+			h.actualBucketSize--
+		}
+	}
+
+	return removed
+}
+
+// Clear removes every entry but keeps the bucket array for reuse
+func (h *HashTable[K, V]) Clear() {
+	h.finishMigration()
+
+	for i, node := range h.buckets {
+		for node != nil {
+			next := node.next
+
+			if h.bound != nil {
+				h.bound.policy.Removed(node.entry.Key)
+			}
+
+			h.release(node)
+			node = next
+		}
+
+		h.buckets[i] = nil
+	}
+
+	h.trees = nil
+
+	h.actualBucketSize = 0
+	h.sizeItems = 0
+}
+
+// Clone copies the buckets and chains so the copy can be mutated independently
+func (h *HashTable[K, V]) Clone() *HashTable[K, V] {
+	clone := *h
+	clone.free = nil
+	clone.freeSize = 0
+	clone.buckets = cloneChains(h.buckets)
+	clone.trees = nil
+
+	if h.bound != nil {
+		clone.bound = h.bound.clone()
+	}
+
+	for index := range h.trees {
+		if clone.trees == nil {
+			clone.trees = make(map[uint32]*chainTree[K, V], len(h.trees))
+		}
+
+		clone.trees[index] = newChainTree(clone.buckets[index])
 	}
 
-	msg := fmt.Sprintf("key not found: %v", key)
-	panic(errors.New(msg))
+	if h.migrating() {
+		clone.oldBuckets = cloneChains(h.oldBuckets)
+	}
+
+	return &clone
 }
 
-func (h *HashTable[K, V]) Delete(key K) {
-	_, index := h.Hash(key)
+func cloneChains[K, V any](buckets []*Node[K, V]) []*Node[K, V] {
+	clone := make([]*Node[K, V], len(buckets))
+
+	for i, node := range buckets {
+		link := &clone[i]
+
+		for ; node != nil; node = node.next {
+			*link = &Node[K, V]{
+				entry: node.entry,
+				hash:  node.hash,
+			}
+
+			link = &(*link).next
+		}
+	}
 
-	h.buckets[index] = nil
-	h.actualBucketSize--
+	return clone
 }
 
 func (h *HashTable[K, V]) Size() uint32 {
 	return h.sizeItems
 }
 
-func (h *HashTable[K, V]) Iter() <-chan Entry[K, V] {
-	iterator := make(chan Entry[K, V])
+func (h *HashTable[K, V]) SizeBytes(cost func(Entry[K, V]) uint64) uint64 {
+	var node *Node[K, V]
 
-	go func() {
-		for _, node := range h.buckets {
-			if node == nil {
-				continue
-			}
+	size := uint64(unsafe.Sizeof(*h))
+	nodeSize := uint64(unsafe.Sizeof(*node))
 
-			iterator <- Entry[K, V]{
-				Key:   node.entry.Key,
-				Value: node.entry.Value,
+	for _, buckets := range h.bucketArrays() {
+		size += uint64(cap(buckets)) * uint64(unsafe.Sizeof(node))
+
+		for _, node := range buckets {
+			for ; node != nil; node = node.next {
+				size += nodeSize
+
+				if cost != nil {
+					size += cost(node.entry)
+				}
 			}
+		}
+	}
 
-			for node.next != nil {
-				node = node.next
+	return size
+}
 
-				iterator <- Entry[K, V]{
-					Key:   node.entry.Key,
-					Value: node.entry.Value,
+func (h *HashTable[K, V]) Iter() <-chan Entry[K, V] {
+	return entriesOf(h.All())
+}
+
+// All yields every entry without spawning a goroutine. The table must not be
+// modified while ranging over it.
+func (h *HashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, buckets := range h.bucketArrays() {
+			for _, node := range buckets {
+				for ; node != nil; node = node.next {
+					if !yield(node.entry.Key, node.entry.Value) {
+						return
+					}
 				}
 			}
 		}
+	}
+}
+
+func (h *HashTable[K, V]) Keys() iter.Seq[K] {
+	return keysOf(h.All())
+}
+
+func (h *HashTable[K, V]) Values() iter.Seq[V] {
+	return valuesOf(h.All())
+}
+
+func (h *HashTable[K, V]) IterBatched(n int) <-chan []Entry[K, V] {
+	if n <= 0 {
+		msg := fmt.Sprintf("invalid batch size: %d", n)
+		panic(errors.New(msg))
+	}
+
+	iterator := make(chan []Entry[K, V])
+
+	go func() {
+		batch := make([]Entry[K, V], 0, n)
+
+		for key, value := range h.All() {
+			batch = append(batch, Entry[K, V]{Key: key, Value: value})
+
+			if len(batch) == n {
+				iterator <- batch
+				batch = make([]Entry[K, V], 0, n)
+			}
+		}
+
+		if len(batch) > 0 {
+			iterator <- batch
+		}
 
 		close(iterator)
 	}()
@@ -239,7 +666,32 @@ func (h *HashTable[K, V]) Filter(f func(Entry[K, V]) bool) iterator.Collection[E
 }
 
 func (h *HashTable[K, V]) ForEach(f func(Entry[K, V])) {
-	for entry := range h.Iter() {
+	h.Range(func(entry Entry[K, V]) bool {
 		f(entry)
+		return true
+	})
+}
+
+// Range calls f on every entry until it returns false. It allocates nothing,
+// unlike Iter which synchronizes with a goroutine for every entry.
+func (h *HashTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	h.rangeEntries(f)
+}
+
+// rangeEntries is Range reporting whether f asked to continue, for callers
+// that hold their own lock around it
+func (h *HashTable[K, V]) rangeEntries(f func(Entry[K, V]) bool) bool {
+	return rangeChains(h.buckets, f) && (!h.migrating() || rangeChains(h.oldBuckets, f))
+}
+
+func rangeChains[K, V any](buckets []*Node[K, V], f func(Entry[K, V]) bool) bool {
+	for _, node := range buckets {
+		for ; node != nil; node = node.next {
+			if !f(node.entry) {
+				return false
+			}
+		}
 	}
+
+	return true
 }
@@ -46,6 +46,42 @@ func TestInsertDuplicatedElement(t *testing.T) {
 	}
 }
 
+// constantHasher sends every key to the same hash to force collisions
+type constantHasher[K any] struct{}
+
+func (constantHasher[K]) Hash(K) uint64 {
+	return 42
+}
+
+func TestCollidingKeysAreKeptApart(t *testing.T) {
+	hashTable := NewHashTableWithOptions[string, string](WithHasher[string](constantHasher[string]{}))
+
+	hashTable.Insert("foo", "bar")
+	hashTable.Insert("baz", "qux")
+
+	if hashTable.Size() != 2 {
+		t.Errorf("Expected size to be 2, got %d", hashTable.Size())
+	}
+
+	if value := hashTable.Get("foo"); value != "bar" {
+		t.Errorf("Expected value to be 'bar', got %s", value)
+	}
+
+	if value := hashTable.Get("baz"); value != "qux" {
+		t.Errorf("Expected value to be 'qux', got %s", value)
+	}
+
+	if _, found := hashTable.TryGet("missing"); found {
+		t.Errorf("Expected 'missing' to not be found")
+	}
+
+	hashTable.DeleteAll([]string{"foo"})
+
+	if value := hashTable.Get("baz"); value != "qux" {
+		t.Errorf("Expected value to be 'qux', got %s", value)
+	}
+}
+
 func TestGetElement(t *testing.T) {
 	hashTable := NewHashTable[string, string]()
 
@@ -58,15 +94,114 @@ func TestGetElement(t *testing.T) {
 	}
 }
 
+func TestTryGetElement(t *testing.T) {
+	hashTable := NewHashTable[string, string]()
+
+	hashTable.Insert("foo", "bar")
+
+	if value, found := hashTable.TryGet("foo"); !found || value != "bar" {
+		t.Errorf("Expected value to be 'bar', got %s", value)
+	}
+
+	if _, found := hashTable.TryGet("baz"); found {
+		t.Errorf("Expected 'baz' to not be found")
+	}
+
+	if !hashTable.Contains("foo") || hashTable.Contains("baz") {
+		t.Errorf("Expected Contains to report only 'foo'")
+	}
+}
+
 func TestDeleteKey(t *testing.T) {
 	hashTable := NewHashTable[string, string]()
 
 	hashTable.Insert("foo", "bar")
-	hashTable.Delete("foo")
+
+	if value, found := hashTable.Delete("foo"); !found || value != "bar" {
+		t.Errorf("Expected to delete 'bar', got %s (found %v)", value, found)
+	}
 
 	if hashTable.actualBucketSize != 0 {
 		t.Errorf("Expected size to be 0, got %d", hashTable.actualBucketSize)
 	}
+
+	if _, found := hashTable.Delete("foo"); found {
+		t.Errorf("Expected 'foo' to already be deleted")
+	}
+}
+
+func TestDeleteKeepsChainedEntries(t *testing.T) {
+	hashTable := NewHashTableWithOptions[string, string](WithHasher[string](constantHasher[string]{}))
+
+	hashTable.Insert("foo", "1")
+	hashTable.Insert("bar", "2")
+	hashTable.Insert("baz", "3")
+
+	if value, found := hashTable.Delete("bar"); !found || value != "2" {
+		t.Errorf("Expected to delete '2', got %s (found %v)", value, found)
+	}
+
+	if hashTable.Size() != 2 {
+		t.Errorf("Expected size to be 2, got %d", hashTable.Size())
+	}
+
+	if hashTable.Get("foo") != "1" || hashTable.Get("baz") != "3" {
+		t.Errorf("Expected the other chained entries to survive")
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	removed := hashTable.DeleteAll([]int{1, 2, 3, 50, 1000})
+
+	if removed != 4 {
+		t.Errorf("Expected removed to be 4, got %d", removed)
+	}
+
+	if hashTable.Size() != 96 {
+		t.Errorf("Expected size to be 96, got %d", hashTable.Size())
+	}
+
+	for entry := range hashTable.Iter() {
+		if entry.Key == 1 || entry.Key == 2 || entry.Key == 3 || entry.Key == 50 {
+			t.Errorf("Expected key %d to be deleted", entry.Key)
+		}
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	removed := hashTable.RemoveIf(func(entry Entry[int, int]) bool {
+		return entry.Value%2 == 0
+	})
+
+	if removed != 50 {
+		t.Errorf("Expected removed to be 50, got %d", removed)
+	}
+
+	counter := 0
+
+	for entry := range hashTable.Iter() {
+		if entry.Value%2 == 0 {
+			t.Errorf("Expected even value %d to be removed", entry.Value)
+		}
+
+		counter++
+	}
+
+	if counter != 50 || hashTable.Size() != 50 {
+		t.Errorf("Expected 50 entries, got %d (size %d)", counter, hashTable.Size())
+	}
 }
 
 func TestGetIterKeyValueFromHashTable(t *testing.T) {
@@ -95,6 +230,170 @@ func TestGetIterKeyValueFromHashTable(t *testing.T) {
 	}
 }
 
+func TestKeysAndValues(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+
+	hashTable.Insert("foo", 1)
+	hashTable.Insert("bar", 2)
+
+	keys := make(map[string]bool)
+	for key := range hashTable.Keys() {
+		keys[key] = true
+	}
+
+	if len(keys) != 2 || !keys["foo"] || !keys["bar"] {
+		t.Errorf("Expected keys to be foo and bar, got %v", keys)
+	}
+
+	sum := 0
+	for value := range hashTable.Values() {
+		sum += value
+	}
+
+	if sum != 3 {
+		t.Errorf("Expected sum of values to be 3, got %d", sum)
+	}
+}
+
+func TestAll(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(i, i*2)
+	}
+
+	visited := 0
+
+	for key, value := range hashTable.All() {
+		if value != key*2 {
+			t.Errorf("Expected value to be %d, got %d", key*2, value)
+		}
+
+		visited++
+	}
+
+	if visited != 100 {
+		t.Errorf("Expected to visit 100 entries, got %d", visited)
+	}
+
+	visited = 0
+
+	for range hashTable.All() {
+		visited++
+
+		if visited == 10 {
+			break
+		}
+	}
+
+	if visited != 10 {
+		t.Errorf("Expected to stop after 10 entries, got %d", visited)
+	}
+}
+
+func TestIterBatched(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 10; i++ {
+		hashTable.Insert(i, i*2)
+	}
+
+	batches := 0
+	counter := 0
+
+	for batch := range hashTable.IterBatched(3) {
+		if len(batch) > 3 {
+			t.Errorf("Expected batch length to be at most 3, got %d", len(batch))
+		}
+
+		for _, entry := range batch {
+			if entry.Value != entry.Key*2 {
+				t.Errorf("Expected value to be %d, got %d", entry.Key*2, entry.Value)
+			}
+
+			counter++
+		}
+
+		batches++
+	}
+
+	if counter != 10 {
+		t.Errorf("Expected counter to be 10, got %d", counter)
+	}
+
+	if batches != 4 {
+		t.Errorf("Expected batches to be 4, got %d", batches)
+	}
+}
+
+func TestShouldPanicWhenBatchSizeIsInvalid(t *testing.T) {
+	hashTable := NewHashTable[string, string]()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	hashTable.IterBatched(0)
+}
+
+func TestClear(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	bucketLength := hashTable.actualBucketLength
+	hashTable.Clear()
+
+	if hashTable.Size() != 0 || hashTable.actualBucketSize != 0 {
+		t.Errorf("Expected table to be empty, got size %d", hashTable.Size())
+	}
+
+	if hashTable.actualBucketLength != bucketLength {
+		t.Errorf("Expected bucket length to be kept at %d, got %d", bucketLength, hashTable.actualBucketLength)
+	}
+
+	if hashTable.Contains(1) {
+		t.Errorf("Expected key 1 to be cleared")
+	}
+
+	hashTable.Insert(1, 1)
+
+	if hashTable.Get(1) != 1 {
+		t.Errorf("Expected table to be usable after Clear")
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	hashTable := NewHashTable[string, string]()
+
+	hashTable.Insert("foo", "bar")
+	hashTable.Insert("baz", "qux")
+
+	clone := hashTable.Clone()
+
+	clone.Insert("foo", "changed")
+	clone.Insert("new", "value")
+	hashTable.RemoveIf(func(entry Entry[string, string]) bool {
+		return entry.Key == "baz"
+	})
+
+	if hashTable.Get("foo") != "bar" {
+		t.Errorf("Expected original value to be 'bar', got %s", hashTable.Get("foo"))
+	}
+
+	if hashTable.Contains("new") {
+		t.Errorf("Expected original to not see keys inserted in the clone")
+	}
+
+	if clone.Get("baz") != "qux" || clone.Size() != 3 {
+		t.Errorf("Expected clone to keep 'baz' and hold 3 entries, got size %d", clone.Size())
+	}
+}
+
 func TestSize(t *testing.T) {
 	hashTable := NewHashTable[string, string]()
 
@@ -106,6 +405,30 @@ func TestSize(t *testing.T) {
 	}
 }
 
+func TestSizeBytesGrowsWithEntries(t *testing.T) {
+	hashTable := NewHashTable[string, string]()
+
+	var _ iterator.Sizer[Entry[string, string]] = hashTable
+
+	cost := func(entry Entry[string, string]) uint64 {
+		return uint64(len(entry.Key) + len(entry.Value))
+	}
+
+	empty := hashTable.SizeBytes(cost)
+
+	hashTable.Insert("foo", "barbaz")
+
+	withEntry := hashTable.SizeBytes(cost)
+
+	if withEntry <= empty+9 {
+		t.Errorf("Expected size to grow by more than 9 bytes, got %d -> %d", empty, withEntry)
+	}
+
+	if hashTable.SizeBytes(nil) >= withEntry {
+		t.Errorf("Expected size without cost callback to be smaller than %d", withEntry)
+	}
+}
+
 func TestIncreaseBucketLengthWhenMoreThan50ElementsAreInserted(t *testing.T) {
 	hashTable := NewHashTable[string, string]()
 
@@ -283,3 +606,246 @@ func TestWithTraditionalMapOfGoLang(t *testing.T) {
 		t.Errorf("Expected time to be less than 5 second, got %s", elapsed)
 	}
 }
+
+func TestGetOrInsert(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+	calls := 0
+
+	defaultFn := func() int {
+		calls++
+		return 7
+	}
+
+	if value := hashTable.GetOrInsert("foo", defaultFn); value != 7 {
+		t.Errorf("Expected value to be 7, got %d", value)
+	}
+
+	if value := hashTable.GetOrInsert("foo", defaultFn); value != 7 {
+		t.Errorf("Expected value to be 7, got %d", value)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected defaultFn to be called once, got %d", calls)
+	}
+
+	if hashTable.Size() != 1 {
+		t.Errorf("Expected size to be 1, got %d", hashTable.Size())
+	}
+}
+
+func countingHasher(hashes *int) Option {
+	return WithHasher[string](HasherFunc[string](func(key string) uint64 {
+		*hashes++
+		return StringHash(key)
+	}))
+}
+
+func TestGetOrInsertHashesOnce(t *testing.T) {
+	hashes := 0
+	hashTable := NewHashTableWithOptions[string, int](countingHasher(&hashes))
+
+	hashTable.GetOrInsert("foo", func() int { return 7 })
+
+	if hashes != 1 {
+		t.Errorf("Expected the key to be hashed once, got %d", hashes)
+	}
+}
+
+func TestComputeIfAbsent(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+
+	for _, key := range []string{"a", "bb", "a", "ccc"} {
+		hashTable.ComputeIfAbsent(key, func(key string) int {
+			return len(key)
+		})
+	}
+
+	if hashTable.Size() != 3 {
+		t.Errorf("Expected size to be 3, got %d", hashTable.Size())
+	}
+
+	if value := hashTable.Get("ccc"); value != 3 {
+		t.Errorf("Expected value to be 3, got %d", value)
+	}
+}
+
+func TestComputeIfAbsentRecursive(t *testing.T) {
+	fib := NewHashTable[int, int]()
+
+	var compute func(n int) int
+
+	compute = func(n int) int {
+		return fib.ComputeIfAbsent(n, func(n int) int {
+			if n < 2 {
+				return n
+			}
+
+			return compute(n-1) + compute(n-2)
+		})
+	}
+
+	if value := compute(40); value != 102334155 {
+		t.Errorf("Expected fib(40) to be 102334155, got %d", value)
+	}
+
+	if fib.Size() != 41 {
+		t.Errorf("Expected size to be 41, got %d", fib.Size())
+	}
+
+	for n := 0; n <= 40; n++ {
+		if !fib.Contains(n) {
+			t.Errorf("Expected %d to be reachable", n)
+		}
+	}
+}
+
+func TestComputeIfPresentWritingCallback(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+	hashTable.Insert(0, 0)
+
+	value, found := hashTable.ComputeIfPresent(0, func(_ int, value int) int {
+		for i := 1; i < 100; i++ {
+			hashTable.Insert(i, i)
+		}
+
+		hashTable.Delete(0)
+
+		return value + 1
+	})
+
+	if !found || value != 1 || hashTable.Get(0) != 1 || hashTable.Size() != 100 {
+		t.Errorf("Expected 0 to be stored again as 1 among 100 keys, got %d of %d keys", hashTable.Get(0), hashTable.Size())
+	}
+}
+
+func TestComputeIfPresentMarksAccess(t *testing.T) {
+	hashTable := NewHashTableWithOptions[string, int](WithMaxEntries(2))
+	hashTable.Insert("a", 1)
+	hashTable.Insert("b", 2)
+	hashTable.Update("a", func(value int) int { return value + 1 })
+	hashTable.Insert("c", 3)
+
+	if !hashTable.Contains("a") || hashTable.Contains("b") {
+		t.Errorf("Expected the updated key to be kept over the least recently used one")
+	}
+}
+
+func TestComputeIfPresent(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+	hashTable.Insert("foo", 1)
+
+	increment := func(_ string, value int) int {
+		return value + 1
+	}
+
+	if value, found := hashTable.ComputeIfPresent("foo", increment); !found || value != 2 {
+		t.Errorf("Expected value to be 2, got %d (found %v)", value, found)
+	}
+
+	if _, found := hashTable.ComputeIfPresent("bar", increment); found {
+		t.Errorf("Expected bar not to be found")
+	}
+
+	if hashTable.Contains("bar") {
+		t.Errorf("Expected bar not to be inserted")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+
+	for _, word := range []string{"foo", "bar", "foo", "foo"} {
+		if !hashTable.Update(word, func(count int) int { return count + 1 }) {
+			hashTable.Insert(word, 1)
+		}
+	}
+
+	if count := hashTable.Get("foo"); count != 3 {
+		t.Errorf("Expected count to be 3, got %d", count)
+	}
+
+	if count := hashTable.Get("bar"); count != 1 {
+		t.Errorf("Expected count to be 1, got %d", count)
+	}
+
+	if hashTable.Update("baz", func(count int) int { return count + 1 }) {
+		t.Errorf("Expected Update to report a missing key")
+	}
+}
+
+func TestUpdateHashesOnce(t *testing.T) {
+	hashes := 0
+	hashTable := NewHashTableWithOptions[string, int](countingHasher(&hashes))
+
+	hashTable.Insert("foo", 1)
+	hashes = 0
+
+	hashTable.Update("foo", func(count int) int { return count + 1 })
+
+	if hashes != 1 || hashTable.Get("foo") != 2 {
+		t.Errorf("Expected one hash and a count of 2, got %d and %d", hashes, hashTable.Get("foo"))
+	}
+}
+
+func TestNewHashTableWithDefaultRecursive(t *testing.T) {
+	var fib *HashTable[int, int]
+
+	fib = NewHashTableWithDefault(func(n int) int {
+		if n < 2 {
+			return n
+		}
+
+		return fib.Get(n-1) + fib.Get(n-2)
+	})
+
+	if value := fib.Get(40); value != 102334155 {
+		t.Errorf("Expected fib(40) to be 102334155, got %d", value)
+	}
+
+	if fib.Size() != 41 {
+		t.Errorf("Expected size to be 41, got %d", fib.Size())
+	}
+
+	for n := 0; n <= 40; n++ {
+		if !fib.Contains(n) {
+			t.Errorf("Expected %d to be reachable", n)
+		}
+	}
+}
+
+func TestNewHashTableWithDefault(t *testing.T) {
+	adjacency := NewHashTableWithDefault(func(int) []int { return []int{} })
+
+	for _, edge := range [][2]int{{1, 2}, {1, 3}, {2, 3}} {
+		adjacency.Insert(edge[0], append(adjacency.Get(edge[0]), edge[1]))
+	}
+
+	if neighbours := adjacency.Get(1); fmt.Sprint(neighbours) != "[2 3]" {
+		t.Errorf("Expected [2 3], got %v", neighbours)
+	}
+
+	if _, found := adjacency.TryGet(4); found {
+		t.Errorf("Expected TryGet not to insert a default")
+	}
+
+	if neighbours := adjacency.Get(4); len(neighbours) != 0 || !adjacency.Contains(4) {
+		t.Errorf("Expected Get to insert an empty default for 4")
+	}
+
+	if adjacency.Size() != 3 {
+		t.Errorf("Expected size to be 3, got %d", adjacency.Size())
+	}
+
+	calls := 0
+	lengths := NewHashTableWithDefault(func(key string) int {
+		calls++
+		return len(key)
+	})
+
+	lengths.Get("foo")
+	lengths.Get("foo")
+
+	if calls != 1 || lengths.Clone().Get("quux") != 4 {
+		t.Errorf("Expected the provider to run once per missing key and survive Clone")
+	}
+}
@@ -0,0 +1,307 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// hopscotchRange is the neighbourhood size: every key sits within this many
+// slots of its home bucket
+const hopscotchRange = 32
+
+// hopscotchLoad is the load factor, as a fraction of 100, that triggers growth
+const hopscotchLoad = 90
+
+type hopscotchSlot[K comparable, V any] struct {
+	entry Entry[K, V]
+	hash  uint64
+	used  bool
+}
+
+// HopscotchTable is an open-addressing table that keeps every key in the
+// hopscotchRange slots following its home bucket. Each bucket records in a
+// bitmap which of those slots hold its keys, so lookups touch one cache-friendly
+// neighbourhood even at load factors above 90%. Inserts find a free slot by
+// linear probing and then hop it back towards home by displacing entries
+// that can move without leaving their own neighbourhood.
+type HopscotchTable[K comparable, V any] struct {
+	slots  []hopscotchSlot[K, V]
+	hops   []uint32
+	mask   uint64
+	size   uint32
+	growAt uint32
+	hasher Hasher[K]
+}
+
+func NewHopscotchTable[K comparable, V any](opts ...Option) *HopscotchTable[K, V] {
+	o := applyOptions(opts)
+	rejectBound(o, "HopscotchTable")
+
+	table := HopscotchTable[K, V]{
+		hasher: resolveHasher[K](o),
+	}
+
+	length := uint64(hopscotchRange)
+
+	for uint64(o.capacity)*100 > length*hopscotchLoad {
+		length <<= 1
+	}
+
+	table.allocate(length)
+
+	return &table
+}
+
+func (h *HopscotchTable[K, V]) allocate(length uint64) {
+	h.slots = make([]hopscotchSlot[K, V], length)
+	h.hops = make([]uint32, length)
+	h.mask = length - 1
+	h.growAt = uint32(length * hopscotchLoad / 100)
+	h.size = 0
+}
+
+// grow doubles the table until every entry fits, pending included when it is
+// used. Distinct full hashes always end up in different home buckets once
+// the table is long enough, so it only gives up, leaving the table as it
+// was, when more keys than a neighbourhood holds share their full hash.
+func (h *HopscotchTable[K, V]) grow(pending hopscotchSlot[K, V]) {
+	slots, hops, mask, size, growAt := h.slots, h.hops, h.mask, h.size, h.growAt
+
+	for attempt := 1; ; attempt++ {
+		h.allocate(uint64(len(slots)) << attempt)
+
+		failed := pending
+		placed := !pending.used || h.place(pending.entry, pending.hash)
+
+		for i := range slots {
+			if !placed {
+				break
+			}
+
+			if slots[i].used {
+				failed = slots[i]
+				placed = h.place(slots[i].entry, slots[i].hash)
+			}
+		}
+
+		if placed {
+			return
+		}
+
+		if shared := sharingHash(slots, pending, failed.hash); shared > hopscotchRange {
+			h.slots, h.hops, h.mask, h.size, h.growAt = slots, hops, mask, size, growAt
+
+			msg := fmt.Sprintf("hopscotch: %d keys share the hash %#x, at most %d fit", shared, failed.hash, hopscotchRange)
+			panic(errors.New(msg))
+		}
+	}
+}
+
+func sharingHash[K comparable, V any](slots []hopscotchSlot[K, V], pending hopscotchSlot[K, V], hash uint64) int {
+	shared := 0
+
+	if pending.used && pending.hash == hash {
+		shared++
+	}
+
+	for i := range slots {
+		if slots[i].used && slots[i].hash == hash {
+			shared++
+		}
+	}
+
+	return shared
+}
+
+// home mixes the hash before masking it, so that hashes differing only in
+// their high bits still spread over the buckets
+func (h *HopscotchTable[K, V]) home(hash uint64) uint64 {
+	return IntHash(hash) & h.mask
+}
+
+func (h *HopscotchTable[K, V]) find(key K, hash uint64) (index uint64, found bool) {
+	home := h.home(hash)
+
+	for hops := h.hops[home]; hops != 0; hops &= hops - 1 {
+		index = (home + uint64(bits.TrailingZeros32(hops))) & h.mask
+
+		if h.slots[index].hash == hash && h.slots[index].entry.Key == key {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+// place stores an entry known not to be in the table yet, reporting false
+// when no free slot can be brought into its neighbourhood
+func (h *HopscotchTable[K, V]) place(entry Entry[K, V], hash uint64) bool {
+	home := h.home(hash)
+	length := uint64(len(h.slots))
+
+	distance := uint64(0)
+
+	for distance < length && h.slots[(home+distance)&h.mask].used {
+		distance++
+	}
+
+	if distance == length {
+		return false
+	}
+
+	for distance >= hopscotchRange {
+		free := (home + distance) & h.mask
+		moved := false
+
+		// Look for an entry before the free slot that may move into it
+		for back := uint64(hopscotchRange - 1); back > 0 && !moved; back-- {
+			bucket := (free - back) & h.mask
+
+			for hops := h.hops[bucket]; hops != 0; hops &= hops - 1 {
+				offset := uint64(bits.TrailingZeros32(hops))
+
+				if offset >= back {
+					break
+				}
+
+				from := (bucket + offset) & h.mask
+
+				h.slots[free] = h.slots[from]
+				h.slots[from] = hopscotchSlot[K, V]{}
+				h.hops[bucket] |= 1 << back
+				h.hops[bucket] &^= 1 << offset
+
+				distance -= back - offset
+				moved = true
+
+				break
+			}
+		}
+
+		if !moved {
+			return false
+		}
+	}
+
+	index := (home + distance) & h.mask
+
+	h.slots[index] = hopscotchSlot[K, V]{entry: entry, hash: hash, used: true}
+	h.hops[home] |= 1 << distance
+	h.size++
+
+	return true
+}
+
+func (h *HopscotchTable[K, V]) Insert(key K, value V) {
+	hash := h.hasher.Hash(key)
+
+	if index, found := h.find(key, hash); found {
+		h.slots[index].entry.Value = value
+		return
+	}
+
+	if h.size >= h.growAt {
+		h.grow(hopscotchSlot[K, V]{})
+	}
+
+	if !h.place(Entry[K, V]{Key: key, Value: value}, hash) {
+		h.grow(hopscotchSlot[K, V]{entry: Entry[K, V]{Key: key, Value: value}, hash: hash, used: true})
+	}
+}
+
+func (h *HopscotchTable[K, V]) Get(key K) (value V) {
+	value, found := h.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return
+}
+
+func (h *HopscotchTable[K, V]) TryGet(key K) (value V, found bool) {
+	index, found := h.find(key, h.hasher.Hash(key))
+
+	if !found {
+		return
+	}
+
+	return h.slots[index].entry.Value, true
+}
+
+func (h *HopscotchTable[K, V]) Contains(key K) bool {
+	_, found := h.find(key, h.hasher.Hash(key))
+
+	return found
+}
+
+func (h *HopscotchTable[K, V]) Delete(key K) (value V, found bool) {
+	hash := h.hasher.Hash(key)
+	index, found := h.find(key, hash)
+
+	if !found {
+		return
+	}
+
+	home := h.home(hash)
+	value = h.slots[index].entry.Value
+
+	h.slots[index] = hopscotchSlot[K, V]{}
+	h.hops[home] &^= 1 << ((index - home) & h.mask)
+	h.size--
+
+	return value, true
+}
+
+func (h *HopscotchTable[K, V]) Size() uint32 {
+	return h.size
+}
+
+func (h *HopscotchTable[K, V]) Clear() {
+	for i := range h.slots {
+		h.slots[i] = hopscotchSlot[K, V]{}
+		h.hops[i] = 0
+	}
+
+	h.size = 0
+}
+
+func (h *HopscotchTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range h.slots {
+			if h.slots[i].used && !yield(h.slots[i].entry.Key, h.slots[i].entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+func (h *HopscotchTable[K, V]) Keys() iter.Seq[K] {
+	return keysOf(h.All())
+}
+
+func (h *HopscotchTable[K, V]) Values() iter.Seq[V] {
+	return valuesOf(h.All())
+}
+
+func (h *HopscotchTable[K, V]) Iter() <-chan Entry[K, V] {
+	return entriesOf(h.All())
+}
+
+func (h *HopscotchTable[K, V]) ForEach(f func(Entry[K, V])) {
+	h.Range(func(entry Entry[K, V]) bool {
+		f(entry)
+		return true
+	})
+}
+
+func (h *HopscotchTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	for i := range h.slots {
+		if h.slots[i].used && !f(h.slots[i].entry) {
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package hashtable
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+func hasStringKeys[K comparable]() bool {
+	return reflect.TypeOf((*K)(nil)).Elem().Kind() == reflect.String
+}
+
+// MarshalJSON encodes tables with string keys as a JSON object and any other
+// table as an array of entries.
+func (h *HashTable[K, V]) MarshalJSON() ([]byte, error) {
+	if hasStringKeys[K]() {
+		object := make(map[K]V, h.Size())
+
+		for entry := range h.Iter() {
+			object[entry.Key] = entry.Value
+		}
+
+		return json.Marshal(object)
+	}
+
+	entries := make([]Entry[K, V], 0, h.Size())
+
+	for entry := range h.Iter() {
+		entries = append(entries, entry)
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON inserts the decoded entries, keeping the ones already present
+// like decoding into a map does.
+func (h *HashTable[K, V]) UnmarshalJSON(data []byte) error {
+	if h.buckets == nil {
+		*h = *NewHashTable[K, V]()
+	}
+
+	if hasStringKeys[K]() {
+		object := make(map[K]V)
+
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+
+		for key, value := range object {
+			h.Insert(key, value)
+		}
+
+		return nil
+	}
+
+	entries := make([]Entry[K, V], 0)
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		h.Insert(entry.Key, entry.Value)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package hashtable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSONWithStringKeys(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+	hashTable.Insert("foo", 1)
+	hashTable.Insert("bar", 2)
+
+	data, err := json.Marshal(hashTable)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if string(data) != `{"bar":2,"foo":1}` {
+		t.Errorf("Expected a JSON object, got %s", data)
+	}
+}
+
+func TestMarshalJSONWithOtherKeys(t *testing.T) {
+	hashTable := NewHashTable[int, string]()
+	hashTable.Insert(1, "foo")
+
+	data, err := json.Marshal(hashTable)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if string(data) != `[{"key":1,"value":"foo"}]` {
+		t.Errorf("Expected an array of entries, got %s", data)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var config struct {
+		Limits *HashTable[string, int]   `json:"limits"`
+		Names  *HashTable[int, string]   `json:"names"`
+		Points HashTable[float64, []int] `json:"points"`
+	}
+
+	data := `{
+		"limits": {"cpu": 2, "memory": 512},
+		"names": [{"key": 1, "value": "one"}, {"key": 2, "value": "two"}],
+		"points": [{"key": 0.5, "value": [1, 2]}]
+	}`
+
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if config.Limits.Get("memory") != 512 || config.Limits.Size() != 2 {
+		t.Errorf("Expected limits to be decoded, got size %d", config.Limits.Size())
+	}
+
+	if config.Names.Get(2) != "two" || config.Names.Size() != 2 {
+		t.Errorf("Expected names to be decoded, got size %d", config.Names.Size())
+	}
+
+	if points := config.Points.Get(0.5); len(points) != 2 {
+		t.Errorf("Expected points to be decoded, got %v", points)
+	}
+}
+
+func TestUnmarshalJSONKeepsExistingEntries(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+	hashTable.Insert("foo", 1)
+
+	if err := json.Unmarshal([]byte(`{"bar": 2}`), hashTable); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if hashTable.Size() != 2 {
+		t.Errorf("Expected size to be 2, got %d", hashTable.Size())
+	}
+
+	if err := json.Unmarshal([]byte(`[1, 2]`), hashTable); err == nil {
+		t.Errorf("Expected an error for a malformed object")
+	}
+}
@@ -0,0 +1,29 @@
+package hashtable
+
+import "iter"
+
+// Map is the API shared by the growable tables, so they can be swapped for
+// one another and benchmarked against each other
+type Map[K comparable, V any] interface {
+	Insert(key K, value V)
+	Get(key K) V
+	TryGet(key K) (V, bool)
+	Contains(key K) bool
+	Delete(key K) (V, bool)
+	Size() uint32
+	Clear()
+	All() iter.Seq2[K, V]
+	Keys() iter.Seq[K]
+	Values() iter.Seq[V]
+	Iter() <-chan Entry[K, V]
+	ForEach(f func(Entry[K, V]))
+	Range(f func(Entry[K, V]) bool)
+}
+
+var (
+	_ Map[int, int] = (*HashTable[int, int])(nil)
+	_ Map[int, int] = (*RobinHoodTable[int, int])(nil)
+	_ Map[int, int] = (*CuckooTable[int, int])(nil)
+	_ Map[int, int] = (*HopscotchTable[int, int])(nil)
+	_ Map[int, int] = (*SwissTable[int, int])(nil)
+)
@@ -0,0 +1,256 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+)
+
+var mapImplementations = []struct {
+	name string
+	new  func(opts ...Option) Map[int, int]
+}{
+	{"Chained", func(opts ...Option) Map[int, int] { return NewHashTableWithOptions[int, int](opts...) }},
+	{"RobinHood", func(opts ...Option) Map[int, int] { return NewRobinHoodTable[int, int](opts...) }},
+	{"Cuckoo", func(opts ...Option) Map[int, int] { return NewCuckooTable[int, int](opts...) }},
+	{"Hopscotch", func(opts ...Option) Map[int, int] { return NewHopscotchTable[int, int](opts...) }},
+	{"Swiss", func(opts ...Option) Map[int, int] { return NewSwissTable[int, int](opts...) }},
+}
+
+func TestMapImplementations(t *testing.T) {
+	for _, implementation := range mapImplementations {
+		t.Run(implementation.name, func(t *testing.T) {
+			table := implementation.new()
+
+			for i := 0; i < 5000; i++ {
+				table.Insert(i, i)
+			}
+
+			for i := 0; i < 5000; i += 3 {
+				if value, found := table.Delete(i); !found || value != i {
+					t.Errorf("Expected to delete %d, got %d (found %v)", i, value, found)
+				}
+			}
+
+			for i := 1; i < 5000; i += 3 {
+				table.Insert(i, -i)
+			}
+
+			expected := 5000 - (5000+2)/3
+
+			if table.Size() != uint32(expected) {
+				t.Errorf("Expected size to be %d, got %d", expected, table.Size())
+			}
+
+			for i := 0; i < 5000; i++ {
+				value, found := table.TryGet(i)
+
+				switch {
+				case i%3 == 0 && found:
+					t.Errorf("Expected %d to be deleted", i)
+				case i%3 == 1 && value != -i:
+					t.Errorf("Expected value to be %d, got %d", -i, value)
+				case i%3 == 2 && value != i:
+					t.Errorf("Expected value to be %d, got %d", i, value)
+				}
+			}
+
+			count := 0
+
+			for key, value := range table.All() {
+				if table.Get(key) != value {
+					t.Errorf("Expected All to yield the stored value of %d", key)
+				}
+
+				count++
+			}
+
+			if count != expected {
+				t.Errorf("Expected to iterate %d entries, got %d", expected, count)
+			}
+
+			table.Clear()
+
+			if table.Size() != 0 || table.Contains(1) {
+				t.Errorf("Expected an empty table after Clear")
+			}
+		})
+	}
+}
+
+func TestHopscotchKeepsNeighbourhoods(t *testing.T) {
+	table := NewHopscotchTable[int, int](WithCapacity(10_000))
+	length := len(table.slots)
+
+	for i := 0; i < 10_000; i++ {
+		table.Insert(i, i)
+	}
+
+	if len(table.slots) != length {
+		t.Errorf("Expected WithCapacity to avoid growth, got %d slots instead of %d", len(table.slots), length)
+	}
+
+	if load := float64(table.Size()) / float64(len(table.slots)); load < 0.6 {
+		t.Errorf("Expected a high load factor, got %v", load)
+	}
+
+	for i, slot := range table.slots {
+		if !slot.used {
+			continue
+		}
+
+		home := table.home(slot.hash)
+		offset := (uint64(i) - home) & table.mask
+
+		if offset >= hopscotchRange || table.hops[home]&(1<<offset) == 0 {
+			t.Errorf("Expected slot %d to be recorded in the neighbourhood of %d", i, home)
+		}
+	}
+}
+
+func TestHopscotchCollidingKeys(t *testing.T) {
+	table := NewHopscotchTable[int, int](WithHasher[int](HasherFunc[int](func(int) uint64 {
+		return 7
+	})))
+
+	for i := 0; i < hopscotchRange; i++ {
+		table.Insert(i, i)
+	}
+
+	length := len(table.slots)
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		table.Insert(hopscotchRange, hopscotchRange)
+	}()
+
+	if len(table.slots) != length || table.Size() != hopscotchRange {
+		t.Errorf("Expected the table to be left as it was, got %d slots and size %d", len(table.slots), table.Size())
+	}
+
+	for i := 0; i < hopscotchRange; i++ {
+		if value, found := table.TryGet(i); !found || value != i {
+			t.Errorf("Expected %d to be kept, got %d", i, value)
+		}
+	}
+}
+
+func TestSwissGroupMatch(t *testing.T) {
+	group := swissGroup[int, int]{ctrl: emptyControl()}
+
+	group.ctrl[0] = 0x12
+	group.ctrl[3] = 0x13
+	group.ctrl[9] = 0x12
+	group.ctrl[15] = swissDeleted
+
+	if matches := group.match(0x12); matches != 1|1<<9 {
+		t.Errorf("Expected slots 0 and 9 to match, got %016b", matches)
+	}
+
+	if matches := group.match(0x7f); matches != 0 {
+		t.Errorf("Expected no slot to match, got %016b", matches)
+	}
+
+	if empty := group.matchEmpty(); empty != 0xffff&^(1|1<<3|1<<9|1<<15) {
+		t.Errorf("Expected every other slot to be empty, got %016b", empty)
+	}
+
+	if free := group.matchFree(); free != 0xffff&^(1|1<<3|1<<9) {
+		t.Errorf("Expected empty and deleted slots to be free, got %016b", free)
+	}
+}
+
+func TestSwissTombstones(t *testing.T) {
+	table := NewSwissTable[int, int](WithHasher[int](sameBucketHasher{}))
+
+	// Every key starts probing at the first group, so deleting from it must
+	// not hide the keys that overflowed into the next groups
+	for i := 0; i < 40; i++ {
+		table.Insert(i, i)
+	}
+
+	for i := 0; i < 40; i += 2 {
+		table.Delete(i)
+	}
+
+	if table.tombstones == 0 {
+		t.Errorf("Expected deletes from full groups to leave tombstones")
+	}
+
+	for i := 1; i < 40; i += 2 {
+		if value, found := table.TryGet(i); !found || value != i {
+			t.Errorf("Expected value to be %d, got %d (found %v)", i, value, found)
+		}
+	}
+
+	groups := len(table.groups)
+
+	for round := 0; round < 100; round++ {
+		table.Insert(100+round, round)
+		table.Delete(100 + round)
+	}
+
+	if len(table.groups) != groups {
+		t.Errorf("Expected churn to reuse tombstones, got %d groups instead of %d", len(table.groups), groups)
+	}
+
+	if table.Size() != 20 {
+		t.Errorf("Expected size to be 20, got %d", table.Size())
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	for _, size := range []int{1_000, 100_000} {
+		for _, implementation := range mapImplementations {
+			b.Run(fmt.Sprintf("Insert/%s/%d", implementation.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+
+				for i := 0; i < b.N; i++ {
+					table := implementation.new()
+
+					for j := 0; j < size; j++ {
+						table.Insert(j, j)
+					}
+				}
+			})
+
+			b.Run(fmt.Sprintf("Get/%s/%d", implementation.name, size), func(b *testing.B) {
+				table := implementation.new(WithCapacity(uint32(size)))
+
+				for j := 0; j < size; j++ {
+					table.Insert(j, j)
+				}
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					table.TryGet(i % size)
+				}
+			})
+		}
+	}
+}
+
+func TestHopscotchHashesDifferingInHighBits(t *testing.T) {
+	table := NewHopscotchTable[int, int](WithHasher[int](HasherFunc[int](func(k int) uint64 {
+		return uint64(k) << 40
+	})))
+
+	for i := 0; i < 1000; i++ {
+		table.Insert(i, i)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if value, found := table.TryGet(i); !found || value != i {
+			t.Fatalf("Expected %d to be found, got %d", i, value)
+		}
+	}
+
+	if table.Size() != 1000 {
+		t.Errorf("Expected size to be 1000, got %d", table.Size())
+	}
+}
@@ -0,0 +1,116 @@
+package hashtable
+
+import (
+	"iter"
+	"slices"
+)
+
+// MultiMap associates every key with the list of values inserted for it, in
+// insertion order
+type MultiMap[K comparable, V comparable] struct {
+	table *HashTable[K, []V]
+	size  uint32
+}
+
+func NewMultiMap[K comparable, V comparable](opts ...Option) *MultiMap[K, V] {
+	rejectBound(applyOptions(opts), "MultiMap")
+
+	return &MultiMap[K, V]{
+		table: NewHashTableWithOptions[K, []V](opts...),
+	}
+}
+
+func (m *MultiMap[K, V]) Insert(key K, value V) {
+	values, _ := m.table.TryGet(key)
+
+	m.table.Insert(key, append(values, value))
+	m.size++
+}
+
+// GetAll returns a copy of the values of key, or nil when it has none
+func (m *MultiMap[K, V]) GetAll(key K) []V {
+	values, _ := m.table.TryGet(key)
+
+	return slices.Clone(values)
+}
+
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	return m.table.Contains(key)
+}
+
+func (m *MultiMap[K, V]) ContainsValue(key K, value V) bool {
+	values, _ := m.table.TryGet(key)
+
+	return slices.Contains(values, value)
+}
+
+// DeleteValue removes every occurrence of value from the values of key and
+// returns how many were removed
+func (m *MultiMap[K, V]) DeleteValue(key K, value V) int {
+	values, found := m.table.TryGet(key)
+
+	if !found {
+		return 0
+	}
+
+	remaining := slices.DeleteFunc(values, func(v V) bool {
+		return v == value
+	})
+
+	removed := len(values) - len(remaining)
+	m.size -= uint32(removed)
+
+	if len(remaining) == 0 {
+		m.table.Delete(key)
+	} else if removed > 0 {
+		m.table.Insert(key, remaining)
+	}
+
+	return removed
+}
+
+// Delete removes key with all its values
+func (m *MultiMap[K, V]) Delete(key K) ([]V, bool) {
+	values, found := m.table.Delete(key)
+	m.size -= uint32(len(values))
+
+	return values, found
+}
+
+// Size is the number of (key, value) pairs
+func (m *MultiMap[K, V]) Size() uint32 {
+	return m.size
+}
+
+func (m *MultiMap[K, V]) KeyCount() uint32 {
+	return m.table.Size()
+}
+
+func (m *MultiMap[K, V]) Clear() {
+	m.table.Clear()
+	m.size = 0
+}
+
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(func(entry Entry[K, V]) bool {
+			return yield(entry.Key, entry.Value)
+		})
+	}
+}
+
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return m.table.Keys()
+}
+
+func (m *MultiMap[K, V]) Range(f func(Entry[K, V]) bool) {
+	m.table.Range(func(entry Entry[K, []V]) bool {
+		for _, value := range entry.Value {
+			if !f(Entry[K, V]{Key: entry.Key, Value: value}) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
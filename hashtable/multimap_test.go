@@ -0,0 +1,95 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMultiMapInsertAndGetAll(t *testing.T) {
+	m := NewMultiMap[string, int]()
+
+	m.Insert("foo", 1)
+	m.Insert("foo", 2)
+	m.Insert("foo", 1)
+	m.Insert("bar", 3)
+
+	if values := m.GetAll("foo"); fmt.Sprint(values) != "[1 2 1]" {
+		t.Errorf("Expected [1 2 1], got %v", values)
+	}
+
+	if values := m.GetAll("baz"); values != nil {
+		t.Errorf("Expected nil, got %v", values)
+	}
+
+	if m.Size() != 4 || m.KeyCount() != 2 {
+		t.Errorf("Expected 4 pairs over 2 keys, got %d over %d", m.Size(), m.KeyCount())
+	}
+
+	m.GetAll("foo")[0] = 100
+
+	if !m.ContainsValue("foo", 1) || m.ContainsValue("foo", 100) {
+		t.Errorf("Expected GetAll to return a copy")
+	}
+}
+
+func TestMultiMapDeleteValue(t *testing.T) {
+	m := NewMultiMap[string, int]()
+
+	m.Insert("foo", 1)
+	m.Insert("foo", 2)
+	m.Insert("foo", 1)
+
+	if removed := m.DeleteValue("foo", 1); removed != 2 {
+		t.Errorf("Expected 2 values to be removed, got %d", removed)
+	}
+
+	if removed := m.DeleteValue("foo", 7); removed != 0 {
+		t.Errorf("Expected no value to be removed, got %d", removed)
+	}
+
+	if values := m.GetAll("foo"); fmt.Sprint(values) != "[2]" {
+		t.Errorf("Expected [2], got %v", values)
+	}
+
+	m.DeleteValue("foo", 2)
+
+	if m.Contains("foo") || m.Size() != 0 {
+		t.Errorf("Expected the key to be dropped with its last value")
+	}
+}
+
+func TestMultiMapDeleteAndIterate(t *testing.T) {
+	m := NewMultiMap[int, int]()
+
+	for i := 0; i < 100; i++ {
+		m.Insert(i%10, i)
+	}
+
+	if values, found := m.Delete(3); !found || len(values) != 10 {
+		t.Errorf("Expected 10 values for key 3, got %v", values)
+	}
+
+	if m.Size() != 90 {
+		t.Errorf("Expected size to be 90, got %d", m.Size())
+	}
+
+	count := 0
+
+	for key, value := range m.All() {
+		if value%10 != key {
+			t.Errorf("Expected %d to be stored under %d", value, value%10)
+		}
+
+		count++
+	}
+
+	if count != 90 {
+		t.Errorf("Expected to iterate 90 pairs, got %d", count)
+	}
+
+	m.Clear()
+
+	if m.Size() != 0 || m.KeyCount() != 0 {
+		t.Errorf("Expected an empty multimap after Clear")
+	}
+}
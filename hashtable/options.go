@@ -0,0 +1,153 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/arena"
+)
+
+type options struct {
+	hasher         any
+	accessOrder    bool
+	capacity       uint32
+	loadFactor     float64
+	shrinkFactor   *float64
+	arenaChunk     int
+	maxEntries     uint32
+	eviction       Eviction
+	evictionPolicy any
+	onEvict        any
+}
+
+type Option func(*options)
+
+func applyOptions(opts []Option) options {
+	o := options{loadFactor: 0.5}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+func WithHasher[K any](hasher Hasher[K]) Option {
+	return func(o *options) {
+		o.hasher = hasher
+	}
+}
+
+// WithAccessOrder makes an OrderedHashTable move entries to the back of its
+// order whenever they are read or overwritten. Other tables ignore it.
+func WithAccessOrder() Option {
+	return func(o *options) {
+		o.accessOrder = true
+	}
+}
+
+// WithCapacity sizes the bucket array up front so n keys can be inserted
+// without any intermediate resize.
+func WithCapacity(n uint32) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// WithLoadFactor sets the fraction of occupied buckets that triggers a resize.
+// It must be in (0, 1) since only non-empty buckets are counted.
+func WithLoadFactor(f float64) Option {
+	return func(o *options) {
+		if !(f > 0 && f < 1) {
+			msg := fmt.Sprintf("invalid load factor: %v", f)
+			panic(errors.New(msg))
+		}
+
+		o.loadFactor = f
+	}
+}
+
+// WithShrinkFactor sets the fraction of occupied buckets under which deletes
+// rebuild the table into a smaller bucket array. It defaults to a quarter of
+// the load factor and zero disables shrinking.
+func WithShrinkFactor(f float64) Option {
+	return func(o *options) {
+		o.shrinkFactor = &f
+	}
+}
+
+// WithArena carves nodes out of chunks of chunkSize instead of allocating
+// them one by one. Chunks are only reclaimed once none of their nodes is
+// referenced, which suits tables that are built and dropped as a whole.
+func WithArena(chunkSize int) Option {
+	return func(o *options) {
+		if chunkSize <= 0 {
+			msg := fmt.Sprintf("invalid arena chunk size: %d", chunkSize)
+			panic(errors.New(msg))
+		}
+
+		o.arenaChunk = chunkSize
+	}
+}
+
+func resolveHasher[K comparable](o options) Hasher[K] {
+	if o.hasher == nil {
+		return newDefaultHasher[K]()
+	}
+
+	hasher, ok := o.hasher.(Hasher[K])
+
+	if !ok {
+		msg := fmt.Sprintf("hasher %T does not hash keys of type %T", o.hasher, *new(K))
+		panic(errors.New(msg))
+	}
+
+	return hasher
+}
+
+func NewHashTableWithOptions[K comparable, V any](opts ...Option) *HashTable[K, V] {
+	o := applyOptions(opts)
+
+	shrinkFactor := o.loadFactor / 4
+
+	if o.shrinkFactor != nil {
+		shrinkFactor = *o.shrinkFactor
+	}
+
+	if !(shrinkFactor >= 0 && shrinkFactor < o.loadFactor/2) {
+		msg := fmt.Sprintf("invalid shrink factor %v for load factor %v", shrinkFactor, o.loadFactor)
+		panic(errors.New(msg))
+	}
+
+	hashTable := HashTable[K, V]{
+		actualBucketSize: 0,
+		sizeItems:        0,
+		loadFactor:       o.loadFactor,
+		shrinkFactor:     shrinkFactor,
+		hasher:           resolveHasher[K](o),
+		bound:            resolveBound[K, V](o),
+	}
+
+	length := initialLength(o.capacity, o.loadFactor)
+
+	if o.arenaChunk > 0 {
+		hashTable.arena = arena.New[Node[K, V]](o.arenaChunk)
+	}
+
+	hashTable.minLength = length
+	hashTable.resetBucket(length)
+
+	return &hashTable
+}
+
+// initialLength is the bucket length holding capacity keys without a resize,
+// capped at 1<<31 like the growth of reserve since the length is a uint32
+func initialLength(capacity uint32, loadFactor float64) uint32 {
+	length := uint32(2)
+
+	for float64(capacity) > float64(length)*loadFactor && length < 1<<31 {
+		length <<= 1
+	}
+
+	return length
+}
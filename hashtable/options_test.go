@@ -0,0 +1,161 @@
+package hashtable
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestWithHasherIsUsedForEveryOperation(t *testing.T) {
+	calls := 0
+
+	hashTable := NewHashTableWithOptions[string, int](WithHasher[string](HasherFunc[string](func(key string) uint64 {
+		calls++
+		return StringHash(key)
+	})))
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(fmt.Sprint(i), i)
+	}
+
+	for i := 0; i < 100; i++ {
+		if value := hashTable.Get(fmt.Sprint(i)); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+
+	if calls < 200 {
+		t.Errorf("Expected the custom hasher to be called at least 200 times, got %d", calls)
+	}
+}
+
+func TestShouldPanicWhenHasherDoesNotMatchKeyType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewHashTableWithOptions[int, int](WithHasher[string](HasherFunc[string](StringHash)))
+}
+
+func TestWithCapacityAvoidsResize(t *testing.T) {
+	hashTable := NewHashTableWithOptions[int, int](WithCapacity(1000))
+	length := hashTable.actualBucketLength
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	if hashTable.actualBucketLength != length {
+		t.Errorf("Expected bucket length to stay %d, got %d", length, hashTable.actualBucketLength)
+	}
+
+	if hashTable.Size() != 1000 {
+		t.Errorf("Expected size to be 1000, got %d", hashTable.Size())
+	}
+}
+
+func TestWithLoadFactor(t *testing.T) {
+	sparse := NewHashTableWithOptions[int, int](WithLoadFactor(0.25))
+	dense := NewHashTableWithOptions[int, int](WithLoadFactor(0.9))
+
+	for i := 0; i < 1000; i++ {
+		sparse.Insert(i, i)
+		dense.Insert(i, i)
+	}
+
+	if sparse.actualBucketLength <= dense.actualBucketLength {
+		t.Errorf("Expected a lower load factor to use more buckets, got %d and %d", sparse.actualBucketLength, dense.actualBucketLength)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if value := dense.Get(i); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+}
+
+func TestShouldPanicOnInvalidLoadFactor(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewHashTableWithOptions[int, int](WithLoadFactor(1))
+}
+
+func TestShrinksAfterDeletes(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	peak := hashTable.actualBucketLength
+
+	hashTable.RemoveIf(func(entry Entry[int, int]) bool {
+		return entry.Key >= 10
+	})
+
+	if hashTable.actualBucketLength >= peak {
+		t.Errorf("Expected bucket length to shrink below %d, got %d", peak, hashTable.actualBucketLength)
+	}
+
+	if hashTable.Size() != 10 {
+		t.Errorf("Expected size to be 10, got %d", hashTable.Size())
+	}
+
+	for i := 0; i < 10; i++ {
+		if value := hashTable.Get(i); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+}
+
+func TestShrinkStopsAtInitialCapacity(t *testing.T) {
+	hashTable := NewHashTableWithOptions[int, int](WithCapacity(100))
+	initial := hashTable.actualBucketLength
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	hashTable.RemoveIf(func(Entry[int, int]) bool {
+		return true
+	})
+
+	if hashTable.actualBucketLength != initial {
+		t.Errorf("Expected bucket length to be %d, got %d", initial, hashTable.actualBucketLength)
+	}
+}
+
+func TestWithShrinkFactorZeroDisablesShrinking(t *testing.T) {
+	hashTable := NewHashTableWithOptions[int, int](WithShrinkFactor(0))
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	peak := hashTable.actualBucketLength
+
+	hashTable.DeleteAll([]int{1, 2, 3})
+	hashTable.RemoveIf(func(Entry[int, int]) bool {
+		return true
+	})
+
+	if hashTable.actualBucketLength != peak {
+		t.Errorf("Expected bucket length to stay %d, got %d", peak, hashTable.actualBucketLength)
+	}
+}
+
+func TestInitialLength(t *testing.T) {
+	if length := initialLength(12, 0.75); length != 16 {
+		t.Errorf("Expected length to be 16, got %d", length)
+	}
+
+	if length := initialLength(math.MaxUint32, 0.75); length != 1<<31 {
+		t.Errorf("Expected length to be capped at %d, got %d", 1<<31, length)
+	}
+}
@@ -0,0 +1,210 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/arena"
+	"algorithms/iterator"
+)
+
+type orderedNode[K comparable, V any] struct {
+	entry  Entry[K, V]
+	before *orderedNode[K, V]
+	after  *orderedNode[K, V]
+}
+
+// OrderedHashTable threads a doubly linked list through its entries so that
+// iteration follows insertion order. Overwriting a key keeps its position,
+// unless the table was created WithAccessOrder, in which case every read or
+// overwrite moves the entry to the back and Eldest is the least recently used.
+type OrderedHashTable[K comparable, V any] struct {
+	table       *HashTable[K, *orderedNode[K, V]]
+	head        *orderedNode[K, V]
+	tail        *orderedNode[K, V]
+	accessOrder bool
+	arena       *arena.Arena[orderedNode[K, V]]
+}
+
+func NewOrderedHashTable[K comparable, V any](opts ...Option) *OrderedHashTable[K, V] {
+	o := applyOptions(opts)
+	rejectBound(o, "OrderedHashTable")
+
+	table := OrderedHashTable[K, V]{
+		table:       NewHashTableWithOptions[K, *orderedNode[K, V]](opts...),
+		accessOrder: o.accessOrder,
+	}
+
+	if o.arenaChunk > 0 {
+		table.arena = arena.New[orderedNode[K, V]](o.arenaChunk)
+	}
+
+	return &table
+}
+
+func (o *OrderedHashTable[K, V]) Insert(key K, value V) {
+	if node, found := o.table.TryGet(key); found {
+		node.entry.Value = value
+		o.touch(node)
+		return
+	}
+
+	var node *orderedNode[K, V]
+
+	if o.arena != nil {
+		node = o.arena.New()
+	} else {
+		node = &orderedNode[K, V]{}
+	}
+
+	node.entry = Entry[K, V]{Key: key, Value: value}
+
+	o.linkLast(node)
+	o.table.Insert(key, node)
+}
+
+func (o *OrderedHashTable[K, V]) Get(key K) V {
+	value, found := o.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return value
+}
+
+func (o *OrderedHashTable[K, V]) TryGet(key K) (value V, found bool) {
+	node, found := o.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	o.touch(node)
+
+	return node.entry.Value, true
+}
+
+// Peek reads a value without affecting the access order
+func (o *OrderedHashTable[K, V]) Peek(key K) (value V, found bool) {
+	node, found := o.table.TryGet(key)
+
+	if !found {
+		return
+	}
+
+	return node.entry.Value, true
+}
+
+func (o *OrderedHashTable[K, V]) Eldest() (entry Entry[K, V], found bool) {
+	if o.head == nil {
+		return
+	}
+
+	return o.head.entry, true
+}
+
+func (o *OrderedHashTable[K, V]) touch(node *orderedNode[K, V]) {
+	if o.accessOrder && node != o.tail {
+		o.unlink(node)
+		o.linkLast(node)
+	}
+}
+
+func (o *OrderedHashTable[K, V]) Delete(key K) bool {
+	node, found := o.table.Delete(key)
+
+	if !found {
+		return false
+	}
+
+	o.unlink(node)
+
+	return true
+}
+
+func (o *OrderedHashTable[K, V]) Size() uint32 {
+	return o.table.Size()
+}
+
+func (o *OrderedHashTable[K, V]) linkLast(node *orderedNode[K, V]) {
+	node.before = o.tail
+	node.after = nil
+
+	if o.tail == nil {
+		o.head = node
+	} else {
+		o.tail.after = node
+	}
+
+	o.tail = node
+}
+
+func (o *OrderedHashTable[K, V]) unlink(node *orderedNode[K, V]) {
+	if node.before == nil {
+		o.head = node.after
+	} else {
+		node.before.after = node.after
+	}
+
+	if node.after == nil {
+		o.tail = node.before
+	} else {
+		node.after.before = node.before
+	}
+
+	node.before = nil
+	node.after = nil
+}
+
+func (o *OrderedHashTable[K, V]) Iter() <-chan Entry[K, V] {
+	iterator := make(chan Entry[K, V])
+
+	go func() {
+		for node := o.head; node != nil; node = node.after {
+			iterator <- node.entry
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func (o *OrderedHashTable[K, V]) Map(f func(Entry[K, V]) interface{}) iterator.Collection[interface{}] {
+	collection := iterator.NewList[interface{}]()
+
+	for entry := range o.Iter() {
+		collection.Append(f(entry))
+	}
+
+	return collection
+}
+
+func (o *OrderedHashTable[K, V]) Filter(f func(Entry[K, V]) bool) iterator.Collection[Entry[K, V]] {
+	collection := iterator.NewList[Entry[K, V]]()
+
+	for entry := range o.Iter() {
+		if f(entry) {
+			collection.Append(entry)
+		}
+	}
+
+	return collection
+}
+
+func (o *OrderedHashTable[K, V]) ForEach(f func(Entry[K, V])) {
+	o.Range(func(entry Entry[K, V]) bool {
+		f(entry)
+		return true
+	})
+}
+
+func (o *OrderedHashTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	for node := o.head; node != nil; node = node.after {
+		if !f(node.entry) {
+			return
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+
+	"algorithms/iterator"
+)
+
+func TestOrderedHashTableImplementsIterator(t *testing.T) {
+	var _ iterator.Iterator[Entry[string, string]] = NewOrderedHashTable[string, string]()
+}
+
+func TestOrderedIterFollowsInsertionOrder(t *testing.T) {
+	hashTable := NewOrderedHashTable[string, int]()
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(fmt.Sprint(i), i)
+	}
+
+	// Overwriting keeps the original position
+	hashTable.Insert("0", -1)
+
+	expected := 0
+
+	for entry := range hashTable.Iter() {
+		if entry.Key != fmt.Sprint(expected) {
+			t.Fatalf("Expected key to be %d, got %s", expected, entry.Key)
+		}
+
+		expected++
+	}
+
+	if value := hashTable.Get("0"); value != -1 {
+		t.Errorf("Expected value to be -1, got %d", value)
+	}
+}
+
+func TestOrderedDelete(t *testing.T) {
+	hashTable := NewOrderedHashTable[string, int]()
+
+	hashTable.Insert("foo", 1)
+	hashTable.Insert("bar", 2)
+	hashTable.Insert("baz", 3)
+
+	if !hashTable.Delete("bar") {
+		t.Errorf("Expected 'bar' to be deleted")
+	}
+
+	if hashTable.Delete("bar") {
+		t.Errorf("Expected second delete to report nothing removed")
+	}
+
+	hashTable.Insert("bar", 4)
+
+	keys := make([]string, 0)
+	hashTable.ForEach(func(entry Entry[string, int]) {
+		keys = append(keys, entry.Key)
+	})
+
+	if fmt.Sprint(keys) != "[foo baz bar]" {
+		t.Errorf("Expected [foo baz bar], got %v", keys)
+	}
+
+	if hashTable.Size() != 3 {
+		t.Errorf("Expected size to be 3, got %d", hashTable.Size())
+	}
+}
+
+func TestAccessOrderMovesReadEntriesToTheBack(t *testing.T) {
+	hashTable := NewOrderedHashTable[string, int](WithAccessOrder())
+
+	hashTable.Insert("foo", 1)
+	hashTable.Insert("bar", 2)
+	hashTable.Insert("baz", 3)
+
+	if eldest, _ := hashTable.Eldest(); eldest.Key != "foo" {
+		t.Errorf("Expected eldest to be 'foo', got %s", eldest.Key)
+	}
+
+	hashTable.Get("foo")
+	hashTable.Insert("bar", 20)
+
+	if eldest, _ := hashTable.Eldest(); eldest.Key != "baz" {
+		t.Errorf("Expected eldest to be 'baz', got %s", eldest.Key)
+	}
+
+	hashTable.Peek("baz")
+
+	keys := make([]string, 0)
+	hashTable.ForEach(func(entry Entry[string, int]) {
+		keys = append(keys, entry.Key)
+	})
+
+	if fmt.Sprint(keys) != "[baz foo bar]" {
+		t.Errorf("Expected [baz foo bar], got %v", keys)
+	}
+}
+
+func TestInsertionOrderIgnoresReads(t *testing.T) {
+	hashTable := NewOrderedHashTable[string, int]()
+
+	hashTable.Insert("foo", 1)
+	hashTable.Insert("bar", 2)
+	hashTable.Get("foo")
+
+	if eldest, _ := hashTable.Eldest(); eldest.Key != "foo" {
+		t.Errorf("Expected eldest to be 'foo', got %s", eldest.Key)
+	}
+
+	if _, found := NewOrderedHashTable[string, int]().Eldest(); found {
+		t.Errorf("Expected empty table to have no eldest entry")
+	}
+}
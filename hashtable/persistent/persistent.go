@@ -0,0 +1,259 @@
+package persistent
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"algorithms/hashtable"
+	"algorithms/wal"
+)
+
+const (
+	snapshotName = "snapshot"
+	logName      = "wal"
+)
+
+const (
+	opInsert uint8 = iota
+	opDelete
+)
+
+var ErrInvalidRecord = errors.New("persistent: invalid log record")
+
+type record[K comparable, V any] struct {
+	Op    uint8
+	Key   K
+	Value V
+}
+
+// Table is a hash table stored in a directory: a snapshot of the whole table
+// plus a write-ahead log of every mutation since. Writes are appended to the
+// log before they are applied in memory, and become durable on Sync. Compact
+// writes a fresh snapshot and empties the log. Keys and values are encoded
+// with encoding/gob.
+type Table[K comparable, V any] struct {
+	mutex sync.Mutex
+	dir   string
+	table *hashtable.HashTable[K, V]
+	log   *wal.Log
+}
+
+// Open loads the snapshot in dir, replays the log on top of it and keeps the
+// log open for appends. The directory is created when missing.
+func Open[K comparable, V any](dir string) (*Table[K, V], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	t := Table[K, V]{
+		dir:   dir,
+		table: hashtable.NewHashTable[K, V](),
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, snapshotName))
+
+	switch {
+	case err == nil:
+		if err := t.table.GobDecode(data); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	log, err := wal.Open(filepath.Join(dir, logName))
+	if err != nil {
+		return nil, err
+	}
+
+	err = log.Replay(func(entry wal.Record) error {
+		return t.apply(entry.Data)
+	})
+
+	if err != nil {
+		log.Close()
+		return nil, err
+	}
+
+	t.log = log
+
+	return &t, nil
+}
+
+func (t *Table[K, V]) apply(data []byte) error {
+	var r record[K, V]
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&r); err != nil {
+		return err
+	}
+
+	switch r.Op {
+	case opInsert:
+		t.table.Insert(r.Key, r.Value)
+	case opDelete:
+		t.table.Delete(r.Key)
+	default:
+		return ErrInvalidRecord
+	}
+
+	return nil
+}
+
+func (t *Table[K, V]) append(r record[K, V]) error {
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(r); err != nil {
+		return err
+	}
+
+	_, err := t.log.Append(buffer.Bytes())
+
+	return err
+}
+
+func (t *Table[K, V]) Insert(key K, value V) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err := t.append(record[K, V]{Op: opInsert, Key: key, Value: value}); err != nil {
+		return err
+	}
+
+	t.table.Insert(key, value)
+
+	return nil
+}
+
+// Delete logs nothing for a missing key
+func (t *Table[K, V]) Delete(key K) (bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.table.Contains(key) {
+		return false, nil
+	}
+
+	if err := t.append(record[K, V]{Op: opDelete, Key: key}); err != nil {
+		return false, err
+	}
+
+	t.table.Delete(key)
+
+	return true, nil
+}
+
+func (t *Table[K, V]) Get(key K) (V, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.table.TryGet(key)
+}
+
+func (t *Table[K, V]) Contains(key K) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.table.Contains(key)
+}
+
+func (t *Table[K, V]) Size() uint32 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.table.Size()
+}
+
+// Range calls f on every entry until it returns false. The table is locked
+// meanwhile, so f must not call back into it.
+func (t *Table[K, V]) Range(f func(hashtable.Entry[K, V]) bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.table.Range(f)
+}
+
+// LogSize is the number of bytes logged since the last snapshot, a hint for
+// when to Compact
+func (t *Table[K, V]) LogSize() int64 {
+	return t.log.Size()
+}
+
+func (t *Table[K, V]) Sync() error {
+	return t.log.Sync()
+}
+
+// Compact replaces the snapshot with the current contents and empties the
+// log. The snapshot is written to a temporary file and renamed over the old
+// one, so a crash leaves either the old snapshot with the full log or the new
+// one, on which replaying the log again is harmless.
+func (t *Table[K, V]) Compact() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	data, err := t.table.GobEncode()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(t.dir, snapshotName)
+
+	if err := writeFile(path+".tmp", data); err != nil {
+		return err
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	if err := syncDir(t.dir); err != nil {
+		return err
+	}
+
+	return t.log.Reset()
+}
+
+func writeFile(path string, data []byte) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	err = file.Sync()
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Close flushes the log and releases the file, without taking a snapshot
+func (t *Table[K, V]) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.log.Close()
+}
@@ -0,0 +1,140 @@
+package persistent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"algorithms/hashtable"
+)
+
+func open(t *testing.T, dir string) *Table[string, int] {
+	table, err := Open[string, int](dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	return table
+}
+
+func TestReopenReplaysLog(t *testing.T) {
+	dir := t.TempDir()
+	table := open(t, dir)
+
+	table.Insert("foo", 1)
+	table.Insert("bar", 2)
+	table.Insert("foo", 3)
+
+	if deleted, _ := table.Delete("bar"); !deleted {
+		t.Errorf("Expected 'bar' to be deleted")
+	}
+
+	if deleted, _ := table.Delete("baz"); deleted {
+		t.Errorf("Expected 'baz' not to be found")
+	}
+
+	table.Close()
+
+	table = open(t, dir)
+	defer table.Close()
+
+	if value, found := table.Get("foo"); !found || value != 3 {
+		t.Errorf("Expected value to be 3, got %d", value)
+	}
+
+	if table.Contains("bar") || table.Size() != 1 {
+		t.Errorf("Expected only 'foo' to survive, got size %d", table.Size())
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	table := open(t, dir)
+
+	for i := 0; i < 100; i++ {
+		table.Insert(string(rune('a'+i%26)), i)
+	}
+
+	if err := table.Compact(); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if table.LogSize() != 0 {
+		t.Errorf("Expected an empty log after Compact, got %d bytes", table.LogSize())
+	}
+
+	table.Insert("z", -1)
+	table.Delete("a")
+	table.Close()
+
+	table = open(t, dir)
+	defer table.Close()
+
+	if table.Size() != 25 {
+		t.Errorf("Expected size to be 25, got %d", table.Size())
+	}
+
+	if value, _ := table.Get("z"); value != -1 {
+		t.Errorf("Expected value to be -1, got %d", value)
+	}
+
+	if value, _ := table.Get("b"); value != 79 {
+		t.Errorf("Expected value to be 79, got %d", value)
+	}
+
+	count := 0
+
+	table.Range(func(hashtable.Entry[string, int]) bool {
+		count++
+		return true
+	})
+
+	if count != 25 {
+		t.Errorf("Expected to visit 25 entries, got %d", count)
+	}
+}
+
+func TestReplayAfterSnapshotIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	table := open(t, dir)
+
+	table.Insert("foo", 1)
+	table.Delete("foo")
+	table.Insert("bar", 2)
+	table.Sync()
+
+	log, _ := os.ReadFile(filepath.Join(dir, logName))
+
+	table.Compact()
+	table.Close()
+
+	// Simulate a crash between renaming the snapshot and emptying the log
+	os.WriteFile(filepath.Join(dir, logName), log, 0o644)
+
+	table = open(t, dir)
+	defer table.Close()
+
+	if table.Size() != 1 || table.Contains("foo") {
+		t.Errorf("Expected only 'bar' after replaying over the snapshot, got size %d", table.Size())
+	}
+}
+
+func TestTornLogTail(t *testing.T) {
+	dir := t.TempDir()
+	table := open(t, dir)
+
+	table.Insert("foo", 1)
+	table.Insert("bar", 2)
+	table.Close()
+
+	path := filepath.Join(dir, logName)
+	info, _ := os.Stat(path)
+	os.Truncate(path, info.Size()-3)
+
+	table = open(t, dir)
+	defer table.Close()
+
+	if !table.Contains("foo") || table.Contains("bar") {
+		t.Errorf("Expected the torn record to be dropped")
+	}
+}
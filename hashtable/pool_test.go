@@ -0,0 +1,168 @@
+package hashtable
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestChurnReusesNodes(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	i := 0
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		hashTable.Delete(i)
+		hashTable.Insert(i, i)
+		i++
+	})
+
+	if allocs != 0 {
+		t.Errorf("Expected no allocations per delete and insert, got %v", allocs)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if value := hashTable.Get(i); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+}
+
+func TestClearReusesNodes(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	fill := func() {
+		for i := 0; i < 1000; i++ {
+			hashTable.Insert(i, i)
+		}
+	}
+
+	fill()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		hashTable.Clear()
+		fill()
+	})
+
+	if allocs != 0 {
+		t.Errorf("Expected refilling a cleared table not to allocate, got %v", allocs)
+	}
+}
+
+func TestReleasedNodesDropReferences(t *testing.T) {
+	hashTable := NewHashTable[int, *[]byte]()
+	payload := make([]byte, 16)
+
+	hashTable.Insert(1, &payload)
+	hashTable.Delete(1)
+
+	if hashTable.free == nil || hashTable.free.entry.Value != nil {
+		t.Errorf("Expected the released node to be zeroed")
+	}
+}
+
+func benchmarkChurn(b *testing.B, n int) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < n; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		key := i % n
+
+		hashTable.Delete(key)
+		hashTable.Insert(key, i)
+	}
+
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+
+	b.ReportMetric(float64(after.TotalAlloc-before.TotalAlloc)/float64(b.N), "total-B/op")
+	b.ReportMetric(float64(after.NumGC-before.NumGC), "gc-cycles")
+}
+
+func BenchmarkChurn1K(b *testing.B) {
+	benchmarkChurn(b, 1_000)
+}
+
+func BenchmarkChurn1M(b *testing.B) {
+	benchmarkChurn(b, 1_000_000)
+}
+
+func BenchmarkInsert1M(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		hashTable := NewHashTable[int, int]()
+
+		for j := 0; j < 1_000_000; j++ {
+			hashTable.Insert(j, j)
+		}
+	}
+}
+
+func TestWithArenaAllocatesNodesInChunks(t *testing.T) {
+	fill := func(opts ...Option) func() {
+		return func() {
+			hashTable := NewHashTableWithOptions[int, int](opts...)
+
+			for i := 0; i < 1000; i++ {
+				hashTable.Insert(i, i)
+			}
+		}
+	}
+
+	plain := testing.AllocsPerRun(5, fill())
+	chunked := testing.AllocsPerRun(5, fill(WithArena(1024)))
+
+	if chunked >= plain/10 {
+		t.Errorf("Expected far fewer allocations with an arena, got %v against %v", chunked, plain)
+	}
+
+	hashTable := NewHashTableWithOptions[int, int](WithArena(16))
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	for i := 0; i < 100; i++ {
+		if value := hashTable.Get(i); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+}
+
+func TestOrderedWithArena(t *testing.T) {
+	hashTable := NewOrderedHashTable[int, int](WithArena(8))
+
+	for i := 0; i < 20; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	hashTable.Delete(5)
+
+	expected := 0
+
+	for entry := range hashTable.Iter() {
+		if expected == 5 {
+			expected++
+		}
+
+		if entry.Key != expected {
+			t.Errorf("Expected key to be %d, got %d", expected, entry.Key)
+		}
+
+		expected++
+	}
+}
@@ -0,0 +1,25 @@
+package hashtable
+
+import (
+	"sort"
+	"strings"
+)
+
+// ScanPrefix returns the entries whose key starts with prefix sorted by key.
+// The table has no key order, so every call takes a snapshot of the matching
+// keys and sorts it.
+func ScanPrefix[V any](h *HashTable[string, V], prefix string) []Entry[string, V] {
+	entries := make([]Entry[string, V], 0)
+
+	for entry := range h.Iter() {
+		if strings.HasPrefix(entry.Key, prefix) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries
+}
@@ -0,0 +1,28 @@
+package hashtable
+
+import "testing"
+
+func TestScanPrefix(t *testing.T) {
+	hashTable := NewHashTable[string, int]()
+
+	for i, key := range []string{"config/b", "config/a", "configuration", "secrets/a", "config/c"} {
+		hashTable.Insert(key, i)
+	}
+
+	entries := ScanPrefix(hashTable, "config/")
+	expected := []string{"config/a", "config/b", "config/c"}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(entries))
+	}
+
+	for i, entry := range entries {
+		if entry.Key != expected[i] {
+			t.Errorf("Expected key to be %s, got %s", expected[i], entry.Key)
+		}
+	}
+
+	if len(ScanPrefix(hashTable, "missing/")) != 0 {
+		t.Errorf("Expected no entries for a missing prefix")
+	}
+}
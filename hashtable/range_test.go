@@ -0,0 +1,111 @@
+package hashtable
+
+import (
+	"testing"
+	"time"
+)
+
+type rangeable interface {
+	Range(f func(Entry[int, int]) bool)
+}
+
+func rangeCollections(n int) map[string]rangeable {
+	collections := map[string]rangeable{}
+
+	for _, implementation := range mapImplementations {
+		table := implementation.new()
+
+		for i := 0; i < n; i++ {
+			table.Insert(i, i)
+		}
+
+		collections[implementation.name] = table
+	}
+
+	fixed := NewFixedHashTable[int, int](uint32(n), IntHash[int])
+	ordered := NewOrderedHashTable[int, int]()
+	concurrent := NewConcurrentHashTable[int, int](8)
+	expiring := NewExpiringHashTable[int, int]()
+	versioned := NewVersionedHashTable[int, int]()
+
+	for i := 0; i < n; i++ {
+		fixed.Insert(i, i)
+		ordered.Insert(i, i)
+		concurrent.Insert(i, i)
+		expiring.InsertWithTTL(i, i, time.Hour)
+		versioned.Insert(i, i)
+	}
+
+	collections["Fixed"] = fixed
+	collections["Ordered"] = ordered
+	collections["Concurrent"] = concurrent
+	collections["Expiring"] = expiring
+	collections["Snapshot"] = versioned.Snapshot()
+
+	return collections
+}
+
+func TestRangeVisitsEveryEntryWithoutAllocating(t *testing.T) {
+	for name, collection := range rangeCollections(1000) {
+		sum := 0
+
+		visit := func(entry Entry[int, int]) bool {
+			sum += entry.Value
+			return true
+		}
+
+		collection.Range(visit)
+
+		if sum != 999*1000/2 {
+			t.Errorf("Expected %s to visit every entry, got sum %d", name, sum)
+		}
+
+		if allocs := testing.AllocsPerRun(10, func() { collection.Range(visit) }); allocs != 0 {
+			t.Errorf("Expected %s Range not to allocate, got %v", name, allocs)
+		}
+	}
+}
+
+func TestRangeStopsEarly(t *testing.T) {
+	for name, collection := range rangeCollections(100) {
+		visited := 0
+
+		collection.Range(func(Entry[int, int]) bool {
+			visited++
+			return visited < 10
+		})
+
+		if visited != 10 {
+			t.Errorf("Expected %s to stop after 10 entries, got %d", name, visited)
+		}
+	}
+}
+
+func BenchmarkRange(b *testing.B) {
+	for name, collection := range rangeCollections(10_000) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				collection.Range(func(Entry[int, int]) bool {
+					return true
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkIter(b *testing.B) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 10_000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for range hashTable.Iter() {
+		}
+	}
+}
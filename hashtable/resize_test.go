@@ -0,0 +1,107 @@
+package hashtable
+
+import "testing"
+
+func fillUntilMigrating(t *testing.T, hashTable *HashTable[int, int]) int {
+	t.Helper()
+
+	for i := 0; ; i++ {
+		hashTable.Insert(i, i)
+
+		if i > 1000 && hashTable.migrating() {
+			return i + 1
+		}
+
+		if i > 1_000_000 {
+			t.Fatalf("Expected a resize to start")
+		}
+	}
+}
+
+func TestResizeMigratesIncrementally(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+	n := fillUntilMigrating(t, hashTable)
+
+	pending := hashTable.oldBucketSize
+
+	if pending < migrateStep*4 {
+		t.Fatalf("Expected many buckets left to migrate, got %d", pending)
+	}
+
+	hashTable.Insert(n, n)
+	n++
+
+	if hashTable.oldBucketSize < pending-migrateStep-1 {
+		t.Errorf("Expected a single insert to migrate at most %d buckets, got %d", migrateStep+1, pending-hashTable.oldBucketSize)
+	}
+
+	for i := 0; i < n; i++ {
+		if value, found := hashTable.TryGet(i); !found || value != i {
+			t.Errorf("Expected value to be %d, got %d (found %v)", i, value, found)
+		}
+	}
+
+	visited := 0
+
+	for range hashTable.All() {
+		visited++
+	}
+
+	if visited != n || hashTable.Size() != uint32(n) {
+		t.Errorf("Expected %d entries, visited %d with size %d", n, visited, hashTable.Size())
+	}
+
+	for hashTable.migrating() {
+		hashTable.Insert(n, n)
+		n++
+	}
+
+	if hashTable.Size() != uint32(n) || hashTable.Stats().NonEmptyBuckets != hashTable.actualBucketSize {
+		t.Errorf("Expected migration to settle with %d entries, got %+v", n, hashTable.Stats())
+	}
+}
+
+func TestWritesDuringMigration(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+	n := fillUntilMigrating(t, hashTable)
+
+	clone := hashTable.Clone()
+
+	for i := 0; i < n; i += 2 {
+		if _, found := hashTable.Delete(i); !found {
+			t.Errorf("Expected %d to be deleted", i)
+		}
+	}
+
+	for i := 1; i < n; i += 2 {
+		hashTable.Insert(i, -i)
+	}
+
+	if hashTable.Size() != uint32(n/2) {
+		t.Errorf("Expected size to be %d, got %d", n/2, hashTable.Size())
+	}
+
+	for i := 1; i < n; i += 2 {
+		if value := hashTable.Get(i); value != -i {
+			t.Errorf("Expected value to be %d, got %d", -i, value)
+		}
+	}
+
+	if clone.Size() != uint32(n) {
+		t.Errorf("Expected the clone to keep %d entries, got %d", n, clone.Size())
+	}
+
+	for i := 0; i < n; i++ {
+		if value := clone.Get(i); value != i {
+			t.Errorf("Expected clone value to be %d, got %d", i, value)
+		}
+	}
+
+	removed := clone.RemoveIf(func(entry Entry[int, int]) bool {
+		return entry.Key%3 == 0
+	})
+
+	if clone.Size() != uint32(n-removed) || clone.Contains(3) {
+		t.Errorf("Expected RemoveIf to drop multiples of 3, got size %d", clone.Size())
+	}
+}
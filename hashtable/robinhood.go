@@ -0,0 +1,217 @@
+package hashtable
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+type robinHoodSlot[K comparable, V any] struct {
+	entry Entry[K, V]
+	hash  uint64
+	// distance is one more than how far the slot is from its home, zero
+	// marks an empty slot
+	distance uint32
+}
+
+// RobinHoodTable is an open-addressing table where an insert takes the slot
+// of any entry closer to its home than the one being placed. Probe distances
+// stay short and even, lookups stop as soon as they would be richer than
+// the slot they inspect, and deletions shift the following run backwards.
+type RobinHoodTable[K comparable, V any] struct {
+	slots  []robinHoodSlot[K, V]
+	mask   uint64
+	size   uint32
+	growAt uint32
+	hasher Hasher[K]
+}
+
+// robinHoodLoad is the load factor, as a fraction of 8, that triggers growth
+const robinHoodLoad = 7
+
+func NewRobinHoodTable[K comparable, V any](opts ...Option) *RobinHoodTable[K, V] {
+	o := applyOptions(opts)
+	rejectBound(o, "RobinHoodTable")
+
+	table := RobinHoodTable[K, V]{
+		hasher: resolveHasher[K](o),
+	}
+
+	length := uint64(8)
+
+	for uint64(o.capacity)*8 > length*robinHoodLoad {
+		length <<= 1
+	}
+
+	table.resize(length)
+
+	return &table
+}
+
+func (r *RobinHoodTable[K, V]) resize(length uint64) {
+	slots := r.slots
+
+	r.slots = make([]robinHoodSlot[K, V], length)
+	r.mask = length - 1
+	r.growAt = uint32(length * robinHoodLoad / 8)
+	r.size = 0
+
+	for i := range slots {
+		if slots[i].distance != 0 {
+			r.place(slots[i].entry, slots[i].hash)
+		}
+	}
+}
+
+// place inserts an entry known not to be in the table yet
+func (r *RobinHoodTable[K, V]) place(entry Entry[K, V], hash uint64) {
+	candidate := robinHoodSlot[K, V]{entry: entry, hash: hash, distance: 1}
+	index := hash & r.mask
+
+	for {
+		slot := &r.slots[index]
+
+		if slot.distance == 0 {
+			*slot = candidate
+			r.size++
+			return
+		}
+
+		if slot.distance < candidate.distance {
+			*slot, candidate = candidate, *slot
+		}
+
+		candidate.distance++
+		index = (index + 1) & r.mask
+	}
+}
+
+func (r *RobinHoodTable[K, V]) find(key K, hash uint64) (index uint64, found bool) {
+	index = hash & r.mask
+
+	for distance := uint32(1); r.slots[index].distance >= distance; distance++ {
+		if r.slots[index].hash == hash && r.slots[index].entry.Key == key {
+			return index, true
+		}
+
+		index = (index + 1) & r.mask
+	}
+
+	return 0, false
+}
+
+func (r *RobinHoodTable[K, V]) Insert(key K, value V) {
+	hash := r.hasher.Hash(key)
+
+	if index, found := r.find(key, hash); found {
+		r.slots[index].entry.Value = value
+		return
+	}
+
+	if r.size >= r.growAt {
+		r.resize(uint64(len(r.slots)) << 1)
+	}
+
+	r.place(Entry[K, V]{Key: key, Value: value}, hash)
+}
+
+func (r *RobinHoodTable[K, V]) Get(key K) (value V) {
+	value, found := r.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return
+}
+
+func (r *RobinHoodTable[K, V]) TryGet(key K) (value V, found bool) {
+	index, found := r.find(key, r.hasher.Hash(key))
+
+	if !found {
+		return
+	}
+
+	return r.slots[index].entry.Value, true
+}
+
+func (r *RobinHoodTable[K, V]) Contains(key K) bool {
+	_, found := r.find(key, r.hasher.Hash(key))
+
+	return found
+}
+
+func (r *RobinHoodTable[K, V]) Delete(key K) (value V, found bool) {
+	index, found := r.find(key, r.hasher.Hash(key))
+
+	if !found {
+		return
+	}
+
+	value = r.slots[index].entry.Value
+
+	// Backward shift: pull every displaced successor one slot closer to home
+	next := (index + 1) & r.mask
+
+	for r.slots[next].distance > 1 {
+		r.slots[index] = r.slots[next]
+		r.slots[index].distance--
+		index = next
+		next = (next + 1) & r.mask
+	}
+
+	r.slots[index] = robinHoodSlot[K, V]{}
+	r.size--
+
+	return value, true
+}
+
+func (r *RobinHoodTable[K, V]) Size() uint32 {
+	return r.size
+}
+
+func (r *RobinHoodTable[K, V]) Clear() {
+	for i := range r.slots {
+		r.slots[i] = robinHoodSlot[K, V]{}
+	}
+
+	r.size = 0
+}
+
+func (r *RobinHoodTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range r.slots {
+			if r.slots[i].distance != 0 && !yield(r.slots[i].entry.Key, r.slots[i].entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+func (r *RobinHoodTable[K, V]) Keys() iter.Seq[K] {
+	return keysOf(r.All())
+}
+
+func (r *RobinHoodTable[K, V]) Values() iter.Seq[V] {
+	return valuesOf(r.All())
+}
+
+func (r *RobinHoodTable[K, V]) Iter() <-chan Entry[K, V] {
+	return entriesOf(r.All())
+}
+
+func (r *RobinHoodTable[K, V]) ForEach(f func(Entry[K, V])) {
+	r.Range(func(entry Entry[K, V]) bool {
+		f(entry)
+		return true
+	})
+}
+
+func (r *RobinHoodTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	for i := range r.slots {
+		if r.slots[i].distance != 0 && !f(r.slots[i].entry) {
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRobinHoodInsertGetDelete(t *testing.T) {
+	table := NewRobinHoodTable[string, int]()
+
+	for i := 0; i < 10_000; i++ {
+		table.Insert(fmt.Sprint(i), i)
+	}
+
+	if table.Size() != 10_000 {
+		t.Errorf("Expected size to be 10000, got %d", table.Size())
+	}
+
+	for i := 0; i < 10_000; i += 2 {
+		if value, found := table.Delete(fmt.Sprint(i)); !found || value != i {
+			t.Errorf("Expected to delete %d, got %d (found %v)", i, value, found)
+		}
+	}
+
+	for i := 0; i < 10_000; i++ {
+		value, found := table.TryGet(fmt.Sprint(i))
+
+		if found != (i%2 == 1) || (found && value != i) {
+			t.Errorf("Expected %d to be found %v, got %d (found %v)", i, i%2 == 1, value, found)
+		}
+	}
+
+	if table.Size() != 5_000 {
+		t.Errorf("Expected size to be 5000, got %d", table.Size())
+	}
+}
+
+func TestRobinHoodOverwrite(t *testing.T) {
+	table := NewRobinHoodTable[string, string]()
+
+	table.Insert("foo", "bar")
+	table.Insert("foo", "baz")
+
+	if table.Size() != 1 || table.Get("foo") != "baz" {
+		t.Errorf("Expected a single overwritten entry, got %d entries", table.Size())
+	}
+
+	if _, found := table.Delete("missing"); found {
+		t.Errorf("Expected missing key not to be deleted")
+	}
+}
+
+func TestRobinHoodKeepsProbeInvariant(t *testing.T) {
+	table := NewRobinHoodTable[int, int](WithCapacity(1000))
+	length := len(table.slots)
+
+	for i := 0; i < 1000; i++ {
+		table.Insert(i, i)
+	}
+
+	for i := 0; i < 1000; i += 3 {
+		table.Delete(i)
+	}
+
+	if len(table.slots) != length {
+		t.Errorf("Expected WithCapacity to avoid growth, got %d slots instead of %d", len(table.slots), length)
+	}
+
+	for i, slot := range table.slots {
+		if slot.distance == 0 {
+			continue
+		}
+
+		home := slot.hash & table.mask
+
+		if uint64(slot.distance-1) != (uint64(i)-home)&table.mask {
+			t.Errorf("Expected slot %d to be %d away from home, got %d", i, (uint64(i)-home)&table.mask, slot.distance-1)
+		}
+	}
+}
+
+func TestRobinHoodWithCollidingHasher(t *testing.T) {
+	table := NewRobinHoodTable[string, int](WithHasher[string](constantHasher[string]{}))
+
+	for i := 0; i < 100; i++ {
+		table.Insert(fmt.Sprint(i), i)
+	}
+
+	table.Delete("50")
+
+	for i := 0; i < 100; i++ {
+		if _, found := table.TryGet(fmt.Sprint(i)); found != (i != 50) {
+			t.Errorf("Expected %d to be found %v", i, i != 50)
+		}
+	}
+
+	sum := 0
+
+	for _, value := range table.All() {
+		sum += value
+	}
+
+	if sum != 4950-50 {
+		t.Errorf("Expected sum to be %d, got %d", 4950-50, sum)
+	}
+}
+
+func TestRobinHoodGetPanicsOnMissingKey(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewRobinHoodTable[int, int]().Get(1)
+}
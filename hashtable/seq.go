@@ -0,0 +1,38 @@
+package hashtable
+
+import "iter"
+
+func keysOf[K, V any](all iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range all {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func valuesOf[K, V any](all iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range all {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// entriesOf adapts a range-over-func sequence to the channel iterators
+func entriesOf[K, V any](all iter.Seq2[K, V]) <-chan Entry[K, V] {
+	iterator := make(chan Entry[K, V])
+
+	go func() {
+		for key, value := range all {
+			iterator <- Entry[K, V]{Key: key, Value: value}
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
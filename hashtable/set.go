@@ -0,0 +1,189 @@
+package hashtable
+
+import (
+	"iter"
+
+	"algorithms/iterator"
+)
+
+// Set is a hash set of elements, stored as the keys of a HashTable
+type Set[E comparable] struct {
+	table *HashTable[E, struct{}]
+}
+
+func NewSet[E comparable](opts ...Option) *Set[E] {
+	rejectBound(applyOptions(opts), "Set")
+
+	return &Set[E]{
+		table: NewHashTableWithOptions[E, struct{}](opts...),
+	}
+}
+
+func SetOf[E comparable](elements ...E) *Set[E] {
+	s := NewSet[E](WithCapacity(uint32(len(elements))))
+
+	for _, element := range elements {
+		s.Add(element)
+	}
+
+	return s
+}
+
+// Add reports whether element was not in the set yet
+func (s *Set[E]) Add(element E) bool {
+	hash, index := s.table.Hash(element)
+
+	if s.table.find(hash, index, element) != nil {
+		return false
+	}
+
+	s.table.insertNode(s.table.newNode(hash, element, struct{}{}), index)
+
+	return true
+}
+
+func (s *Set[E]) Contains(element E) bool {
+	return s.table.Contains(element)
+}
+
+// Remove reports whether element was in the set
+func (s *Set[E]) Remove(element E) bool {
+	_, found := s.table.Delete(element)
+
+	return found
+}
+
+func (s *Set[E]) RemoveIf(f func(E) bool) int {
+	return s.table.RemoveIf(func(entry Entry[E, struct{}]) bool {
+		return f(entry.Key)
+	})
+}
+
+func (s *Set[E]) Size() uint32 {
+	return s.table.Size()
+}
+
+func (s *Set[E]) IsEmpty() bool {
+	return s.table.Size() == 0
+}
+
+func (s *Set[E]) Clear() {
+	s.table.Clear()
+}
+
+func (s *Set[E]) Union(other *Set[E]) *Set[E] {
+	return &Set[E]{table: s.table.Union(other.table)}
+}
+
+func (s *Set[E]) Intersect(other *Set[E]) *Set[E] {
+	return &Set[E]{table: s.table.Intersect(other.table)}
+}
+
+func (s *Set[E]) Difference(other *Set[E]) *Set[E] {
+	return &Set[E]{table: s.table.Difference(other.table)}
+}
+
+func (s *Set[E]) IsSubsetOf(other *Set[E]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+
+	subset := true
+
+	s.Range(func(element E) bool {
+		subset = other.Contains(element)
+		return subset
+	})
+
+	return subset
+}
+
+func (s *Set[E]) All() iter.Seq[E] {
+	return s.table.Keys()
+}
+
+func (s *Set[E]) Iter() <-chan E {
+	iterator := make(chan E)
+
+	go func() {
+		for element := range s.All() {
+			iterator <- element
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func (s *Set[E]) Map(f func(E) interface{}) iterator.Collection[interface{}] {
+	collection := iterator.NewList[interface{}]()
+
+	s.Range(func(element E) bool {
+		collection.Append(f(element))
+		return true
+	})
+
+	return collection
+}
+
+func (s *Set[E]) Filter(f func(E) bool) iterator.Collection[E] {
+	filtered := NewSet[E]()
+
+	s.Range(func(element E) bool {
+		if f(element) {
+			filtered.Add(element)
+		}
+
+		return true
+	})
+
+	return filtered.Collection()
+}
+
+func (s *Set[E]) ForEach(f func(E)) {
+	s.Range(func(element E) bool {
+		f(element)
+		return true
+	})
+}
+
+func (s *Set[E]) Range(f func(E) bool) {
+	s.table.Range(func(entry Entry[E, struct{}]) bool {
+		return f(entry.Key)
+	})
+}
+
+// Collection views the set as an iterator.Collection. Its Append adds an
+// element and its Remove drops the element at the given position of the
+// iteration order, since a set has no indices of its own.
+func (s *Set[E]) Collection() iterator.Collection[E] {
+	return setCollection[E]{s}
+}
+
+type setCollection[E comparable] struct {
+	*Set[E]
+}
+
+func (c setCollection[E]) Append(element E) {
+	c.Add(element)
+}
+
+func (c setCollection[E]) Remove(index int) {
+	position := 0
+
+	c.Range(func(element E) bool {
+		if position == index {
+			c.Set.Remove(element)
+			return false
+		}
+
+		position++
+
+		return true
+	})
+}
+
+func (c setCollection[E]) Size() uint16 {
+	return uint16(c.Set.Size())
+}
@@ -0,0 +1,130 @@
+package hashtable
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"algorithms/iterator"
+)
+
+func sortedElements(s *Set[int]) []int {
+	return slices.Sorted(s.All())
+}
+
+func TestSetAddContainsRemove(t *testing.T) {
+	var _ iterator.Iterator[int] = NewSet[int]()
+
+	s := NewSet[int]()
+
+	if !s.Add(1) || s.Add(1) {
+		t.Errorf("Expected only the first Add to report a new element")
+	}
+
+	s.Add(2)
+
+	if !s.Contains(1) || s.Contains(3) {
+		t.Errorf("Expected the set to contain 1 but not 3")
+	}
+
+	if !s.Remove(1) || s.Remove(1) {
+		t.Errorf("Expected only the first Remove to find the element")
+	}
+
+	if s.Size() != 1 || s.IsEmpty() {
+		t.Errorf("Expected size to be 1, got %d", s.Size())
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := SetOf(1, 2, 3, 4)
+	b := SetOf(3, 4, 5)
+
+	if union := sortedElements(a.Union(b)); fmt.Sprint(union) != "[1 2 3 4 5]" {
+		t.Errorf("Expected [1 2 3 4 5], got %v", union)
+	}
+
+	if intersection := sortedElements(a.Intersect(b)); fmt.Sprint(intersection) != "[3 4]" {
+		t.Errorf("Expected [3 4], got %v", intersection)
+	}
+
+	if difference := sortedElements(a.Difference(b)); fmt.Sprint(difference) != "[1 2]" {
+		t.Errorf("Expected [1 2], got %v", difference)
+	}
+
+	if !SetOf(3, 4).IsSubsetOf(a) || b.IsSubsetOf(a) || !NewSet[int]().IsSubsetOf(b) {
+		t.Errorf("Expected IsSubsetOf to compare membership")
+	}
+
+	if a.Size() != 4 || b.Size() != 3 {
+		t.Errorf("Expected set operations to leave their operands untouched")
+	}
+}
+
+func TestSetCollection(t *testing.T) {
+	s := SetOf(1, 2, 3)
+	collection := s.Collection()
+
+	collection.Append(3)
+	collection.Append(4)
+
+	if collection.Size() != 4 {
+		t.Errorf("Expected size to be 4, got %d", collection.Size())
+	}
+
+	list := iterator.NewList[int]()
+
+	for _, element := range []int{4, 3, 2, 1} {
+		list.Append(element)
+	}
+
+	if !iterator.EqualUnordered(collection, list, func(a, b int) bool { return a == b }) {
+		t.Errorf("Expected the set to equal [4 3 2 1] in any order")
+	}
+
+	collection.Remove(0)
+
+	if s.Size() != 3 {
+		t.Errorf("Expected Remove to drop one element, got size %d", s.Size())
+	}
+
+	if removed := collection.RemoveIf(func(e int) bool { return e > 0 }); removed != 3 || !collection.IsEmpty() {
+		t.Errorf("Expected RemoveIf to empty the set, removed %d", removed)
+	}
+}
+
+func TestSetIterators(t *testing.T) {
+	s := SetOf(1, 2, 3, 4)
+
+	even := s.Filter(func(e int) bool { return e%2 == 0 })
+
+	if even.Size() != 2 {
+		t.Errorf("Expected 2 even elements, got %d", even.Size())
+	}
+
+	doubled := make([]int, 0)
+
+	for element := range s.Map(func(e int) interface{} { return e * 2 }).Iter() {
+		doubled = append(doubled, element.(int))
+	}
+
+	slices.Sort(doubled)
+
+	if fmt.Sprint(doubled) != "[2 4 6 8]" {
+		t.Errorf("Expected [2 4 6 8], got %v", doubled)
+	}
+
+	sum := 0
+
+	s.ForEach(func(e int) {
+		sum += e
+	})
+
+	for e := range s.Iter() {
+		sum += e
+	}
+
+	if sum != 20 {
+		t.Errorf("Expected sum to be 20, got %d", sum)
+	}
+}
@@ -0,0 +1,61 @@
+package hashtable
+
+// Merge returns a new table holding the entries of both tables. Keys present
+// in both are resolved by onConflict, which receives the value of h first.
+// Like the other set operations, the result keeps the hasher, default
+// provider and resize factors of h but none of its entry limit.
+func (h *HashTable[K, V]) Merge(other *HashTable[K, V], onConflict func(key K, current, incoming V) V) *HashTable[K, V] {
+	merged := h.empty(h.sizeItems + other.sizeItems)
+
+	for key, value := range h.All() {
+		merged.Insert(key, value)
+	}
+
+	for key, value := range other.All() {
+		if current, found := merged.TryGet(key); found {
+			merged.Insert(key, onConflict(key, current, value))
+			continue
+		}
+
+		merged.Insert(key, value)
+	}
+
+	return merged
+}
+
+// Union keeps the value of h for keys present in both tables
+func (h *HashTable[K, V]) Union(other *HashTable[K, V]) *HashTable[K, V] {
+	return h.Merge(other, func(_ K, current, _ V) V {
+		return current
+	})
+}
+
+// Intersect keeps the entries of h whose key is also in other
+func (h *HashTable[K, V]) Intersect(other *HashTable[K, V]) *HashTable[K, V] {
+	result := h.empty(min(h.sizeItems, other.sizeItems))
+
+	for key, value := range h.All() {
+		if other.Contains(key) {
+			result.Insert(key, value)
+		}
+	}
+
+	return result
+}
+
+// Difference keeps the entries of h whose key is not in other
+func (h *HashTable[K, V]) Difference(other *HashTable[K, V]) *HashTable[K, V] {
+	result := h.empty(h.sizeItems)
+
+	for key, value := range h.All() {
+		if !other.Contains(key) {
+			result.Insert(key, value)
+		}
+	}
+
+	return result
+}
+
+func (h *HashTable[K, V]) empty(capacity uint32) *HashTable[K, V] {
+	return NewHashTableWithDefault(h.defaultFn, WithHasher[K](h.hasher), WithLoadFactor(h.loadFactor), WithShrinkFactor(h.shrinkFactor), WithCapacity(capacity))
+}
@@ -0,0 +1,75 @@
+package hashtable
+
+import "testing"
+
+func newTableOf(entries map[string]int) *HashTable[string, int] {
+	hashTable := NewHashTable[string, int]()
+
+	for key, value := range entries {
+		hashTable.Insert(key, value)
+	}
+
+	return hashTable
+}
+
+func assertEntries(t *testing.T, hashTable *HashTable[string, int], expected map[string]int) {
+	t.Helper()
+
+	if hashTable.Size() != uint32(len(expected)) {
+		t.Errorf("Expected size to be %d, got %d", len(expected), hashTable.Size())
+	}
+
+	for key, value := range expected {
+		if actual, found := hashTable.TryGet(key); !found || actual != value {
+			t.Errorf("Expected %s to be %d, got %d (found %v)", key, value, actual, found)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := newTableOf(map[string]int{"foo": 1, "bar": 2})
+	b := newTableOf(map[string]int{"bar": 3, "baz": 4})
+
+	merged := a.Merge(b, func(_ string, current, incoming int) int {
+		return current + incoming
+	})
+
+	assertEntries(t, merged, map[string]int{"foo": 1, "bar": 5, "baz": 4})
+	assertEntries(t, a, map[string]int{"foo": 1, "bar": 2})
+}
+
+func TestUnion(t *testing.T) {
+	a := newTableOf(map[string]int{"foo": 1, "bar": 2})
+	b := newTableOf(map[string]int{"bar": 3, "baz": 4})
+
+	assertEntries(t, a.Union(b), map[string]int{"foo": 1, "bar": 2, "baz": 4})
+}
+
+func TestIntersect(t *testing.T) {
+	a := newTableOf(map[string]int{"foo": 1, "bar": 2})
+	b := newTableOf(map[string]int{"bar": 3, "baz": 4})
+
+	assertEntries(t, a.Intersect(b), map[string]int{"bar": 2})
+}
+
+func TestDifference(t *testing.T) {
+	a := newTableOf(map[string]int{"foo": 1, "bar": 2})
+	b := newTableOf(map[string]int{"bar": 3, "baz": 4})
+
+	assertEntries(t, a.Difference(b), map[string]int{"foo": 1})
+}
+
+func TestMergeWithCustomEvictionPolicy(t *testing.T) {
+	a := NewHashTableWithOptions[int, string](WithMaxEntries(2), WithEvictionPolicy[int](&smallestFirst{keys: map[int]bool{}}))
+	b := NewHashTable[int, string]()
+
+	a.Insert(1, "one")
+	a.Insert(2, "two")
+	b.Insert(3, "three")
+
+	merged := a.Union(b)
+
+	if merged.Size() != 3 || merged.Get(1) != "one" || merged.Get(3) != "three" {
+		t.Errorf("Expected the merge to hold 3 entries, got %d", merged.Size())
+	}
+}
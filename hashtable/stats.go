@@ -0,0 +1,49 @@
+package hashtable
+
+type Stats struct {
+	Size            uint32
+	Buckets         uint32
+	NonEmptyBuckets uint32
+	LoadFactor      float64
+	MaxChain        uint32
+	AvgChain        float64
+	Collisions      uint32
+	Resizes         uint32
+	Trees           uint32
+}
+
+// Stats walks every chain, so it costs as much as a full iteration.
+// AvgChain only counts non-empty buckets and Collisions is the number of
+// entries sharing a bucket with an earlier one.
+func (h *HashTable[K, V]) Stats() Stats {
+	stats := Stats{
+		Size:            h.sizeItems,
+		Buckets:         h.actualBucketLength,
+		NonEmptyBuckets: h.actualBucketSize + h.oldBucketSize,
+		LoadFactor:      float64(h.sizeItems) / float64(h.actualBucketLength),
+		Resizes:         h.resizes,
+		Trees:           uint32(len(h.trees)),
+	}
+
+	stats.Collisions = stats.Size - stats.NonEmptyBuckets
+
+	for _, buckets := range h.bucketArrays() {
+		for _, node := range buckets {
+			chain := uint32(0)
+
+			for ; node != nil; node = node.next {
+				chain++
+			}
+
+			if chain > stats.MaxChain {
+				stats.MaxChain = chain
+			}
+		}
+	}
+
+	if stats.NonEmptyBuckets > 0 {
+		stats.AvgChain = float64(stats.Size) / float64(stats.NonEmptyBuckets)
+	}
+
+	return stats
+}
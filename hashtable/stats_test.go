@@ -0,0 +1,53 @@
+package hashtable
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	hashTable := NewHashTable[int, int]()
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	stats := hashTable.Stats()
+
+	if stats.Size != 1000 {
+		t.Errorf("Expected size to be 1000, got %d", stats.Size)
+	}
+
+	if stats.Buckets != hashTable.actualBucketLength || stats.NonEmptyBuckets != hashTable.actualBucketSize+hashTable.oldBucketSize {
+		t.Errorf("Expected bucket counts to match the table, got %+v", stats)
+	}
+
+	if stats.Resizes == 0 {
+		t.Errorf("Expected at least one resize")
+	}
+
+	if stats.Collisions != stats.Size-stats.NonEmptyBuckets {
+		t.Errorf("Expected collisions to be %d, got %d", stats.Size-stats.NonEmptyBuckets, stats.Collisions)
+	}
+
+	if stats.AvgChain < 1 || float64(stats.MaxChain) < stats.AvgChain {
+		t.Errorf("Expected 1 <= avg chain <= max chain, got %v and %d", stats.AvgChain, stats.MaxChain)
+	}
+}
+
+func TestStatsWithConstantHasher(t *testing.T) {
+	hashTable := NewHashTableWithOptions[string, string](WithHasher[string](constantHasher[string]{}))
+
+	hashTable.Insert("foo", "1")
+	hashTable.Insert("bar", "2")
+	hashTable.Insert("baz", "3")
+
+	stats := hashTable.Stats()
+
+	if stats.MaxChain != 3 || stats.Collisions != 2 || stats.NonEmptyBuckets != 1 {
+		t.Errorf("Expected a single chain of 3 with 2 collisions, got %+v", stats)
+	}
+
+	empty := NewHashTable[string, string]().Stats()
+
+	if empty.AvgChain != 0 || empty.LoadFactor != 0 {
+		t.Errorf("Expected an empty table to report zero chains, got %+v", empty)
+	}
+}
@@ -0,0 +1,315 @@
+package hashtable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+const swissGroupSize = 16
+
+// Control bytes of a slot: a full slot stores the low 7 bits of its hash,
+// so the high bit alone tells free slots from used ones
+const (
+	swissEmpty   uint8 = 0b1000_0000
+	swissDeleted uint8 = 0b1111_1110
+)
+
+// swissLoad is the load factor, as a fraction of 8, that triggers growth
+const swissLoad = 7
+
+const (
+	swissLows  uint64 = 0x0101010101010101
+	swissHighs uint64 = 0x8080808080808080
+)
+
+type swissGroup[K comparable, V any] struct {
+	ctrl  [swissGroupSize]uint8
+	slots [swissGroupSize]Entry[K, V]
+}
+
+// swissBitset has bit i set for every matching slot i of a group
+type swissBitset uint32
+
+// SwissTable is a flat open-addressing table split into groups of 16 slots.
+// Each slot has one control byte holding either 7 bits of its hash or an
+// empty/deleted marker, so a probe compares all the control bytes of a group
+// word-at-a-time and only touches the keys whose hash bits match. Groups are
+// probed quadratically and a lookup stops at the first group with an empty
+// slot, which is why deletions leave tombstones in full groups.
+type SwissTable[K comparable, V any] struct {
+	groups     []swissGroup[K, V]
+	mask       uint64
+	size       uint32
+	tombstones uint32
+	growthLeft uint32
+	hasher     Hasher[K]
+}
+
+func NewSwissTable[K comparable, V any](opts ...Option) *SwissTable[K, V] {
+	o := applyOptions(opts)
+	rejectBound(o, "SwissTable")
+
+	table := SwissTable[K, V]{
+		hasher: resolveHasher[K](o),
+	}
+
+	groups := uint64(1)
+
+	for uint64(o.capacity)*8 > groups*swissGroupSize*swissLoad {
+		groups <<= 1
+	}
+
+	table.allocate(groups)
+
+	return &table
+}
+
+func (s *SwissTable[K, V]) allocate(groups uint64) {
+	s.groups = make([]swissGroup[K, V], groups)
+	s.mask = groups - 1
+	s.size = 0
+	s.tombstones = 0
+	s.growthLeft = uint32(groups * swissGroupSize * swissLoad / 8)
+
+	for i := range s.groups {
+		s.groups[i].ctrl = emptyControl()
+	}
+}
+
+func emptyControl() (ctrl [swissGroupSize]uint8) {
+	for i := range ctrl {
+		ctrl[i] = swissEmpty
+	}
+
+	return
+}
+
+// rehash doubles the table unless dropping the tombstones frees enough room
+func (s *SwissTable[K, V]) rehash() {
+	groups := s.groups
+	length := uint64(len(groups))
+
+	if uint64(s.size)*2 >= length*swissGroupSize*swissLoad/8 {
+		length <<= 1
+	}
+
+	s.allocate(length)
+
+	for i := range groups {
+		for slot, ctrl := range groups[i].ctrl {
+			if ctrl&swissEmpty == 0 {
+				entry := groups[i].slots[slot]
+				s.place(entry, s.hasher.Hash(entry.Key))
+			}
+		}
+	}
+}
+
+func splitHash(hash uint64) (h1 uint64, h2 uint8) {
+	return hash >> 7, uint8(hash & 0x7f)
+}
+
+func (g *swissGroup[K, V]) words() (uint64, uint64) {
+	return binary.LittleEndian.Uint64(g.ctrl[:8]), binary.LittleEndian.Uint64(g.ctrl[8:])
+}
+
+// collect turns a word with only high bits set into one bit per byte
+func collect(word uint64) swissBitset {
+	return swissBitset(((word >> 7) * 0x0102040810204080) >> 56)
+}
+
+func (g *swissGroup[K, V]) match(h2 uint8) swissBitset {
+	low, high := g.words()
+
+	return matchByte(low, h2) | matchByte(high, h2)<<8
+}
+
+func matchByte(word uint64, b uint8) swissBitset {
+	x := word ^ (swissLows * uint64(b))
+	nonZero := ((x &^ swissHighs) + ^swissHighs) | x
+
+	return collect(^nonZero & swissHighs)
+}
+
+func (g *swissGroup[K, V]) matchEmpty() swissBitset {
+	low, high := g.words()
+
+	// Empty is the only control byte with the high bit set and bit 1 clear
+	return collect(low&^(low<<6)&swissHighs) | collect(high&^(high<<6)&swissHighs)<<8
+}
+
+func (g *swissGroup[K, V]) matchFree() swissBitset {
+	low, high := g.words()
+
+	return collect(low&swissHighs) | collect(high&swissHighs)<<8
+}
+
+func (b swissBitset) first() int {
+	return bits.TrailingZeros32(uint32(b))
+}
+
+func (s *SwissTable[K, V]) find(key K, hash uint64) (group *swissGroup[K, V], slot int, found bool) {
+	h1, h2 := splitHash(hash)
+	index := h1 & s.mask
+
+	for step := uint64(1); ; step++ {
+		group = &s.groups[index]
+
+		for matches := group.match(h2); matches != 0; matches &= matches - 1 {
+			slot = matches.first()
+
+			if group.slots[slot].Key == key {
+				return group, slot, true
+			}
+		}
+
+		if group.matchEmpty() != 0 || step > s.mask {
+			return nil, 0, false
+		}
+
+		index = (index + step) & s.mask
+	}
+}
+
+// place stores an entry known not to be in the table yet, reporting false
+// when it would need a never used slot and the growth budget is exhausted
+func (s *SwissTable[K, V]) place(entry Entry[K, V], hash uint64) bool {
+	h1, h2 := splitHash(hash)
+	index := h1 & s.mask
+
+	for step := uint64(1); ; step++ {
+		group := &s.groups[index]
+
+		if free := group.matchFree(); free != 0 {
+			slot := free.first()
+
+			if group.ctrl[slot] == swissDeleted {
+				s.tombstones--
+			} else if s.growthLeft == 0 {
+				return false
+			} else {
+				s.growthLeft--
+			}
+
+			group.ctrl[slot] = h2
+			group.slots[slot] = entry
+			s.size++
+
+			return true
+		}
+
+		index = (index + step) & s.mask
+	}
+}
+
+func (s *SwissTable[K, V]) Insert(key K, value V) {
+	hash := s.hasher.Hash(key)
+
+	if group, slot, found := s.find(key, hash); found {
+		group.slots[slot].Value = value
+		return
+	}
+
+	for !s.place(Entry[K, V]{Key: key, Value: value}, hash) {
+		s.rehash()
+	}
+}
+
+func (s *SwissTable[K, V]) Get(key K) (value V) {
+	value, found := s.TryGet(key)
+
+	if !found {
+		msg := fmt.Sprintf("key not found: %v", key)
+		panic(errors.New(msg))
+	}
+
+	return
+}
+
+func (s *SwissTable[K, V]) TryGet(key K) (value V, found bool) {
+	group, slot, found := s.find(key, s.hasher.Hash(key))
+
+	if !found {
+		return
+	}
+
+	return group.slots[slot].Value, true
+}
+
+func (s *SwissTable[K, V]) Contains(key K) bool {
+	_, _, found := s.find(key, s.hasher.Hash(key))
+
+	return found
+}
+
+func (s *SwissTable[K, V]) Delete(key K) (value V, found bool) {
+	group, slot, found := s.find(key, s.hasher.Hash(key))
+
+	if !found {
+		return
+	}
+
+	value = group.slots[slot].Value
+	group.slots[slot] = Entry[K, V]{}
+	s.size--
+
+	// Probes stop at a group with an empty slot, so only a full group needs a
+	// tombstone to keep the keys that overflowed past it reachable
+	if group.matchEmpty() != 0 {
+		group.ctrl[slot] = swissEmpty
+		s.growthLeft++
+	} else {
+		group.ctrl[slot] = swissDeleted
+		s.tombstones++
+	}
+
+	return value, true
+}
+
+func (s *SwissTable[K, V]) Size() uint32 {
+	return s.size
+}
+
+func (s *SwissTable[K, V]) Clear() {
+	s.allocate(uint64(len(s.groups)))
+}
+
+func (s *SwissTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.Range(func(entry Entry[K, V]) bool {
+			return yield(entry.Key, entry.Value)
+		})
+	}
+}
+
+func (s *SwissTable[K, V]) Keys() iter.Seq[K] {
+	return keysOf(s.All())
+}
+
+func (s *SwissTable[K, V]) Values() iter.Seq[V] {
+	return valuesOf(s.All())
+}
+
+func (s *SwissTable[K, V]) Iter() <-chan Entry[K, V] {
+	return entriesOf(s.All())
+}
+
+func (s *SwissTable[K, V]) ForEach(f func(Entry[K, V])) {
+	s.Range(func(entry Entry[K, V]) bool {
+		f(entry)
+		return true
+	})
+}
+
+func (s *SwissTable[K, V]) Range(f func(Entry[K, V]) bool) {
+	for i := range s.groups {
+		for used := ^s.groups[i].matchFree() & (1<<swissGroupSize - 1); used != 0; used &= used - 1 {
+			if !f(s.groups[i].slots[used.first()]) {
+				return
+			}
+		}
+	}
+}
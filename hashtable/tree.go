@@ -0,0 +1,198 @@
+package hashtable
+
+const (
+	// treeifyThreshold is the chain length at which a bucket gets a tree
+	treeifyThreshold = 8
+	// untreeifyThreshold is the length under which the tree is dropped again,
+	// lower than treeifyThreshold so a bucket does not flip on every write
+	untreeifyThreshold = 6
+)
+
+// chainTree indexes the nodes of one long chain in an AVL tree ordered by
+// hash, keeping lookups logarithmic when many keys land in the same bucket.
+// The chain stays authoritative for iteration. Keys with identical hashes
+// share a tree node and are still compared one by one, since keys are only
+// comparable and not ordered.
+type chainTree[K comparable, V any] struct {
+	root *treeNode[K, V]
+	size int
+}
+
+type treeNode[K comparable, V any] struct {
+	hash   uint64
+	nodes  []*Node[K, V]
+	left   *treeNode[K, V]
+	right  *treeNode[K, V]
+	height int
+}
+
+func newChainTree[K comparable, V any](head *Node[K, V]) *chainTree[K, V] {
+	tree := &chainTree[K, V]{}
+
+	for node := head; node != nil; node = node.next {
+		tree.insert(node)
+	}
+
+	return tree
+}
+
+func (t *chainTree[K, V]) find(hash uint64, key K) *Node[K, V] {
+	current := t.root
+
+	for current != nil {
+		switch {
+		case hash < current.hash:
+			current = current.left
+		case hash > current.hash:
+			current = current.right
+		default:
+			for _, node := range current.nodes {
+				if node.entry.Key == key {
+					return node
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (t *chainTree[K, V]) insert(node *Node[K, V]) {
+	t.root = t.root.insert(node)
+	t.size++
+}
+
+func (t *chainTree[K, V]) remove(node *Node[K, V]) {
+	t.root = t.root.remove(node)
+	t.size--
+}
+
+func (n *treeNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func (n *treeNode[K, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+}
+
+func (n *treeNode[K, V]) rotateRight() *treeNode[K, V] {
+	left := n.left
+	n.left = left.right
+	left.right = n
+
+	n.update()
+	left.update()
+
+	return left
+}
+
+func (n *treeNode[K, V]) rotateLeft() *treeNode[K, V] {
+	right := n.right
+	n.right = right.left
+	right.left = n
+
+	n.update()
+	right.update()
+
+	return right
+}
+
+func (n *treeNode[K, V]) balance() *treeNode[K, V] {
+	n.update()
+
+	switch factor := n.left.getHeight() - n.right.getHeight(); {
+	case factor > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+
+		return n.rotateRight()
+	case factor < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+
+		return n.rotateLeft()
+	}
+
+	return n
+}
+
+func (n *treeNode[K, V]) insert(node *Node[K, V]) *treeNode[K, V] {
+	if n == nil {
+		return &treeNode[K, V]{hash: node.hash, nodes: []*Node[K, V]{node}, height: 1}
+	}
+
+	switch {
+	case node.hash < n.hash:
+		n.left = n.left.insert(node)
+	case node.hash > n.hash:
+		n.right = n.right.insert(node)
+	default:
+		n.nodes = append(n.nodes, node)
+		return n
+	}
+
+	return n.balance()
+}
+
+func (n *treeNode[K, V]) remove(node *Node[K, V]) *treeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	switch {
+	case node.hash < n.hash:
+		n.left = n.left.remove(node)
+	case node.hash > n.hash:
+		n.right = n.right.remove(node)
+	default:
+		for i, candidate := range n.nodes {
+			if candidate == node {
+				n.nodes = append(n.nodes[:i], n.nodes[i+1:]...)
+				break
+			}
+		}
+
+		if len(n.nodes) > 0 {
+			return n
+		}
+
+		if n.left == nil {
+			return n.right
+		}
+
+		if n.right == nil {
+			return n.left
+		}
+
+		// Replace with the smallest hash of the right subtree
+		successor := n.right
+
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		n.hash = successor.hash
+		n.nodes = successor.nodes
+		n.right = n.right.removeMin()
+	}
+
+	return n.balance()
+}
+
+func (n *treeNode[K, V]) removeMin() *treeNode[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+
+	n.left = n.left.removeMin()
+
+	return n.balance()
+}
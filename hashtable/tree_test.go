@@ -0,0 +1,107 @@
+package hashtable
+
+import "testing"
+
+// sameBucketHasher gives every key a distinct hash that still maps to
+// bucket 0 for any power of two length up to 2^32
+type sameBucketHasher struct{}
+
+func (sameBucketHasher) Hash(key int) uint64 {
+	return uint64(key) << 32
+}
+
+func treeHeight[K comparable, V any](n *treeNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+
+	return 1 + max(treeHeight(n.left), treeHeight(n.right))
+}
+
+func TestLongChainsAreTreeified(t *testing.T) {
+	hashTable := NewHashTableWithOptions[int, int](WithHasher[int](sameBucketHasher{}))
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	tree := hashTable.trees[0]
+
+	if tree == nil || tree.size != 1000 {
+		t.Fatalf("Expected bucket 0 to hold a tree of 1000 nodes, got %+v", tree)
+	}
+
+	if height := treeHeight(tree.root); height > 15 {
+		t.Errorf("Expected a balanced tree, got height %d", height)
+	}
+
+	for i := 0; i < 1000; i++ {
+		hashTable.Insert(i, i*2)
+	}
+
+	if hashTable.Size() != 1000 {
+		t.Errorf("Expected overwrites to keep size 1000, got %d", hashTable.Size())
+	}
+
+	for i := 0; i < 1000; i++ {
+		if value := hashTable.Get(i); value != i*2 {
+			t.Errorf("Expected value to be %d, got %d", i*2, value)
+		}
+	}
+
+	if stats := hashTable.Stats(); stats.Trees != 1 || stats.MaxChain != 1000 {
+		t.Errorf("Expected one tree over a chain of 1000, got %+v", stats)
+	}
+}
+
+func TestTreeShrinksBackToChain(t *testing.T) {
+	hashTable := NewHashTableWithOptions[int, int](WithHasher[int](sameBucketHasher{}))
+
+	for i := 0; i < 100; i++ {
+		hashTable.Insert(i, i)
+	}
+
+	for i := 0; i < 95; i++ {
+		if _, found := hashTable.Delete(i); !found {
+			t.Errorf("Expected %d to be deleted", i)
+		}
+
+		if hashTable.Contains(i) {
+			t.Errorf("Expected %d to be gone", i)
+		}
+	}
+
+	if hashTable.trees[0] != nil {
+		t.Errorf("Expected the tree to be dropped under %d nodes", untreeifyThreshold)
+	}
+
+	for i := 95; i < 100; i++ {
+		if value := hashTable.Get(i); value != i {
+			t.Errorf("Expected value to be %d, got %d", i, value)
+		}
+	}
+}
+
+func TestTreeWithIdenticalHashes(t *testing.T) {
+	hashTable := NewHashTableWithOptions[string, string](WithHasher[string](constantHasher[string]{}))
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	for _, key := range keys {
+		hashTable.Insert(key, key)
+	}
+
+	hashTable.Delete("e")
+
+	for _, key := range keys {
+		if value, found := hashTable.TryGet(key); found != (key != "e") || (found && value != key) {
+			t.Errorf("Expected %s to be found %v, got %s (found %v)", key, key != "e", value, found)
+		}
+	}
+
+	clone := hashTable.Clone()
+	clone.Insert("k", "k")
+
+	if clone.trees[0] == nil || hashTable.Contains("k") || clone.Get("k") != "k" {
+		t.Errorf("Expected the clone to have its own tree")
+	}
+}
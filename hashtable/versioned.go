@@ -0,0 +1,254 @@
+package hashtable
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const versionPageSize = 32
+
+type versionPage[K comparable, V any] [versionPageSize]*Node[K, V]
+
+// Published versions are never mutated: writers copy the page directory, the
+// touched page and the prefix of the touched chain, then swap the pointer.
+type version[K comparable, V any] struct {
+	number       uint64
+	pages        []*versionPage[K, V]
+	bucketLength uint32
+	size         uint32
+}
+
+type VersionedHashTable[K comparable, V any] struct {
+	mutex   sync.Mutex
+	current atomic.Pointer[version[K, V]]
+	hasher  Hasher[K]
+}
+
+type Snapshot[K comparable, V any] struct {
+	version *version[K, V]
+	hasher  Hasher[K]
+}
+
+func NewVersionedHashTable[K comparable, V any]() *VersionedHashTable[K, V] {
+	table := VersionedHashTable[K, V]{
+		hasher: newDefaultHasher[K](),
+	}
+	table.current.Store(newVersion[K, V](0, versionPageSize))
+
+	return &table
+}
+
+func newVersion[K comparable, V any](number uint64, bucketLength uint32) *version[K, V] {
+	pages := make([]*versionPage[K, V], bucketLength/versionPageSize)
+
+	for i := range pages {
+		pages[i] = &versionPage[K, V]{}
+	}
+
+	return &version[K, V]{
+		number:       number,
+		pages:        pages,
+		bucketLength: bucketLength,
+	}
+}
+
+func (v *version[K, V]) bucket(index uint32) *Node[K, V] {
+	return v.pages[index/versionPageSize][index%versionPageSize]
+}
+
+func (t *VersionedHashTable[K, V]) Snapshot() *Snapshot[K, V] {
+	return &Snapshot[K, V]{version: t.current.Load(), hasher: t.hasher}
+}
+
+func (t *VersionedHashTable[K, V]) Version() uint64 {
+	return t.current.Load().number
+}
+
+func (t *VersionedHashTable[K, V]) Get(key K) (V, bool) {
+	return t.Snapshot().Get(key)
+}
+
+func (t *VersionedHashTable[K, V]) Size() uint32 {
+	return t.current.Load().size
+}
+
+func (t *VersionedHashTable[K, V]) Insert(key K, value V) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	old := t.current.Load()
+	hash := t.hasher.Hash(key)
+
+	next := old.copyBucket(hash)
+	index := uint32(hash % uint64(next.bucketLength))
+	page := next.pages[index/versionPageSize]
+
+	newNode := &Node[K, V]{
+		hash:  hash,
+		entry: Entry[K, V]{Key: key, Value: value},
+	}
+
+	head, replaced := replaceInChain(page[index%versionPageSize], key, hash, newNode)
+
+	if !replaced {
+		newNode.next = page[index%versionPageSize]
+		head = newNode
+		next.size++
+	}
+
+	page[index%versionPageSize] = head
+
+	if next.size > next.bucketLength {
+		next = next.grow()
+	}
+
+	t.current.Store(next)
+}
+
+func (t *VersionedHashTable[K, V]) Delete(key K) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	old := t.current.Load()
+	hash := t.hasher.Hash(key)
+
+	if _, found := old.lookup(key, hash); !found {
+		return false
+	}
+
+	next := old.copyBucket(hash)
+	index := uint32(hash % uint64(next.bucketLength))
+	page := next.pages[index/versionPageSize]
+
+	page[index%versionPageSize], _ = replaceInChain(page[index%versionPageSize], key, hash, nil)
+	next.size--
+
+	t.current.Store(next)
+
+	return true
+}
+
+// copyBucket returns a new version sharing every page but the one holding hash
+func (v *version[K, V]) copyBucket(hash uint64) *version[K, V] {
+	index := uint32(hash % uint64(v.bucketLength))
+
+	next := &version[K, V]{
+		number:       v.number + 1,
+		pages:        make([]*versionPage[K, V], len(v.pages)),
+		bucketLength: v.bucketLength,
+		size:         v.size,
+	}
+
+	copy(next.pages, v.pages)
+
+	page := *v.pages[index/versionPageSize]
+	next.pages[index/versionPageSize] = &page
+
+	return next
+}
+
+// replaceInChain copies the nodes preceding the node with hash and links the
+// copy to replacement, or to the rest of the chain when replacement is nil
+func replaceInChain[K comparable, V any](head *Node[K, V], key K, hash uint64, replacement *Node[K, V]) (*Node[K, V], bool) {
+	if head == nil {
+		return nil, false
+	}
+
+	if head.hash == hash && head.entry.Key == key {
+		if replacement == nil {
+			return head.next, true
+		}
+
+		replacement.next = head.next
+
+		return replacement, true
+	}
+
+	rest, replaced := replaceInChain(head.next, key, hash, replacement)
+
+	if !replaced {
+		return head, false
+	}
+
+	return &Node[K, V]{
+		entry: head.entry,
+		hash:  head.hash,
+		next:  rest,
+	}, true
+}
+
+func (v *version[K, V]) grow() *version[K, V] {
+	next := newVersion[K, V](v.number, v.bucketLength<<1)
+	next.size = v.size
+
+	for _, page := range v.pages {
+		for _, node := range page {
+			for ; node != nil; node = node.next {
+				index := uint32(node.hash % uint64(next.bucketLength))
+				target := next.pages[index/versionPageSize]
+
+				target[index%versionPageSize] = &Node[K, V]{
+					entry: node.entry,
+					hash:  node.hash,
+					next:  target[index%versionPageSize],
+				}
+			}
+		}
+	}
+
+	return next
+}
+
+func (v *version[K, V]) lookup(key K, hash uint64) (value V, found bool) {
+	index := uint32(hash % uint64(v.bucketLength))
+
+	for node := v.bucket(index); node != nil; node = node.next {
+		if node.hash == hash && node.entry.Key == key {
+			return node.entry.Value, true
+		}
+	}
+
+	return
+}
+
+func (s *Snapshot[K, V]) Version() uint64 {
+	return s.version.number
+}
+
+func (s *Snapshot[K, V]) Size() uint32 {
+	return s.version.size
+}
+
+func (s *Snapshot[K, V]) Get(key K) (V, bool) {
+	return s.version.lookup(key, s.hasher.Hash(key))
+}
+
+func (s *Snapshot[K, V]) Range(f func(Entry[K, V]) bool) {
+	for _, page := range s.version.pages {
+		for _, node := range page {
+			for ; node != nil; node = node.next {
+				if !f(node.entry) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Snapshot[K, V]) Iter() <-chan Entry[K, V] {
+	iterator := make(chan Entry[K, V])
+
+	go func() {
+		for _, page := range s.version.pages {
+			for _, node := range page {
+				for ; node != nil; node = node.next {
+					iterator <- node.entry
+				}
+			}
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
@@ -0,0 +1,134 @@
+package hashtable
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestVersionedInsertAndGet(t *testing.T) {
+	table := NewVersionedHashTable[string, int]()
+
+	for i := 0; i < 1000; i++ {
+		table.Insert(fmt.Sprint(i), i)
+	}
+
+	table.Insert("10", -10)
+
+	if table.Size() != 1000 {
+		t.Errorf("Expected size to be 1000, got %d", table.Size())
+	}
+
+	for i := 0; i < 1000; i++ {
+		expected := i
+		if i == 10 {
+			expected = -10
+		}
+
+		if value, ok := table.Get(fmt.Sprint(i)); !ok || value != expected {
+			t.Fatalf("Expected value to be %d, got %d", expected, value)
+		}
+	}
+}
+
+func TestSnapshotIsIsolatedFromWrites(t *testing.T) {
+	table := NewVersionedHashTable[string, string]()
+
+	table.Insert("foo", "bar")
+	table.Insert("baz", "qux")
+
+	snapshot := table.Snapshot()
+
+	table.Insert("foo", "changed")
+	table.Delete("baz")
+
+	for i := 0; i < 100; i++ {
+		table.Insert(fmt.Sprint(i), "new")
+	}
+
+	if value, _ := snapshot.Get("foo"); value != "bar" {
+		t.Errorf("Expected value to be 'bar', got %s", value)
+	}
+
+	if _, ok := snapshot.Get("baz"); !ok {
+		t.Errorf("Expected 'baz' to still be visible in the snapshot")
+	}
+
+	if snapshot.Size() != 2 {
+		t.Errorf("Expected snapshot size to be 2, got %d", snapshot.Size())
+	}
+
+	counter := 0
+	for range snapshot.Iter() {
+		counter++
+	}
+
+	if counter != 2 {
+		t.Errorf("Expected counter to be 2, got %d", counter)
+	}
+
+	if value, _ := table.Get("foo"); value != "changed" {
+		t.Errorf("Expected value to be 'changed', got %s", value)
+	}
+
+	if snapshot.Version() >= table.Version() {
+		t.Errorf("Expected snapshot version to be older than %d, got %d", table.Version(), snapshot.Version())
+	}
+}
+
+func TestVersionedDelete(t *testing.T) {
+	table := NewVersionedHashTable[int, int]()
+
+	for i := 0; i < 100; i++ {
+		table.Insert(i, i)
+	}
+
+	if !table.Delete(50) {
+		t.Errorf("Expected key 50 to be deleted")
+	}
+
+	if table.Delete(50) {
+		t.Errorf("Expected second delete to report nothing removed")
+	}
+
+	if _, ok := table.Get(50); ok {
+		t.Errorf("Expected key 50 to be missing")
+	}
+
+	if table.Size() != 99 {
+		t.Errorf("Expected size to be 99, got %d", table.Size())
+	}
+}
+
+func TestVersionedConcurrentReaders(t *testing.T) {
+	table := NewVersionedHashTable[int, int]()
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 200; j++ {
+				snapshot := table.Snapshot()
+				counter := uint32(0)
+
+				for range snapshot.Iter() {
+					counter++
+				}
+
+				if counter != snapshot.Size() {
+					t.Errorf("Expected snapshot to hold %d entries, got %d", snapshot.Size(), counter)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 500; i++ {
+		table.Insert(i, i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,204 @@
+package heap
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"algorithms/iterator"
+)
+
+var ErrEmpty = errors.New("heap: empty heap")
+
+// Heap is a binary heap popping the element that sorts first under less.
+// As an iterator.Iterator it visits the elements in heap order without
+// removing them; Drain and DrainIter remove them in priority order.
+type Heap[E any] struct {
+	elements []E
+	less     func(a, b E) bool
+}
+
+func NewHeap[E any](less func(a, b E) bool) *Heap[E] {
+	return &Heap[E]{
+		elements: make([]E, 0),
+		less:     less,
+	}
+}
+
+func NewMinHeap[E cmp.Ordered]() *Heap[E] {
+	return NewHeap(cmp.Less[E])
+}
+
+func NewMaxHeap[E cmp.Ordered]() *Heap[E] {
+	return NewHeap(func(a, b E) bool {
+		return cmp.Less(b, a)
+	})
+}
+
+func (h *Heap[E]) Push(element E) {
+	h.elements = append(h.elements, element)
+	h.up(len(h.elements) - 1)
+}
+
+func (h *Heap[E]) Pop() (E, error) {
+	var zero E
+
+	if len(h.elements) == 0 {
+		return zero, ErrEmpty
+	}
+
+	top := h.elements[0]
+	last := len(h.elements) - 1
+
+	h.elements[0] = h.elements[last]
+	h.elements[last] = zero
+	h.elements = h.elements[:last]
+	h.down(0)
+
+	return top, nil
+}
+
+func (h *Heap[E]) Peek() (E, error) {
+	if len(h.elements) == 0 {
+		var zero E
+		return zero, ErrEmpty
+	}
+
+	return h.elements[0], nil
+}
+
+func (h *Heap[E]) Len() int {
+	return len(h.elements)
+}
+
+func (h *Heap[E]) IsEmpty() bool {
+	return len(h.elements) == 0
+}
+
+func (h *Heap[E]) Clear() {
+	clear(h.elements)
+	h.elements = h.elements[:0]
+}
+
+func (h *Heap[E]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+
+		if !h.less(h.elements[i], h.elements[parent]) {
+			return
+		}
+
+		h.elements[i], h.elements[parent] = h.elements[parent], h.elements[i]
+		i = parent
+	}
+}
+
+func (h *Heap[E]) down(i int) {
+	for {
+		smallest, left, right := i, 2*i+1, 2*i+2
+
+		if left < len(h.elements) && h.less(h.elements[left], h.elements[smallest]) {
+			smallest = left
+		}
+
+		if right < len(h.elements) && h.less(h.elements[right], h.elements[smallest]) {
+			smallest = right
+		}
+
+		if smallest == i {
+			return
+		}
+
+		h.elements[i], h.elements[smallest] = h.elements[smallest], h.elements[i]
+		i = smallest
+	}
+}
+
+// Iter yields a snapshot of the elements in heap order, taken when it is
+// called, so the heap may change while it is read
+func (h *Heap[E]) Iter() <-chan E {
+	elements := slices.Clone(h.elements)
+	iterator := make(chan E)
+
+	go func() {
+		for _, element := range elements {
+			iterator <- element
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func (h *Heap[E]) Map(f func(E) interface{}) iterator.Collection[interface{}] {
+	collection := iterator.NewList[interface{}]()
+
+	h.Range(func(element E) bool {
+		collection.Append(f(element))
+		return true
+	})
+
+	return collection
+}
+
+func (h *Heap[E]) Filter(f func(E) bool) iterator.Collection[E] {
+	collection := iterator.NewList[E]()
+
+	h.Range(func(element E) bool {
+		if f(element) {
+			collection.Append(element)
+		}
+
+		return true
+	})
+
+	return collection
+}
+
+func (h *Heap[E]) ForEach(f func(E)) {
+	h.Range(func(element E) bool {
+		f(element)
+		return true
+	})
+}
+
+// Range calls f on every element in heap order until it returns false, so
+// f must not change the heap
+func (h *Heap[E]) Range(f func(E) bool) {
+	for _, element := range h.elements {
+		if !f(element) {
+			return
+		}
+	}
+}
+
+// Drain pops the elements in priority order, handing each to f until it
+// returns false. The elements f has not received stay in the heap.
+func (h *Heap[E]) Drain(f func(E) bool) {
+	for len(h.elements) > 0 {
+		element, _ := h.Pop()
+
+		if !f(element) {
+			return
+		}
+	}
+}
+
+// DrainIter pops the elements in priority order as they are received. The
+// heap must not be used until the channel is closed, and it must be read to
+// the end: an element popped for a receiver that went away is lost.
+func (h *Heap[E]) DrainIter() <-chan E {
+	iterator := make(chan E)
+
+	go func() {
+		h.Drain(func(element E) bool {
+			iterator <- element
+			return true
+		})
+
+		close(iterator)
+	}()
+
+	return iterator
+}
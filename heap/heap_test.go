@@ -0,0 +1,131 @@
+package heap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"algorithms/iterator"
+)
+
+var _ iterator.Iterator[int] = (*Heap[int])(nil)
+
+func TestPushPop(t *testing.T) {
+	h := NewMinHeap[int]()
+	random := rand.New(rand.NewSource(1))
+	expected := make([]int, 0)
+
+	for i := 0; i < 1000; i++ {
+		value := random.Intn(100)
+		h.Push(value)
+		expected = append(expected, value)
+	}
+
+	sort.Ints(expected)
+
+	if top, _ := h.Peek(); top != expected[0] || h.Len() != 1000 {
+		t.Errorf("Expected top to be %d, got %d", expected[0], top)
+	}
+
+	for i, value := range expected {
+		if popped, err := h.Pop(); err != nil || popped != value {
+			t.Fatalf("Expected element %d to be %d, got %d", i, value, popped)
+		}
+	}
+
+	if _, err := h.Pop(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	if _, err := h.Peek(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestCustomOrder(t *testing.T) {
+	h := NewHeap(func(a, b string) bool {
+		return len(a) < len(b)
+	})
+
+	for _, word := range []string{"ccc", "a", "bb"} {
+		h.Push(word)
+	}
+
+	if top, _ := h.Pop(); top != "a" {
+		t.Errorf("Expected 'a', got %s", top)
+	}
+
+	max := NewMaxHeap[int]()
+	max.Push(1)
+	max.Push(3)
+	max.Push(2)
+
+	if top, _ := max.Pop(); top != 3 {
+		t.Errorf("Expected 3, got %d", top)
+	}
+}
+
+func TestIterDoesNotConsume(t *testing.T) {
+	h := NewMinHeap[int]()
+
+	for _, value := range []int{5, 3, 8, 1} {
+		h.Push(value)
+	}
+
+	seen := make([]int, 0)
+
+	for value := range h.Iter() {
+		seen = append(seen, value)
+	}
+
+	sort.Ints(seen)
+
+	if fmt.Sprint(seen) != "[1 3 5 8]" || h.Len() != 4 {
+		t.Errorf("Expected [1 3 5 8] and 4 elements left, got %v and %d", seen, h.Len())
+	}
+
+	if evens := h.Filter(func(value int) bool { return value%2 == 0 }); evens.Size() != 1 {
+		t.Errorf("Expected 1 even element, got %d", evens.Size())
+	}
+
+	if doubled := h.Map(func(value int) interface{} { return 2 * value }); doubled.Size() != 4 {
+		t.Errorf("Expected 4 mapped elements, got %d", doubled.Size())
+	}
+
+	sum := 0
+	h.ForEach(func(value int) { sum += value })
+
+	if sum != 17 {
+		t.Errorf("Expected sum to be 17, got %d", sum)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	h := NewMinHeap[int]()
+
+	for _, value := range []int{5, 3, 8, 1, 9} {
+		h.Push(value)
+	}
+
+	drained := make([]int, 0)
+
+	h.Drain(func(value int) bool {
+		drained = append(drained, value)
+		return len(drained) < 2
+	})
+
+	if fmt.Sprint(drained) != "[1 3]" || h.Len() != 3 {
+		t.Errorf("Expected [1 3] and 3 elements left, got %v and %d", drained, h.Len())
+	}
+
+	drained = drained[:0]
+
+	for value := range h.DrainIter() {
+		drained = append(drained, value)
+	}
+
+	if fmt.Sprint(drained) != "[5 8 9]" || !h.IsEmpty() {
+		t.Errorf("Expected [5 8 9] and an empty heap, got %v and %d", drained, h.Len())
+	}
+}
@@ -0,0 +1,202 @@
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// HDR records integer values (typically latencies in a fixed unit) with a
+// constant relative precision across the whole trackable range, using the
+// sub-bucket layout of HdrHistogram.
+type HDR struct {
+	lowest                      int64
+	highest                     int64
+	unitMagnitude               int
+	subBucketHalfCountMagnitude int
+	subBucketHalfCount          int
+	subBucketMask               int64
+	counts                      []uint64
+	count                       uint64
+	min                         int64
+	max                         int64
+}
+
+func NewHDR(lowest, highest int64, significantFigures int) *HDR {
+	if lowest < 1 || highest < 2*lowest || significantFigures < 1 || significantFigures > 5 {
+		msg := fmt.Sprintf("invalid HDR parameters: lowest %d, highest %d, figures %d", lowest, highest, significantFigures)
+		panic(errors.New(msg))
+	}
+
+	largestSingleUnitResolution := 2 * int64(math.Pow10(significantFigures))
+	subBucketCountMagnitude := bits.Len64(uint64(largestSingleUnitResolution - 1))
+
+	h := HDR{
+		lowest:                      lowest,
+		highest:                     highest,
+		unitMagnitude:               bits.Len64(uint64(lowest)) - 1,
+		subBucketHalfCountMagnitude: subBucketCountMagnitude - 1,
+		min:                         math.MaxInt64,
+	}
+
+	subBucketCount := 1 << subBucketCountMagnitude
+	h.subBucketHalfCount = subBucketCount / 2
+	h.subBucketMask = int64(subBucketCount-1) << h.unitMagnitude
+
+	smallestUntrackable := int64(subBucketCount) << h.unitMagnitude
+	bucketCount := 1
+
+	for smallestUntrackable <= highest {
+		if smallestUntrackable > math.MaxInt64/2 {
+			bucketCount++
+			break
+		}
+
+		smallestUntrackable <<= 1
+		bucketCount++
+	}
+
+	h.counts = make([]uint64, (bucketCount+1)*h.subBucketHalfCount)
+
+	return &h
+}
+
+func (h *HDR) countsIndex(value int64) int {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(value|h.subBucketMask))
+	bucketIndex := pow2Ceiling - h.unitMagnitude - (h.subBucketHalfCountMagnitude + 1)
+	subBucketIndex := int(value >> (bucketIndex + h.unitMagnitude))
+
+	return (bucketIndex+1)<<h.subBucketHalfCountMagnitude + subBucketIndex - h.subBucketHalfCount
+}
+
+func (h *HDR) valueFromIndex(index int) (lowest, highest int64) {
+	bucketIndex := (index >> h.subBucketHalfCountMagnitude) - 1
+	subBucketIndex := (index & (h.subBucketHalfCount - 1)) + h.subBucketHalfCount
+
+	if bucketIndex < 0 {
+		subBucketIndex -= h.subBucketHalfCount
+		bucketIndex = 0
+	}
+
+	lowest = int64(subBucketIndex) << (bucketIndex + h.unitMagnitude)
+	highest = lowest + int64(1)<<(bucketIndex+h.unitMagnitude) - 1
+
+	return
+}
+
+func (h *HDR) Record(value int64) error {
+	return h.RecordN(value, 1)
+}
+
+func (h *HDR) RecordN(value int64, n uint64) error {
+	if value < 0 || value > h.highest {
+		msg := fmt.Sprintf("value out of range: %d", value)
+		return errors.New(msg)
+	}
+
+	h.counts[h.countsIndex(value)] += n
+	h.count += n
+
+	if value < h.min {
+		h.min = value
+	}
+
+	if value > h.max {
+		h.max = value
+	}
+
+	return nil
+}
+
+func (h *HDR) Count() uint64 {
+	return h.count
+}
+
+func (h *HDR) Min() int64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.min
+}
+
+func (h *HDR) Max() int64 {
+	return h.max
+}
+
+func (h *HDR) Mean() float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	total := 0.0
+
+	for i, count := range h.counts {
+		if count > 0 {
+			lowest, highest := h.valueFromIndex(i)
+			total += float64(count) * float64(lowest+highest) / 2
+		}
+	}
+
+	return total / float64(h.count)
+}
+
+// Percentile returns the highest value equivalent to the p-th percentile
+// sample within the configured precision, clamped to the observed max.
+func (h *HDR) Percentile(p float64) int64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := percentileRank(p, h.count)
+	var cumulative uint64
+
+	for i, count := range h.counts {
+		cumulative += count
+
+		if cumulative >= rank {
+			_, highest := h.valueFromIndex(i)
+
+			if highest > h.max {
+				return h.max
+			}
+
+			return highest
+		}
+	}
+
+	return h.max
+}
+
+func (h *HDR) Merge(other *HDR) error {
+	if h.unitMagnitude != other.unitMagnitude || h.subBucketHalfCount != other.subBucketHalfCount {
+		return errors.New("cannot merge HDR histograms with different precision")
+	}
+
+	for i := len(h.counts); i < len(other.counts); i++ {
+		if other.counts[i] > 0 {
+			lowest, _ := other.valueFromIndex(i)
+			msg := fmt.Sprintf("value out of range: %d", lowest)
+			return errors.New(msg)
+		}
+	}
+
+	for i := range h.counts {
+		if i < len(other.counts) {
+			h.counts[i] += other.counts[i]
+		}
+	}
+
+	h.count += other.count
+
+	if other.count > 0 && other.min < h.min {
+		h.min = other.min
+	}
+
+	if other.max > h.max {
+		h.max = other.max
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Histogram counts float samples into buckets defined by ascending upper
+// bounds, with an extra overflow bucket for samples above the last bound.
+type Histogram struct {
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+	min    float64
+	max    float64
+}
+
+func NewHistogram(bounds []float64) *Histogram {
+	if len(bounds) == 0 || !sort.Float64sAreSorted(bounds) {
+		panic(errors.New("bounds must be non-empty and sorted"))
+	}
+
+	return &Histogram{
+		bounds: append([]float64(nil), bounds...),
+		counts: make([]uint64, len(bounds)+1),
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+	}
+}
+
+func LinearBounds(start, width float64, count int) []float64 {
+	bounds := make([]float64, count)
+
+	for i := range bounds {
+		bounds[i] = start + width*float64(i)
+	}
+
+	return bounds
+}
+
+func ExponentialBounds(start, factor float64, count int) []float64 {
+	if start <= 0 || factor <= 1 {
+		msg := fmt.Sprintf("invalid exponential bounds: start %f, factor %f", start, factor)
+		panic(errors.New(msg))
+	}
+
+	bounds := make([]float64, count)
+
+	for i := range bounds {
+		bounds[i] = start
+		start *= factor
+	}
+
+	return bounds
+}
+
+func (h *Histogram) Record(value float64) {
+	h.RecordN(value, 1)
+}
+
+func (h *Histogram) RecordN(value float64, n uint64) {
+	index := sort.SearchFloat64s(h.bounds, value)
+
+	h.counts[index] += n
+	h.count += n
+	h.sum += value * float64(n)
+	h.min = math.Min(h.min, value)
+	h.max = math.Max(h.max, value)
+}
+
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+func (h *Histogram) Sum() float64 {
+	return h.sum
+}
+
+func (h *Histogram) Mean() float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.sum / float64(h.count)
+}
+
+func (h *Histogram) Min() float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.min
+}
+
+func (h *Histogram) Max() float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.max
+}
+
+// Percentile returns the upper bound of the bucket holding the p-th
+// percentile sample, clamped to the observed min and max.
+func (h *Histogram) Percentile(p float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := percentileRank(p, h.count)
+	var cumulative uint64
+
+	for i, count := range h.counts {
+		cumulative += count
+
+		if cumulative >= rank {
+			if i == len(h.bounds) {
+				return h.max
+			}
+
+			return math.Max(h.min, math.Min(h.bounds[i], h.max))
+		}
+	}
+
+	return h.max
+}
+
+func (h *Histogram) Merge(other *Histogram) error {
+	if len(h.bounds) != len(other.bounds) {
+		return errors.New("cannot merge histograms with different bounds")
+	}
+
+	for i := range h.bounds {
+		if h.bounds[i] != other.bounds[i] {
+			return errors.New("cannot merge histograms with different bounds")
+		}
+	}
+
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+
+	h.count += other.count
+	h.sum += other.sum
+	h.min = math.Min(h.min, other.min)
+	h.max = math.Max(h.max, other.max)
+
+	return nil
+}
+
+func (h *Histogram) Reset() {
+	for i := range h.counts {
+		h.counts[i] = 0
+	}
+
+	h.count = 0
+	h.sum = 0
+	h.min = math.Inf(1)
+	h.max = math.Inf(-1)
+}
+
+func percentileRank(p float64, count uint64) uint64 {
+	p = math.Max(0, math.Min(100, p))
+	rank := uint64(math.Ceil(p / 100 * float64(count)))
+
+	if rank == 0 {
+		rank = 1
+	}
+
+	return rank
+}
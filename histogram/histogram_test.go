@@ -0,0 +1,133 @@
+package histogram
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearHistogramPercentiles(t *testing.T) {
+	h := NewHistogram(LinearBounds(10, 10, 10))
+
+	for i := 1; i <= 100; i++ {
+		h.Record(float64(i))
+	}
+
+	if h.Count() != 100 {
+		t.Errorf("Expected count to be 100, got %d", h.Count())
+	}
+
+	if p := h.Percentile(50); p != 50 {
+		t.Errorf("Expected p50 to be 50, got %f", p)
+	}
+
+	if p := h.Percentile(99); p != 100 {
+		t.Errorf("Expected p99 to be 100, got %f", p)
+	}
+
+	if h.Mean() != 50.5 {
+		t.Errorf("Expected mean to be 50.5, got %f", h.Mean())
+	}
+}
+
+func TestExponentialHistogramOverflow(t *testing.T) {
+	h := NewHistogram(ExponentialBounds(1, 2, 4))
+
+	h.Record(0.5)
+	h.Record(3)
+	h.Record(1000)
+
+	if p := h.Percentile(100); p != 1000 {
+		t.Errorf("Expected p100 to be the max 1000, got %f", p)
+	}
+
+	if p := h.Percentile(1); p != 1 {
+		t.Errorf("Expected p1 to be the first bound 1, got %f", p)
+	}
+
+	if h.Min() != 0.5 {
+		t.Errorf("Expected min to be 0.5, got %f", h.Min())
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	a := NewHistogram(LinearBounds(1, 1, 5))
+	b := NewHistogram(LinearBounds(1, 1, 5))
+
+	a.Record(1)
+	b.Record(5)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if a.Count() != 2 || a.Max() != 5 {
+		t.Errorf("Expected 2 samples with max 5, got %d with max %f", a.Count(), a.Max())
+	}
+
+	if err := a.Merge(NewHistogram(LinearBounds(0, 2, 5))); err == nil {
+		t.Errorf("Expected error when merging different bounds")
+	}
+}
+
+func TestHDRPercentilesWithinPrecision(t *testing.T) {
+	h := NewHDR(1, 3_600_000_000, 3)
+
+	for i := int64(1); i <= 1_000_000; i++ {
+		h.Record(i)
+	}
+
+	for _, p := range []float64{50, 90, 99, 99.9} {
+		expected := p / 100 * 1_000_000
+		actual := float64(h.Percentile(p))
+
+		if math.Abs(actual-expected)/expected > 0.001 {
+			t.Errorf("Expected p%v to be within 0.1%% of %f, got %f", p, expected, actual)
+		}
+	}
+
+	if h.Percentile(100) != 1_000_000 {
+		t.Errorf("Expected p100 to be 1000000, got %d", h.Percentile(100))
+	}
+
+	if h.Min() != 1 {
+		t.Errorf("Expected min to be 1, got %d", h.Min())
+	}
+}
+
+func TestHDRRejectsOutOfRangeValues(t *testing.T) {
+	h := NewHDR(1, 1000, 2)
+
+	if err := h.Record(5000); err == nil {
+		t.Errorf("Expected error for out of range value")
+	}
+
+	if err := h.Record(-1); err == nil {
+		t.Errorf("Expected error for negative value")
+	}
+}
+
+func TestHDRMerge(t *testing.T) {
+	a := NewHDR(1, 1_000_000, 3)
+	b := NewHDR(1, 1_000_000, 3)
+
+	a.Record(100)
+	b.RecordN(900_000, 3)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if a.Count() != 4 {
+		t.Errorf("Expected count to be 4, got %d", a.Count())
+	}
+
+	if p := a.Percentile(50); math.Abs(float64(p)-900_000) > 900 {
+		t.Errorf("Expected p50 to be close to 900000, got %d", p)
+	}
+
+	small := NewHDR(1, 1000, 3)
+
+	if err := small.Merge(b); err == nil {
+		t.Errorf("Expected error when merging values out of range")
+	}
+}
@@ -0,0 +1,32 @@
+package hnsw
+
+import "math"
+
+type Distance func(a, b []float32) float32
+
+func SquaredEuclidean(a, b []float32) float32 {
+	var sum float32
+
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+
+	return sum
+}
+
+func Cosine(a, b []float32) float32 {
+	var dot, normA, normB float32
+
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 1
+	}
+
+	return 1 - dot/float32(math.Sqrt(float64(normA))*math.Sqrt(float64(normB)))
+}
@@ -0,0 +1,44 @@
+package hnsw
+
+type candidate struct {
+	id       int
+	distance float32
+}
+
+// candidateHeap is a binary heap ordered nearest first, or farthest first
+// when farthest is set.
+type candidateHeap struct {
+	items    []candidate
+	farthest bool
+}
+
+func (h *candidateHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *candidateHeap) Less(i, j int) bool {
+	if h.farthest {
+		return h.items[i].distance > h.items[j].distance
+	}
+
+	return h.items[i].distance < h.items[j].distance
+}
+
+func (h *candidateHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *candidateHeap) Push(x any) {
+	h.items = append(h.items, x.(candidate))
+}
+
+func (h *candidateHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+
+	return last
+}
+
+func (h *candidateHeap) Top() candidate {
+	return h.items[0]
+}
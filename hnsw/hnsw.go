@@ -0,0 +1,303 @@
+package hnsw
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type Config struct {
+	// M is the number of neighbors kept per node on the upper layers; the
+	// bottom layer keeps twice as many
+	M              int
+	EfConstruction int
+	EfSearch       int
+	Distance       Distance
+	Seed           int64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		M:              16,
+		EfConstruction: 200,
+		EfSearch:       50,
+		Distance:       SquaredEuclidean,
+		Seed:           1,
+	}
+}
+
+type Result struct {
+	ID       int
+	Distance float32
+}
+
+type node struct {
+	vector    []float32
+	neighbors [][]int
+}
+
+// Index is a Hierarchical Navigable Small World graph for approximate
+// nearest neighbor search. IDs are assigned in insertion order.
+type Index struct {
+	config     Config
+	dimension  int
+	nodes      []node
+	entry      int
+	maxLevel   int
+	levelScale float64
+	random     *rand.Rand
+}
+
+func NewIndex(dimension int, config Config) *Index {
+	if dimension <= 0 || config.M < 2 || config.EfConstruction < 1 || config.EfSearch < 1 {
+		msg := fmt.Sprintf("invalid index parameters: dimension %d, %+v", dimension, config)
+		panic(errors.New(msg))
+	}
+
+	if config.Distance == nil {
+		config.Distance = SquaredEuclidean
+	}
+
+	return &Index{
+		config:     config,
+		dimension:  dimension,
+		entry:      -1,
+		levelScale: 1 / math.Log(float64(config.M)),
+		random:     rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+func (x *Index) Len() int {
+	return len(x.nodes)
+}
+
+func (x *Index) SetEfSearch(ef int) {
+	if ef < 1 {
+		ef = 1
+	}
+
+	x.config.EfSearch = ef
+}
+
+func (x *Index) Vector(id int) []float32 {
+	return x.nodes[id].vector
+}
+
+func (x *Index) Insert(vector []float32) (int, error) {
+	if len(vector) != x.dimension {
+		msg := fmt.Sprintf("vector dimension %d does not match index dimension %d", len(vector), x.dimension)
+		return 0, errors.New(msg)
+	}
+
+	id := len(x.nodes)
+	level := int(-math.Log(1-x.random.Float64()) * x.levelScale)
+
+	x.nodes = append(x.nodes, node{
+		vector:    append([]float32(nil), vector...),
+		neighbors: make([][]int, level+1),
+	})
+
+	if x.entry < 0 {
+		x.entry, x.maxLevel = id, level
+		return id, nil
+	}
+
+	entryPoints := []candidate{{x.entry, x.distance(vector, x.entry)}}
+
+	for l := x.maxLevel; l > level; l-- {
+		entryPoints = x.searchLayer(vector, entryPoints, 1, l)
+	}
+
+	for l := min(level, x.maxLevel); l >= 0; l-- {
+		found := x.searchLayer(vector, entryPoints, x.config.EfConstruction, l)
+		neighbors := x.selectNeighbors(found, x.config.M)
+
+		for _, neighbor := range neighbors {
+			x.nodes[id].neighbors[l] = append(x.nodes[id].neighbors[l], neighbor.id)
+			x.connect(neighbor.id, id, l)
+		}
+
+		entryPoints = found
+	}
+
+	if level > x.maxLevel {
+		x.entry, x.maxLevel = id, level
+	}
+
+	return id, nil
+}
+
+func (x *Index) Search(query []float32, k int) ([]Result, error) {
+	if len(query) != x.dimension {
+		msg := fmt.Sprintf("query dimension %d does not match index dimension %d", len(query), x.dimension)
+		return nil, errors.New(msg)
+	}
+
+	if x.entry < 0 || k <= 0 {
+		return []Result{}, nil
+	}
+
+	entryPoints := []candidate{{x.entry, x.distance(query, x.entry)}}
+
+	for l := x.maxLevel; l > 0; l-- {
+		entryPoints = x.searchLayer(query, entryPoints, 1, l)
+	}
+
+	found := x.searchLayer(query, entryPoints, max(x.config.EfSearch, k), 0)
+
+	results := make([]Result, 0, k)
+
+	for _, c := range found {
+		if len(results) == k {
+			break
+		}
+
+		results = append(results, Result{ID: c.id, Distance: c.distance})
+	}
+
+	return results, nil
+}
+
+func (x *Index) distance(vector []float32, id int) float32 {
+	return x.config.Distance(vector, x.nodes[id].vector)
+}
+
+func (x *Index) maxNeighbors(level int) int {
+	if level == 0 {
+		return 2 * x.config.M
+	}
+
+	return x.config.M
+}
+
+// searchLayer returns up to ef nodes closest to query on the level, nearest first
+func (x *Index) searchLayer(query []float32, entryPoints []candidate, ef int, level int) []candidate {
+	visited := make(map[int]bool, ef*4)
+	candidates := &candidateHeap{}
+	results := &candidateHeap{farthest: true}
+
+	for _, c := range entryPoints {
+		visited[c.id] = true
+		heap.Push(candidates, c)
+		heap.Push(results, c)
+
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		current := heap.Pop(candidates).(candidate)
+
+		if current.distance > results.Top().distance && results.Len() >= ef {
+			break
+		}
+
+		for _, neighbor := range x.nodes[current.id].neighbors[level] {
+			if visited[neighbor] {
+				continue
+			}
+
+			visited[neighbor] = true
+			d := x.distance(query, neighbor)
+
+			if results.Len() < ef || d < results.Top().distance {
+				heap.Push(candidates, candidate{neighbor, d})
+				heap.Push(results, candidate{neighbor, d})
+
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sort.Slice(results.items, func(i, j int) bool {
+		return results.items[i].distance < results.items[j].distance
+	})
+
+	return results.items
+}
+
+// selectNeighbors applies the diversity heuristic from the HNSW paper: a
+// candidate is kept only if it is closer to the new node than to every
+// neighbor kept so far, then the remaining slots are filled by distance.
+func (x *Index) selectNeighbors(candidates []candidate, m int) []candidate {
+	selected := make([]candidate, 0, m)
+	skipped := make([]candidate, 0)
+
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+
+		diverse := true
+
+		for _, s := range selected {
+			if x.config.Distance(x.nodes[c.id].vector, x.nodes[s.id].vector) < c.distance {
+				diverse = false
+				break
+			}
+		}
+
+		if diverse {
+			selected = append(selected, c)
+		} else {
+			skipped = append(skipped, c)
+		}
+	}
+
+	for _, c := range skipped {
+		if len(selected) == m {
+			break
+		}
+
+		selected = append(selected, c)
+	}
+
+	return selected
+}
+
+func (x *Index) connect(from, to, level int) {
+	neighbors := append(x.nodes[from].neighbors[level], to)
+
+	if len(neighbors) > x.maxNeighbors(level) {
+		candidates := make([]candidate, len(neighbors))
+
+		for i, neighbor := range neighbors {
+			candidates[i] = candidate{neighbor, x.distance(x.nodes[from].vector, neighbor)}
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].distance < candidates[j].distance
+		})
+
+		kept := x.selectNeighbors(candidates, x.maxNeighbors(level))
+		neighbors = neighbors[:0]
+
+		for _, c := range kept {
+			neighbors = append(neighbors, c.id)
+		}
+	}
+
+	x.nodes[from].neighbors[level] = neighbors
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
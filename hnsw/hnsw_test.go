@@ -0,0 +1,126 @@
+package hnsw
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomVectors(n, dimension int, seed int64) [][]float32 {
+	random := rand.New(rand.NewSource(seed))
+	vectors := make([][]float32, n)
+
+	for i := range vectors {
+		vectors[i] = make([]float32, dimension)
+
+		for j := range vectors[i] {
+			vectors[i][j] = random.Float32()
+		}
+	}
+
+	return vectors
+}
+
+func bruteForce(vectors [][]float32, query []float32, k int) map[int]bool {
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return SquaredEuclidean(vectors[ids[i]], query) < SquaredEuclidean(vectors[ids[j]], query)
+	})
+
+	nearest := make(map[int]bool, k)
+	for _, id := range ids[:k] {
+		nearest[id] = true
+	}
+
+	return nearest
+}
+
+func TestSearchRecall(t *testing.T) {
+	vectors := randomVectors(2000, 16, 1)
+	index := NewIndex(16, DefaultConfig())
+
+	for _, vector := range vectors {
+		index.Insert(vector)
+	}
+
+	queries := randomVectors(50, 16, 2)
+	hits := 0
+
+	for _, query := range queries {
+		expected := bruteForce(vectors, query, 10)
+		results, _ := index.Search(query, 10)
+
+		for _, result := range results {
+			if expected[result.ID] {
+				hits++
+			}
+		}
+	}
+
+	recall := float64(hits) / float64(len(queries)*10)
+
+	if recall < 0.9 {
+		t.Errorf("Expected recall to be at least 0.9, got %f", recall)
+	}
+}
+
+func TestSearchFindsExactMatchFirst(t *testing.T) {
+	vectors := randomVectors(500, 8, 3)
+	index := NewIndex(8, DefaultConfig())
+
+	for _, vector := range vectors {
+		index.Insert(vector)
+	}
+
+	results, _ := index.Search(vectors[42], 3)
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	if results[0].ID != 42 || results[0].Distance != 0 {
+		t.Errorf("Expected first result to be 42 at distance 0, got %d at %f", results[0].ID, results[0].Distance)
+	}
+
+	for i := 1; i < len(results); i++ {
+		if results[i].Distance < results[i-1].Distance {
+			t.Errorf("Expected results to be sorted by distance")
+		}
+	}
+}
+
+func TestDimensionMismatch(t *testing.T) {
+	index := NewIndex(3, DefaultConfig())
+
+	if _, err := index.Insert([]float32{1, 2}); err == nil {
+		t.Errorf("Expected error when inserting a vector of the wrong dimension")
+	}
+
+	if _, err := index.Search([]float32{1}, 1); err == nil {
+		t.Errorf("Expected error when searching with a vector of the wrong dimension")
+	}
+}
+
+func TestSearchEmptyIndex(t *testing.T) {
+	index := NewIndex(2, DefaultConfig())
+
+	results, err := index.Search([]float32{0, 0}, 5)
+
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected no results, got %v (err %v)", results, err)
+	}
+}
+
+func TestCosineDistance(t *testing.T) {
+	if d := Cosine([]float32{1, 0}, []float32{2, 0}); d > 1e-6 {
+		t.Errorf("Expected parallel vectors to have distance 0, got %f", d)
+	}
+
+	if d := Cosine([]float32{1, 0}, []float32{0, 1}); d < 0.999 || d > 1.001 {
+		t.Errorf("Expected orthogonal vectors to have distance 1, got %f", d)
+	}
+}
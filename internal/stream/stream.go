@@ -0,0 +1,109 @@
+// Package stream holds the format shared by the streaming encoders of the
+// probabilistic structures: three uvarint header fields followed by uint64
+// words in little endian, written and read a chunk at a time.
+package stream
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ChunkWords is how many words are buffered at a time
+const ChunkWords = 512
+
+// Write writes the header then the words without encoding them whole first
+func Write(w io.Writer, header [3]uint64, words []uint64) (int64, error) {
+	buffer := make([]byte, 0, 8*ChunkWords)
+
+	for _, field := range header {
+		buffer = binary.AppendUvarint(buffer, field)
+	}
+
+	written := int64(0)
+
+	for _, word := range words {
+		if len(buffer) == cap(buffer) {
+			n, err := w.Write(buffer)
+			written += int64(n)
+
+			if err != nil {
+				return written, err
+			}
+
+			buffer = buffer[:0]
+		}
+
+		buffer = binary.LittleEndian.AppendUint64(buffer, word)
+	}
+
+	n, err := w.Write(buffer)
+
+	return written + int64(n), err
+}
+
+// Reader decodes a stream written by Write, counting the bytes it consumes
+// so that it never reads past the end of the encoding
+type Reader struct {
+	source io.Reader
+	read   int64
+	buffer []byte
+}
+
+func NewReader(source io.Reader) *Reader {
+	return &Reader{source: source}
+}
+
+// Consumed is the number of bytes read so far
+func (r *Reader) Consumed() int64 {
+	return r.read
+}
+
+func (r *Reader) ReadByte() (byte, error) {
+	var one [1]byte
+
+	n, err := io.ReadFull(r.source, one[:])
+	r.read += int64(n)
+
+	return one[0], err
+}
+
+// Header reads the three header fields, leaving their validation to the
+// caller
+func (r *Reader) Header() (fields [3]uint64, err error) {
+	for i := range fields {
+		if fields[i], err = binary.ReadUvarint(r); err != nil {
+			return
+		}
+	}
+
+	return fields, nil
+}
+
+// Words reads n words a chunk at a time, handing each to fn with its index
+func (r *Reader) Words(n uint64, fn func(i int, word uint64)) error {
+	if r.buffer == nil {
+		r.buffer = make([]byte, 8*ChunkWords)
+	}
+
+	for i := uint64(0); i < n; {
+		chunk := r.buffer[:8*min(n-i, ChunkWords)]
+
+		read, err := io.ReadFull(r.source, chunk)
+		r.read += int64(read)
+
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for j := 0; j < len(chunk); j += 8 {
+			fn(int(i), binary.LittleEndian.Uint64(chunk[j:]))
+			i++
+		}
+	}
+
+	return nil
+}
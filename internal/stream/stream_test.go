@@ -0,0 +1,51 @@
+package stream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	words := make([]uint64, 2*ChunkWords+3)
+
+	for i := range words {
+		words[i] = uint64(i) * 0x9e3779b97f4a7c15
+	}
+
+	buffer := bytes.Buffer{}
+	written, err := Write(&buffer, [3]uint64{1, 300, 1 << 40}, words)
+
+	if err != nil || written != int64(buffer.Len()) {
+		t.Fatalf("Expected %d bytes to be written, got %d (%v)", buffer.Len(), written, err)
+	}
+
+	buffer.WriteString("trailing")
+	source := NewReader(&buffer)
+
+	if header, err := source.Header(); err != nil || header != [3]uint64{1, 300, 1 << 40} {
+		t.Errorf("Expected header to be [1 300 %d], got %v (%v)", uint64(1<<40), header, err)
+	}
+
+	read := make([]uint64, 0)
+
+	err = source.Words(uint64(len(words)), func(i int, word uint64) {
+		if i != len(read) {
+			t.Errorf("Expected index to be %d, got %d", len(read), i)
+		}
+
+		read = append(read, word)
+	})
+
+	if err != nil || len(read) != len(words) || read[len(read)-1] != words[len(words)-1] {
+		t.Errorf("Expected %d words to be read back, got %d (%v)", len(words), len(read), err)
+	}
+
+	if source.Consumed() != written || buffer.String() != "trailing" {
+		t.Errorf("Expected to consume %d bytes, got %d", written, source.Consumed())
+	}
+
+	if err := NewReader(bytes.NewReader([]byte{1, 2, 3, 4})).Words(1, func(int, uint64) {}); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
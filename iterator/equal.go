@@ -0,0 +1,131 @@
+package iterator
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+func Equal[E any](a, b Collection[E], eq func(E, E) bool) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	left := collect(a)
+	right := collect(b)
+
+	for i := range left {
+		if !eq(left[i], right[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualUnordered compares the collections as multisets. Since eq may be any
+// equivalence it matches elements pairwise, calling it O(n²) times; prefer
+// EqualUnorderedComparable when eq is ==.
+func EqualUnordered[E any](a, b Collection[E], eq func(E, E) bool) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	right := collect(b)
+	matched := make([]bool, len(right))
+
+	for _, element := range collect(a) {
+		found := false
+
+		for i := range right {
+			if !matched[i] && eq(element, right[i]) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualUnorderedComparable compares the collections as multisets in linear
+// time by counting their elements
+func EqualUnorderedComparable[E comparable](a, b Collection[E]) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	counts := make(map[E]int, a.Size())
+
+	a.Range(func(element E) bool {
+		counts[element]++
+		return true
+	})
+
+	equal := true
+
+	b.Range(func(element E) bool {
+		counts[element]--
+		equal = counts[element] >= 0
+
+		return equal
+	})
+
+	return equal
+}
+
+// Hash and HashUnordered hash the gob encoding of every element, so they
+// panic on elements gob cannot encode, such as functions and channels
+func Hash[E any](c Collection[E]) uint64 {
+	hasher := fnv.New64()
+
+	c.Range(func(element E) bool {
+		hasher.Write(encode(element))
+		return true
+	})
+
+	return hasher.Sum64()
+}
+
+func HashUnordered[E any](c Collection[E]) uint64 {
+	var sum uint64
+
+	c.Range(func(element E) bool {
+		hasher := fnv.New64()
+		hasher.Write(encode(element))
+		sum += hasher.Sum64()
+
+		return true
+	})
+
+	return sum
+}
+
+func collect[E any](c Collection[E]) []E {
+	elements := make([]E, 0, c.Size())
+
+	c.Range(func(element E) bool {
+		elements = append(elements, element)
+		return true
+	})
+
+	return elements
+}
+
+func encode[E any](element E) []byte {
+	buffer := bytes.Buffer{}
+
+	if err := gob.NewEncoder(&buffer).Encode(element); err != nil {
+		msg := fmt.Sprintf("cannot hash %T: %v", element, err)
+		panic(errors.New(msg))
+	}
+
+	return buffer.Bytes()
+}
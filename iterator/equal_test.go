@@ -0,0 +1,95 @@
+package iterator
+
+import "testing"
+
+func newListOf[E any](elements ...E) Collection[E] {
+	list := NewList[E]()
+
+	for _, element := range elements {
+		list.Append(element)
+	}
+
+	return list
+}
+
+func intEquals(a, b int) bool {
+	return a == b
+}
+
+func TestEqual(t *testing.T) {
+	a := newListOf(1, 2, 3)
+	b := newListOf(1, 2, 3)
+	c := newListOf(3, 2, 1)
+
+	if !Equal(a, b, intEquals) {
+		t.Errorf("Expected collections to be equal")
+	}
+
+	if Equal(a, c, intEquals) {
+		t.Errorf("Expected collections with different order to not be equal")
+	}
+
+	if Equal(a, newListOf(1, 2), intEquals) {
+		t.Errorf("Expected collections with different sizes to not be equal")
+	}
+}
+
+func TestEqualUnordered(t *testing.T) {
+	a := newListOf(1, 2, 2, 3)
+	b := newListOf(2, 3, 1, 2)
+	c := newListOf(1, 2, 3, 3)
+
+	if !EqualUnordered(a, b, intEquals) {
+		t.Errorf("Expected collections to be equal as multisets")
+	}
+
+	if EqualUnordered(a, c, intEquals) {
+		t.Errorf("Expected collections with different multiplicities to not be equal")
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := newListOf("foo", "bar")
+	b := newListOf("foo", "bar")
+	c := newListOf("bar", "foo")
+
+	if Hash(a) != Hash(b) {
+		t.Errorf("Expected equal collections to have the same hash")
+	}
+
+	if Hash(a) == Hash(c) {
+		t.Errorf("Expected collections with different order to have different hashes")
+	}
+
+	if HashUnordered(a) != HashUnordered(c) {
+		t.Errorf("Expected unordered hash to ignore element order")
+	}
+}
+
+func TestEqualUnorderedComparable(t *testing.T) {
+	a := newListOf(1, 2, 2, 3)
+	b := newListOf(2, 3, 1, 2)
+	c := newListOf(1, 2, 3, 3)
+
+	if !EqualUnorderedComparable(a, b) {
+		t.Errorf("Expected collections to be equal as multisets")
+	}
+
+	if EqualUnorderedComparable(a, c) || EqualUnorderedComparable(c, a) {
+		t.Errorf("Expected collections with different multiplicities to not be equal")
+	}
+
+	if EqualUnorderedComparable(a, newListOf(1, 2, 3)) {
+		t.Errorf("Expected collections with different sizes to not be equal")
+	}
+}
+
+func TestHashPanicsOnUnencodableElements(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Hash(newListOf(func() {}))
+}
@@ -5,12 +5,14 @@ type Iterator[E any] interface {
 	Map(f func(E) interface{}) Collection[interface{}]
 	Filter(f func(E) bool) Collection[E]
 	ForEach(f func(E))
+	Range(f func(E) bool)
 }
 
 type Collection[E any] interface {
 	Iterator[E]
 	Append(element E)
 	Remove(index int)
+	RemoveIf(f func(E) bool) int
 	IsEmpty() bool
 	Size() uint16
 }
@@ -62,8 +64,19 @@ func (l *List[E]) Filter(f func(E) bool) Collection[E] {
 }
 
 func (l *List[E]) ForEach(f func(E)) {
-	for entry := range l.Iter() {
-		f(entry)
+	l.Range(func(element E) bool {
+		f(element)
+		return true
+	})
+}
+
+// Range calls f on every element until it returns false, without the
+// goroutine and channel of Iter
+func (l *List[E]) Range(f func(E) bool) {
+	for _, element := range l.elements {
+		if !f(element) {
+			return
+		}
 	}
 }
 
@@ -75,6 +88,28 @@ func (l *List[E]) Remove(index int) {
 	l.elements = append(l.elements[:index], l.elements[index+1:]...)
 }
 
+func (l *List[E]) RemoveIf(f func(E) bool) int {
+	kept := l.elements[:0]
+
+	for _, element := range l.elements {
+		if !f(element) {
+			kept = append(kept, element)
+		}
+	}
+
+	removed := len(l.elements) - len(kept)
+
+	// Clear the tail so removed elements can be garbage collected
+	var zero E
+	for i := len(kept); i < len(l.elements); i++ {
+		l.elements[i] = zero
+	}
+
+	l.elements = kept
+
+	return removed
+}
+
 func (l *List[E]) IsEmpty() bool {
 	return len(l.elements) == 0
 }
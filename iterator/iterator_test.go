@@ -0,0 +1,60 @@
+package iterator
+
+import "testing"
+
+func TestListRemoveIf(t *testing.T) {
+	list := newListOf(1, 2, 3, 4, 5, 6)
+
+	removed := list.RemoveIf(func(element int) bool {
+		return element%2 == 0
+	})
+
+	if removed != 3 {
+		t.Errorf("Expected removed to be 3, got %d", removed)
+	}
+
+	if !Equal(list, newListOf(1, 3, 5), intEquals) {
+		t.Errorf("Expected list to be [1 3 5]")
+	}
+}
+
+func TestListRange(t *testing.T) {
+	list := NewList[int]()
+
+	for i := 0; i < 100; i++ {
+		list.Append(i)
+	}
+
+	sum := 0
+
+	visit := func(element int) bool {
+		sum += element
+		return element < 49
+	}
+
+	list.Range(visit)
+
+	if sum != 49*50/2 {
+		t.Errorf("Expected Range to stop after 50 elements, got sum %d", sum)
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() { list.Range(visit) }); allocs != 0 {
+		t.Errorf("Expected Range not to allocate, got %v", allocs)
+	}
+}
+
+func BenchmarkListRange(b *testing.B) {
+	list := NewList[int]()
+
+	for i := 0; i < 10_000; i++ {
+		list.Append(i)
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		list.Range(func(int) bool {
+			return true
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package iterator
+
+// The set operations below merge two iterators that yield elements in
+// ascending order according to less. They hold a single element of each input
+// at a time, so they work on sequences of any length. Inputs are always read
+// to the end so their producers can finish.
+
+func UnionIter[E any](a, b <-chan E, less func(E, E) bool) <-chan E {
+	iterator := make(chan E)
+
+	go func() {
+		x, okA := <-a
+		y, okB := <-b
+
+		for okA || okB {
+			switch {
+			case !okB || (okA && less(x, y)):
+				iterator <- x
+				x, okA = <-a
+			case !okA || less(y, x):
+				iterator <- y
+				y, okB = <-b
+			default:
+				iterator <- x
+				x, okA = <-a
+				y, okB = <-b
+			}
+		}
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func IntersectIter[E any](a, b <-chan E, less func(E, E) bool) <-chan E {
+	iterator := make(chan E)
+
+	go func() {
+		x, okA := <-a
+		y, okB := <-b
+
+		for okA && okB {
+			switch {
+			case less(x, y):
+				x, okA = <-a
+			case less(y, x):
+				y, okB = <-b
+			default:
+				iterator <- x
+				x, okA = <-a
+				y, okB = <-b
+			}
+		}
+
+		drain(a)
+		drain(b)
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func DifferenceIter[E any](a, b <-chan E, less func(E, E) bool) <-chan E {
+	iterator := make(chan E)
+
+	go func() {
+		x, okA := <-a
+		y, okB := <-b
+
+		for okA {
+			switch {
+			case !okB || less(x, y):
+				iterator <- x
+				x, okA = <-a
+			case less(y, x):
+				y, okB = <-b
+			default:
+				x, okA = <-a
+				y, okB = <-b
+			}
+		}
+
+		drain(b)
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func drain[E any](c <-chan E) {
+	for range c {
+	}
+}
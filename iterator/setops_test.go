@@ -0,0 +1,70 @@
+package iterator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func collectChannel[E any](c <-chan E) []E {
+	elements := make([]E, 0)
+
+	for element := range c {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+func TestUnionIter(t *testing.T) {
+	a := newListOf(1, 3, 5, 7)
+	b := newListOf(2, 3, 6, 7, 9)
+
+	result := collectChannel(UnionIter(a.Iter(), b.Iter(), intLess))
+
+	if fmt.Sprint(result) != "[1 2 3 5 6 7 9]" {
+		t.Errorf("Expected [1 2 3 5 6 7 9], got %v", result)
+	}
+}
+
+func TestIntersectIter(t *testing.T) {
+	a := newListOf(1, 3, 5, 7)
+	b := newListOf(2, 3, 6, 7, 9)
+
+	result := collectChannel(IntersectIter(a.Iter(), b.Iter(), intLess))
+
+	if fmt.Sprint(result) != "[3 7]" {
+		t.Errorf("Expected [3 7], got %v", result)
+	}
+}
+
+func TestDifferenceIter(t *testing.T) {
+	a := newListOf(1, 3, 5, 7)
+	b := newListOf(2, 3, 6, 7, 9)
+
+	result := collectChannel(DifferenceIter(a.Iter(), b.Iter(), intLess))
+
+	if fmt.Sprint(result) != "[1 5]" {
+		t.Errorf("Expected [1 5], got %v", result)
+	}
+}
+
+func TestSetOperationsWithEmptyInput(t *testing.T) {
+	a := newListOf(1, 2)
+	empty := newListOf[int]()
+
+	if result := collectChannel(UnionIter(empty.Iter(), a.Iter(), intLess)); len(result) != 2 {
+		t.Errorf("Expected 2 elements, got %v", result)
+	}
+
+	if result := collectChannel(IntersectIter(a.Iter(), empty.Iter(), intLess)); len(result) != 0 {
+		t.Errorf("Expected no elements, got %v", result)
+	}
+
+	if result := collectChannel(DifferenceIter(a.Iter(), empty.Iter(), intLess)); len(result) != 2 {
+		t.Errorf("Expected 2 elements, got %v", result)
+	}
+}
@@ -0,0 +1,24 @@
+package iterator
+
+import "unsafe"
+
+// Sizer estimates the memory held by a structure. The cost callback reports
+// the bytes an element references outside of its inline representation
+// (string contents, slices, pointers) and may be nil.
+type Sizer[E any] interface {
+	SizeBytes(cost func(E) uint64) uint64
+}
+
+func (l *List[E]) SizeBytes(cost func(E) uint64) uint64 {
+	var element E
+
+	size := uint64(unsafe.Sizeof(*l)) + uint64(cap(l.elements))*uint64(unsafe.Sizeof(element))
+
+	if cost != nil {
+		for _, element := range l.elements {
+			size += cost(element)
+		}
+	}
+
+	return size
+}
@@ -0,0 +1,19 @@
+package iterator
+
+import "testing"
+
+func TestListSizeBytes(t *testing.T) {
+	list := newListOf("a", "bb", "ccc")
+
+	var _ Sizer[string] = list.(*List[string])
+
+	withCost := list.(*List[string]).SizeBytes(func(s string) uint64 {
+		return uint64(len(s))
+	})
+
+	withoutCost := list.(*List[string]).SizeBytes(nil)
+
+	if withCost != withoutCost+6 {
+		t.Errorf("Expected size with cost to be %d, got %d", withoutCost+6, withCost)
+	}
+}
@@ -0,0 +1,269 @@
+package linkcut
+
+import (
+	"errors"
+	"fmt"
+)
+
+const null = -1
+
+type node[V any] struct {
+	children [2]int
+	parent   int
+	reversed bool
+	value    V
+	forward  V
+	backward V
+}
+
+type Forest[V any] struct {
+	nodes   []node[V]
+	combine func(V, V) V
+}
+
+func NewForest[V any](values []V, combine func(V, V) V) *Forest[V] {
+	forest := Forest[V]{
+		nodes:   make([]node[V], len(values)),
+		combine: combine,
+	}
+
+	for i, value := range values {
+		forest.nodes[i] = node[V]{
+			children: [2]int{null, null},
+			parent:   null,
+			value:    value,
+			forward:  value,
+			backward: value,
+		}
+	}
+
+	return &forest
+}
+
+func (f *Forest[V]) Size() int {
+	return len(f.nodes)
+}
+
+func (f *Forest[V]) Value(v int) V {
+	f.checkNode(v)
+
+	return f.nodes[v].value
+}
+
+func (f *Forest[V]) SetValue(v int, value V) {
+	f.checkNode(v)
+
+	f.access(v)
+	f.nodes[v].value = value
+	f.update(v)
+}
+
+func (f *Forest[V]) Link(child, parent int) error {
+	f.checkNode(child)
+	f.checkNode(parent)
+
+	if f.Connected(child, parent) {
+		msg := fmt.Sprintf("nodes already connected: %d and %d", child, parent)
+		return errors.New(msg)
+	}
+
+	f.makeRoot(child)
+	f.nodes[child].parent = parent
+
+	return nil
+}
+
+func (f *Forest[V]) Cut(u, v int) error {
+	f.checkNode(u)
+	f.checkNode(v)
+
+	f.makeRoot(u)
+	f.access(v)
+
+	if f.nodes[v].children[0] != u || f.nodes[u].children[1] != null {
+		msg := fmt.Sprintf("no edge between nodes: %d and %d", u, v)
+		return errors.New(msg)
+	}
+
+	f.nodes[v].children[0] = null
+	f.nodes[u].parent = null
+	f.update(v)
+
+	return nil
+}
+
+func (f *Forest[V]) Connected(u, v int) bool {
+	f.checkNode(u)
+	f.checkNode(v)
+
+	return u == v || f.FindRoot(u) == f.FindRoot(v)
+}
+
+func (f *Forest[V]) FindRoot(v int) int {
+	f.checkNode(v)
+
+	f.access(v)
+
+	root := v
+
+	for {
+		f.push(root)
+
+		if f.nodes[root].children[0] == null {
+			break
+		}
+
+		root = f.nodes[root].children[0]
+	}
+
+	f.splay(root)
+
+	return root
+}
+
+func (f *Forest[V]) Evert(v int) {
+	f.checkNode(v)
+
+	f.makeRoot(v)
+}
+
+func (f *Forest[V]) PathAggregate(u, v int) (V, error) {
+	if !f.Connected(u, v) {
+		var zero V
+		msg := fmt.Sprintf("nodes not connected: %d and %d", u, v)
+		return zero, errors.New(msg)
+	}
+
+	f.makeRoot(u)
+	f.access(v)
+
+	return f.nodes[v].forward, nil
+}
+
+func (f *Forest[V]) checkNode(v int) {
+	if v < 0 || v >= len(f.nodes) {
+		msg := fmt.Sprintf("node out of range: %d", v)
+		panic(errors.New(msg))
+	}
+}
+
+func (f *Forest[V]) isRoot(x int) bool {
+	parent := f.nodes[x].parent
+
+	return parent == null || (f.nodes[parent].children[0] != x && f.nodes[parent].children[1] != x)
+}
+
+func (f *Forest[V]) toggle(x int) {
+	if x == null {
+		return
+	}
+
+	n := &f.nodes[x]
+	n.children[0], n.children[1] = n.children[1], n.children[0]
+	n.forward, n.backward = n.backward, n.forward
+	n.reversed = !n.reversed
+}
+
+func (f *Forest[V]) push(x int) {
+	if f.nodes[x].reversed {
+		f.toggle(f.nodes[x].children[0])
+		f.toggle(f.nodes[x].children[1])
+		f.nodes[x].reversed = false
+	}
+}
+
+func (f *Forest[V]) update(x int) {
+	n := &f.nodes[x]
+	n.forward = n.value
+	n.backward = n.value
+
+	if left := n.children[0]; left != null {
+		n.forward = f.combine(f.nodes[left].forward, n.forward)
+		n.backward = f.combine(n.backward, f.nodes[left].backward)
+	}
+
+	if right := n.children[1]; right != null {
+		n.forward = f.combine(n.forward, f.nodes[right].forward)
+		n.backward = f.combine(f.nodes[right].backward, n.backward)
+	}
+}
+
+func (f *Forest[V]) rotate(x int) {
+	parent := f.nodes[x].parent
+	grandparent := f.nodes[parent].parent
+
+	side := 0
+	if f.nodes[parent].children[1] == x {
+		side = 1
+	}
+
+	child := f.nodes[x].children[1-side]
+
+	if !f.isRoot(parent) {
+		if f.nodes[grandparent].children[0] == parent {
+			f.nodes[grandparent].children[0] = x
+		} else {
+			f.nodes[grandparent].children[1] = x
+		}
+	}
+
+	f.nodes[x].parent = grandparent
+
+	f.nodes[x].children[1-side] = parent
+	f.nodes[parent].parent = x
+
+	f.nodes[parent].children[side] = child
+	if child != null {
+		f.nodes[child].parent = parent
+	}
+
+	f.update(parent)
+	f.update(x)
+}
+
+func (f *Forest[V]) splay(x int) {
+	// Push pending reversals from the top of the auxiliary tree down to x
+	path := []int{x}
+	for y := x; !f.isRoot(y); y = f.nodes[y].parent {
+		path = append(path, f.nodes[y].parent)
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		f.push(path[i])
+	}
+
+	for !f.isRoot(x) {
+		parent := f.nodes[x].parent
+
+		if !f.isRoot(parent) {
+			grandparent := f.nodes[parent].parent
+			zigzig := (f.nodes[grandparent].children[0] == parent) == (f.nodes[parent].children[0] == x)
+
+			if zigzig {
+				f.rotate(parent)
+			} else {
+				f.rotate(x)
+			}
+		}
+
+		f.rotate(x)
+	}
+}
+
+func (f *Forest[V]) access(x int) {
+	last := null
+
+	for y := x; y != null; y = f.nodes[y].parent {
+		f.splay(y)
+		f.nodes[y].children[1] = last
+		f.update(y)
+		last = y
+	}
+
+	f.splay(x)
+}
+
+func (f *Forest[V]) makeRoot(x int) {
+	f.access(x)
+	f.toggle(x)
+}
@@ -0,0 +1,162 @@
+package linkcut
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func sum(a, b int) int {
+	return a + b
+}
+
+func TestLinkAndPathAggregate(t *testing.T) {
+	forest := NewForest([]int{1, 2, 3, 4, 5}, sum)
+
+	forest.Link(1, 0)
+	forest.Link(2, 1)
+	forest.Link(3, 1)
+	forest.Link(4, 3)
+
+	if root := forest.FindRoot(4); root != 0 {
+		t.Errorf("Expected root to be 0, got %d", root)
+	}
+
+	total, err := forest.PathAggregate(2, 4)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if total != 3+2+4+5 {
+		t.Errorf("Expected total to be 14, got %d", total)
+	}
+
+}
+
+func TestCutDisconnectsTrees(t *testing.T) {
+	forest := NewForest([]int{1, 1, 1}, sum)
+
+	forest.Link(1, 0)
+	forest.Link(2, 1)
+
+	if err := forest.Cut(1, 2); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if forest.Connected(0, 2) {
+		t.Errorf("Expected nodes 0 and 2 to be disconnected")
+	}
+
+	if !forest.Connected(0, 1) {
+		t.Errorf("Expected nodes 0 and 1 to be connected")
+	}
+
+	if err := forest.Cut(0, 2); err == nil {
+		t.Errorf("Expected error when cutting a missing edge")
+	}
+}
+
+func TestLinkConnectedNodesFails(t *testing.T) {
+	forest := NewForest([]int{1, 1}, sum)
+
+	forest.Link(0, 1)
+
+	if err := forest.Link(1, 0); err == nil {
+		t.Errorf("Expected error when linking connected nodes")
+	}
+}
+
+func TestNonCommutativePathAggregate(t *testing.T) {
+	concat := func(a, b string) string { return a + b }
+	forest := NewForest([]string{"a", "b", "c", "d"}, concat)
+
+	forest.Link(0, 1)
+	forest.Link(1, 2)
+	forest.Link(3, 2)
+
+	path, _ := forest.PathAggregate(0, 3)
+
+	if path != "abcd" {
+		t.Errorf("Expected path to be 'abcd', got %s", path)
+	}
+
+	path, _ = forest.PathAggregate(3, 0)
+
+	if path != "dcba" {
+		t.Errorf("Expected path to be 'dcba', got %s", path)
+	}
+}
+
+func TestRandomOperationsMatchNaiveForest(t *testing.T) {
+	const size = 30
+	random := rand.New(rand.NewSource(42))
+
+	values := make([]int, size)
+	for i := range values {
+		values[i] = random.Intn(100)
+	}
+
+	forest := NewForest(values, sum)
+	adjacency := make([]map[int]bool, size)
+	for i := range adjacency {
+		adjacency[i] = map[int]bool{}
+	}
+
+	naivePath := func(u, v int) (int, bool) {
+		previous := map[int]int{u: -1}
+		queue := []int{u}
+
+		for len(queue) > 0 {
+			x := queue[0]
+			queue = queue[1:]
+
+			for y := range adjacency[x] {
+				if _, seen := previous[y]; !seen {
+					previous[y] = x
+					queue = append(queue, y)
+				}
+			}
+		}
+
+		if _, ok := previous[v]; !ok {
+			return 0, false
+		}
+
+		total := 0
+		for x := v; x != -1; x = previous[x] {
+			total += values[x]
+		}
+
+		return total, true
+	}
+
+	for i := 0; i < 2000; i++ {
+		u, v := random.Intn(size), random.Intn(size)
+
+		switch random.Intn(3) {
+		case 0:
+			if u != v && !forest.Connected(u, v) {
+				forest.Link(u, v)
+				adjacency[u][v] = true
+				adjacency[v][u] = true
+			}
+		case 1:
+			if adjacency[u][v] {
+				forest.Cut(u, v)
+				delete(adjacency[u], v)
+				delete(adjacency[v], u)
+			}
+		case 2:
+			expected, connected := naivePath(u, v)
+			total, err := forest.PathAggregate(u, v)
+
+			if connected != (err == nil) {
+				t.Fatalf("Expected connected to be %v for %d and %d", connected, u, v)
+			}
+
+			if connected && total != expected {
+				t.Fatalf("Expected total to be %d, got %d", expected, total)
+			}
+		}
+	}
+}
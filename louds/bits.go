@@ -0,0 +1,82 @@
+package louds
+
+import "math/bits"
+
+// bitVector answers rank in constant time from the number of ones before
+// every word, and select by binary search over rank
+type bitVector struct {
+	words  []uint64
+	ranks  []uint32
+	length int
+}
+
+func (b *bitVector) append(bit bool) {
+	if b.length%64 == 0 {
+		b.words = append(b.words, 0)
+	}
+
+	if bit {
+		b.words[b.length/64] |= 1 << (b.length % 64)
+	}
+
+	b.length++
+}
+
+// index computes the ranks once every bit has been appended
+func (b *bitVector) index() {
+	b.ranks = make([]uint32, len(b.words)+1)
+
+	for i, word := range b.words {
+		b.ranks[i+1] = b.ranks[i] + uint32(bits.OnesCount64(word))
+	}
+}
+
+func (b *bitVector) get(i int) bool {
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// rank1 is the number of ones before position i
+func (b *bitVector) rank1(i int) int {
+	rank := int(b.ranks[i/64])
+
+	if i%64 != 0 {
+		rank += bits.OnesCount64(b.words[i/64] << (64 - i%64))
+	}
+
+	return rank
+}
+
+func (b *bitVector) rank0(i int) int {
+	return i - b.rank1(i)
+}
+
+// select1 is the position of the one of rank k, counting from 0
+func (b *bitVector) select1(k int) int {
+	return b.search(func(i int) int { return b.rank1(i) }, k)
+}
+
+func (b *bitVector) select0(k int) int {
+	return b.search(b.rank0, k)
+}
+
+// search finds the last position whose rank is k, which holds the bit of rank
+// k since the rank only grows past it
+func (b *bitVector) search(rank func(int) int, k int) int {
+	lo, hi := 0, b.length
+
+	for lo < hi {
+		middle := int(uint(lo+hi) >> 1)
+
+		if rank(middle+1) <= k {
+			lo = middle + 1
+		} else {
+			hi = middle
+		}
+	}
+
+	return lo
+}
+
+func (b *bitVector) ones() int {
+	return int(b.ranks[len(b.words)])
+}
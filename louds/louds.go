@@ -0,0 +1,295 @@
+package louds
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+)
+
+var errCorrupt = errors.New("louds: corrupt trie")
+
+// Trie is a read-only set of strings stored as a level-order unary degree
+// sequence: visiting the trie nodes breadth first, every node writes a one
+// per child followed by a zero, after a leading 10 for a virtual super root.
+// Node numbers follow the same order, so the children of node x are the
+// consecutive nodes numbered from the rank of the ones after its zero. With
+// one label byte and one terminal bit per node the whole trie takes about
+// 10 bits per node against several words per node of a pointer trie.
+type Trie struct {
+	louds    bitVector
+	terminal bitVector
+	labels   []byte
+	count    int
+}
+
+// New builds a trie from keys sorted in ascending order without duplicates
+func New(keys []string) (*Trie, error) {
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			msg := fmt.Sprintf("keys are not sorted and unique at %d: %q after %q", i, keys[i], keys[i-1])
+			return nil, errors.New(msg)
+		}
+	}
+
+	t := Trie{labels: []byte{0}, count: len(keys)}
+
+	t.louds.append(true)
+	t.louds.append(false)
+
+	// Every node is the range of keys sharing the prefix of its depth, the
+	// shortest of them first since keys are sorted
+	type node struct {
+		lo, hi, depth int
+	}
+
+	queue := []node{{0, len(keys), 0}}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		lo := n.lo
+		terminal := lo < n.hi && len(keys[lo]) == n.depth
+
+		if terminal {
+			lo++
+		}
+
+		t.terminal.append(terminal)
+
+		for lo < n.hi {
+			label := keys[lo][n.depth]
+			hi := lo + 1
+
+			for hi < n.hi && keys[hi][n.depth] == label {
+				hi++
+			}
+
+			t.louds.append(true)
+			t.labels = append(t.labels, label)
+			queue = append(queue, node{lo, hi, n.depth + 1})
+			lo = hi
+		}
+
+		t.louds.append(false)
+	}
+
+	t.louds.index()
+	t.terminal.index()
+
+	return &t, nil
+}
+
+func (t *Trie) Len() int {
+	return t.count
+}
+
+// Bytes is the size of the encoded trie
+func (t *Trie) Bytes() int {
+	return 8*(len(t.louds.words)+len(t.terminal.words)) + 4*(len(t.louds.ranks)+len(t.terminal.ranks)) + len(t.labels)
+}
+
+// children returns the numbers of the first and past the last child of x
+func (t *Trie) children(x int) (first, end int) {
+	start := t.louds.select0(x) + 1
+	first = t.louds.rank1(start)
+	end = first + t.louds.select0(x+1) - start
+
+	return first, end
+}
+
+func (t *Trie) child(x int, label byte) (int, bool) {
+	first, end := t.children(x)
+
+	i := first + sort.Search(end-first, func(i int) bool {
+		return t.labels[first+i] >= label
+	})
+
+	return i, i < end && t.labels[i] == label
+}
+
+// walk returns the node reached by following the bytes of prefix
+func (t *Trie) walk(prefix string) (int, bool) {
+	x := 0
+
+	for i := 0; i < len(prefix); i++ {
+		var found bool
+
+		if x, found = t.child(x, prefix[i]); !found {
+			return 0, false
+		}
+	}
+
+	return x, true
+}
+
+// Find returns the ID of key, a number below Len unique to every key, which
+// can index a slice of values to turn the trie into a map
+func (t *Trie) Find(key string) (id int, found bool) {
+	x, found := t.walk(key)
+
+	if !found || !t.terminal.get(x) {
+		return 0, false
+	}
+
+	return t.terminal.rank1(x), true
+}
+
+func (t *Trie) Contains(key string) bool {
+	_, found := t.Find(key)
+
+	return found
+}
+
+// Key returns the key of the given ID by climbing from its node to the root
+func (t *Trie) Key(id int) string {
+	if id < 0 || id >= t.count {
+		msg := fmt.Sprintf("id out of range: %d", id)
+		panic(errors.New(msg))
+	}
+
+	key := make([]byte, 0)
+
+	for x := t.terminal.select1(id); x != 0; x = t.louds.rank0(t.louds.select1(x)) - 1 {
+		key = append(key, t.labels[x])
+	}
+
+	for i, j := 0, len(key)-1; i < j; i, j = i+1, j-1 {
+		key[i], key[j] = key[j], key[i]
+	}
+
+	return string(key)
+}
+
+// ScanPrefix visits the keys starting with prefix in order until f returns
+// false
+func (t *Trie) ScanPrefix(prefix string, f func(string) bool) {
+	x, found := t.walk(prefix)
+
+	if found {
+		t.visit(x, []byte(prefix), f)
+	}
+}
+
+// visit enumerates the keys below x depth first, which yields them sorted
+func (t *Trie) visit(x int, key []byte, f func(string) bool) bool {
+	if t.terminal.get(x) && !f(string(key)) {
+		return false
+	}
+
+	first, end := t.children(x)
+
+	for child := first; child < end; child++ {
+		if !t.visit(child, append(key, t.labels[child]), f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *Trie) WithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.ScanPrefix(prefix, yield)
+	}
+}
+
+func (t *Trie) Range(f func(string) bool) {
+	t.ScanPrefix("", f)
+}
+
+func (t *Trie) All() iter.Seq[string] {
+	return t.WithPrefix("")
+}
+
+// MarshalBinary encodes the trie so it can be embedded in a binary and
+// loaded without rebuilding
+func (t *Trie) MarshalBinary() ([]byte, error) {
+	buffer := binary.AppendUvarint(nil, uint64(len(t.labels)))
+
+	for _, words := range [][]uint64{t.louds.words, t.terminal.words} {
+		for _, word := range words {
+			buffer = binary.LittleEndian.AppendUint64(buffer, word)
+		}
+	}
+
+	return append(buffer, t.labels...), nil
+}
+
+// UnmarshalBinary restores a trie, checking that every node only has
+// children numbered after it so that corrupt data cannot make lookups loop
+func (t *Trie) UnmarshalBinary(buffer []byte) error {
+	nodes, n := binary.Uvarint(buffer)
+
+	if n <= 0 || nodes == 0 || nodes > uint64(len(buffer)) {
+		return errCorrupt
+	}
+
+	buffer = buffer[n:]
+
+	var decoded Trie
+
+	decoded.louds.length = 2*int(nodes) + 1
+	decoded.terminal.length = int(nodes)
+
+	louds, terminal := (decoded.louds.length+63)/64, (decoded.terminal.length+63)/64
+
+	if len(buffer) != 8*(louds+terminal)+int(nodes) {
+		return errCorrupt
+	}
+
+	words := make([]uint64, louds+terminal)
+
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(buffer[8*i:])
+	}
+
+	decoded.louds.words, decoded.terminal.words = words[:louds], words[louds:]
+	decoded.labels = append([]byte(nil), buffer[8*len(words):]...)
+
+	if !decoded.valid() {
+		return errCorrupt
+	}
+
+	decoded.louds.index()
+	decoded.terminal.index()
+	decoded.count = decoded.terminal.ones()
+
+	*t = decoded
+
+	return nil
+}
+
+// valid checks the bit sequence in a single pass: it starts with 10, padding
+// bits are clear, and the children of every node come after it, which also
+// makes the counts of ones and zeros add up
+func (t *Trie) valid() bool {
+	for _, b := range []bitVector{t.louds, t.terminal} {
+		if b.length%64 != 0 && b.words[len(b.words)-1]>>(b.length%64) != 0 {
+			return false
+		}
+	}
+
+	if !t.louds.get(0) || t.louds.get(1) {
+		return false
+	}
+
+	ones, zeros := 1, 1
+
+	for i := 2; i < t.louds.length; i++ {
+		if t.louds.get(i) {
+			// The child of node zeros - 1 numbered ones
+			if ones < zeros {
+				return false
+			}
+
+			ones++
+		} else {
+			zeros++
+		}
+	}
+
+	return ones == t.terminal.length && zeros == ones+1
+}
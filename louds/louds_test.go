@@ -0,0 +1,168 @@
+package louds
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+)
+
+var words = []string{"", "a", "an", "and", "ant", "any", "are", "art", "bat", "bath", "be", "zebra"}
+
+func TestFindAndKey(t *testing.T) {
+	trie, err := New(words)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if trie.Len() != len(words) {
+		t.Errorf("Expected length to be %d, got %d", len(words), trie.Len())
+	}
+
+	ids := make([]bool, len(words))
+
+	for _, word := range words {
+		id, found := trie.Find(word)
+
+		if !found {
+			t.Fatalf("Expected %q to be found", word)
+		}
+
+		if ids[id] {
+			t.Errorf("Expected the id of %q to be unique, got %d", word, id)
+		}
+
+		ids[id] = true
+
+		if key := trie.Key(id); key != word {
+			t.Errorf("Expected id %d to map back to %q, got %q", id, word, key)
+		}
+	}
+
+	for _, missing := range []string{"b", "ba", "bats", "c", "zebras", "anx"} {
+		if trie.Contains(missing) {
+			t.Errorf("Expected %q not to be found", missing)
+		}
+	}
+}
+
+func TestKeyOutOfRange(t *testing.T) {
+	trie, _ := New(words)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	trie.Key(len(words))
+}
+
+func TestUnsortedKeys(t *testing.T) {
+	if _, err := New([]string{"b", "a"}); err == nil {
+		t.Errorf("Expected an error for unsorted keys")
+	}
+
+	if _, err := New([]string{"a", "a"}); err == nil {
+		t.Errorf("Expected an error for duplicate keys")
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	trie, _ := New(words)
+
+	cases := map[string][]string{
+		"an":  {"an", "and", "ant", "any"},
+		"ba":  {"bat", "bath"},
+		"z":   {"zebra"},
+		"x":   nil,
+		"":    words,
+		"art": {"art"},
+	}
+
+	for prefix, expected := range cases {
+		if got := slices.Collect(trie.WithPrefix(prefix)); !slices.Equal(got, expected) {
+			t.Errorf("Expected prefix %q to yield %v, got %v", prefix, expected, got)
+		}
+	}
+
+	count := 0
+
+	trie.Range(func(string) bool {
+		count++
+		return count < 3
+	})
+
+	if count != 3 {
+		t.Errorf("Expected Range to stop after 3 keys, got %d", count)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	trie, _ := New(nil)
+
+	if trie.Len() != 0 || trie.Contains("") || len(slices.Collect(trie.All())) != 0 {
+		t.Errorf("Expected an empty trie")
+	}
+}
+
+func TestLarge(t *testing.T) {
+	keys := make([]string, 0, 20000)
+
+	for i := 0; i < 20000; i++ {
+		keys = append(keys, fmt.Sprintf("key/%d/%x", i%97, i))
+	}
+
+	sort.Strings(keys)
+	trie, _ := New(keys)
+
+	if got := slices.Collect(trie.All()); !slices.Equal(got, keys) {
+		t.Fatalf("Expected every key in order")
+	}
+
+	for i := 0; i < len(keys); i += 101 {
+		id, found := trie.Find(keys[i])
+
+		if !found || trie.Key(id) != keys[i] {
+			t.Fatalf("Expected %q to round trip", keys[i])
+		}
+	}
+
+	raw := 0
+
+	for _, key := range keys {
+		raw += len(key)
+	}
+
+	if trie.Bytes() >= raw {
+		t.Errorf("Expected the trie to be smaller than the %d bytes of keys, got %d", raw, trie.Bytes())
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	trie, _ := New(words)
+	data, _ := trie.MarshalBinary()
+
+	var restored Trie
+
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := slices.Collect(restored.All()); !slices.Equal(got, words) {
+		t.Errorf("Expected %v after a round trip, got %v", words, got)
+	}
+
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("Expected an error for truncated data")
+	}
+
+	// Break the degree sequence
+	corrupt := slices.Clone(data)
+	corrupt[1] = 0b0101
+
+	if err := restored.UnmarshalBinary(corrupt); err == nil {
+		t.Errorf("Expected an error for a malformed sequence")
+	}
+}
@@ -0,0 +1,85 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Matrix[T any] [][]T
+
+func New[T any](s Semiring[T], rows, cols int) Matrix[T] {
+	m := make(Matrix[T], rows)
+
+	for i := range m {
+		m[i] = make([]T, cols)
+
+		for j := range m[i] {
+			m[i][j] = s.Zero()
+		}
+	}
+
+	return m
+}
+
+func Identity[T any](s Semiring[T], n int) Matrix[T] {
+	m := New(s, n, n)
+
+	for i := range m {
+		m[i][i] = s.One()
+	}
+
+	return m
+}
+
+func (m Matrix[T]) Rows() int {
+	return len(m)
+}
+
+func (m Matrix[T]) Cols() int {
+	if len(m) == 0 {
+		return 0
+	}
+
+	return len(m[0])
+}
+
+func Mul[T any](s Semiring[T], a, b Matrix[T]) Matrix[T] {
+	if a.Cols() != b.Rows() {
+		msg := fmt.Sprintf("matrix: cannot multiply %dx%d by %dx%d", a.Rows(), a.Cols(), b.Rows(), b.Cols())
+		panic(errors.New(msg))
+	}
+
+	result := New(s, a.Rows(), b.Cols())
+
+	for i := range a {
+		for k, left := range a[i] {
+			for j, right := range b[k] {
+				result[i][j] = s.Add(result[i][j], s.Mul(left, right))
+			}
+		}
+	}
+
+	return result
+}
+
+// Pow computes m^k with O(log k) multiplications
+func Pow[T any](s Semiring[T], m Matrix[T], k uint64) Matrix[T] {
+	if m.Rows() != m.Cols() {
+		msg := fmt.Sprintf("matrix: cannot raise a %dx%d matrix to a power", m.Rows(), m.Cols())
+		panic(errors.New(msg))
+	}
+
+	result := Identity(s, m.Rows())
+
+	for base := m; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result = Mul(s, result, base)
+		}
+
+		if k > 1 {
+			base = Mul(s, base, base)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+const prime = 1_000_000_007
+
+func fibonacci(n int, mod uint64) uint64 {
+	a, b := uint64(0), uint64(1)
+
+	for i := 0; i < n; i++ {
+		a, b = b, (a+b)%mod
+	}
+
+	return a
+}
+
+func TestPowFibonacci(t *testing.T) {
+	s := Mod(prime)
+	step := Matrix[uint64]{{1, 1}, {1, 0}}
+
+	for _, n := range []int{0, 1, 2, 10, 90, 1000} {
+		if actual := Pow[uint64](s, step, uint64(n))[0][1]; actual != fibonacci(n, prime) {
+			t.Errorf("Expected F(%d) to be %d, got %d", n, fibonacci(n, prime), actual)
+		}
+	}
+}
+
+func TestModHandlesLargeModulus(t *testing.T) {
+	s := Mod(math.MaxUint64 - 58)
+
+	if actual := s.Mul(math.MaxUint64-59, 2); actual != math.MaxUint64-60 {
+		t.Errorf("Expected product to be %d, got %d", uint64(math.MaxUint64-60), actual)
+	}
+
+	if actual := s.Add(math.MaxUint64-59, 2); actual != 1 {
+		t.Errorf("Expected sum to be 1, got %d", actual)
+	}
+}
+
+func TestPowMinPlusShortestWalks(t *testing.T) {
+	inf := math.Inf(1)
+	graph := Matrix[float64]{
+		{inf, 1, 4},
+		{inf, inf, 1},
+		{1, inf, inf},
+	}
+
+	walks := Pow[float64](MinPlus{}, graph, 3)
+
+	if walks[0][0] != 3 {
+		t.Errorf("Expected the shortest 3-edge cycle to cost 3, got %v", walks[0][0])
+	}
+
+	if two := Pow[float64](MinPlus{}, graph, 2); two[0][2] != 2 || two[0][1] != inf {
+		t.Errorf("Expected 2-edge walks to cost 2 to node 2 and be impossible to node 1, got %v", two[0])
+	}
+}
+
+func TestPowBooleanReachability(t *testing.T) {
+	graph := Matrix[bool]{
+		{false, true, false},
+		{false, false, true},
+		{false, false, false},
+	}
+
+	if !Pow[bool](Boolean{}, graph, 2)[0][2] {
+		t.Errorf("Expected 2 to be reachable from 0 in two steps")
+	}
+
+	if Pow[bool](Boolean{}, graph, 3)[0][2] {
+		t.Errorf("Expected no walk of length 3 from 0 to 2")
+	}
+}
+
+func TestMulPanicsOnMismatchedDimensions(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	Mul[uint64](Mod(prime), New[uint64](Mod(prime), 2, 3), New[uint64](Mod(prime), 2, 3))
+}
+
+func TestKitamasaMatchesPow(t *testing.T) {
+	s := Mod(prime)
+
+	// a[i] = 2a[i-1] + 3a[i-3]
+	coefficients := []uint64{2, 0, 3}
+	initial := []uint64{1, 1, 2}
+	companion := Matrix[uint64]{{2, 0, 3}, {1, 0, 0}, {0, 1, 0}}
+
+	for _, n := range []uint64{0, 2, 3, 10, 12345, 1_000_000_000_000} {
+		power := Pow[uint64](s, companion, n)
+		expected := uint64(0)
+
+		for i := 0; i < 3; i++ {
+			expected = s.Add(expected, s.Mul(power[2][i], initial[2-i]))
+		}
+
+		if actual := Kitamasa[uint64](s, coefficients, initial, n); actual != expected {
+			t.Errorf("Expected a[%d] to be %d, got %d", n, expected, actual)
+		}
+	}
+}
+
+func TestKitamasaFirstOrder(t *testing.T) {
+	if actual := Kitamasa[uint64](Mod(prime), []uint64{2}, []uint64{1}, 20); actual != 1<<20 {
+		t.Errorf("Expected 2^20, got %d", actual)
+	}
+}
+
+func TestBerlekampMasseyRecoversRecurrence(t *testing.T) {
+	sequence := make([]uint64, 20)
+
+	for i := range sequence {
+		sequence[i] = fibonacci(i, prime)
+	}
+
+	coefficients := BerlekampMassey(sequence, prime)
+
+	if len(coefficients) != 2 || coefficients[0] != 1 || coefficients[1] != 1 {
+		t.Errorf("Expected Fibonacci coefficients [1 1], got %v", coefficients)
+	}
+
+	if actual := Kitamasa[uint64](Mod(prime), coefficients, sequence[:2], 1000); actual != fibonacci(1000, prime) {
+		t.Errorf("Expected F(1000) to be %d, got %d", fibonacci(1000, prime), actual)
+	}
+
+	powers := []uint64{1, 3, 9, 27, 81, 243}
+
+	if coefficients := BerlekampMassey(powers, prime); len(coefficients) != 1 || coefficients[0] != 3 {
+		t.Errorf("Expected coefficients [3], got %v", coefficients)
+	}
+}
@@ -0,0 +1,152 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kitamasa returns the n-th term (zero based) of the recurrence
+// a[i] = coefficients[0]*a[i-1] + ... + coefficients[k-1]*a[i-k]
+// given its first k terms. It takes O(k^2 log n) semiring operations instead
+// of the O(k^3 log n) of raising the companion matrix.
+func Kitamasa[T any](s Semiring[T], coefficients, initial []T, n uint64) T {
+	k := len(coefficients)
+
+	if k == 0 || len(initial) != k {
+		msg := fmt.Sprintf("matrix: recurrence needs as many initial terms as coefficients, got %d and %d", len(initial), k)
+		panic(errors.New(msg))
+	}
+
+	if n < uint64(k) {
+		return initial[n]
+	}
+
+	// Polynomials are kept reduced modulo x^k - c[0]x^(k-1) - ... - c[k-1]
+	reduce := func(p []T) []T {
+		for d := len(p) - 1; d >= k; d-- {
+			for i, c := range coefficients {
+				p[d-1-i] = s.Add(p[d-1-i], s.Mul(p[d], c))
+			}
+		}
+
+		return p[:k]
+	}
+
+	multiply := func(a, b []T) []T {
+		product := make([]T, 2*k-1)
+
+		for i := range product {
+			product[i] = s.Zero()
+		}
+
+		for i, x := range a {
+			for j, y := range b {
+				product[i+j] = s.Add(product[i+j], s.Mul(x, y))
+			}
+		}
+
+		return reduce(product)
+	}
+
+	result := make([]T, k)
+	base := make([]T, k+1)
+
+	for i := range base {
+		base[i] = s.Zero()
+	}
+
+	for i := range result {
+		result[i] = s.Zero()
+	}
+
+	result[0] = s.One()
+	base[1] = s.One()
+	base = reduce(base)
+
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = multiply(result, base)
+		}
+
+		if n > 1 {
+			base = multiply(base, base)
+		}
+	}
+
+	term := s.Zero()
+
+	for i, c := range result {
+		term = s.Add(term, s.Mul(c, initial[i]))
+	}
+
+	return term
+}
+
+// BerlekampMassey finds the shortest linear recurrence modulo the prime mod
+// that generates sequence, in the coefficient order Kitamasa expects.
+// A recurrence of order k is only reliable given at least 2k terms.
+func BerlekampMassey(sequence []uint64, mod uint64) []uint64 {
+	field := Mod(mod)
+	n := len(sequence)
+
+	// connection holds 1 - c[0]x - c[1]x^2 - ... as it is being fixed up
+	connection := make([]uint64, n+1)
+	backup := make([]uint64, n+1)
+	connection[0], backup[0] = 1, 1
+
+	length, gap := 0, 0
+	lastDelta := field.One()
+
+	for i := 0; i < n; i++ {
+		gap++
+
+		delta := sequence[i] % mod
+
+		for j := 1; j <= length; j++ {
+			delta = field.Add(delta, field.Mul(connection[j], sequence[i-j]))
+		}
+
+		if delta == 0 {
+			continue
+		}
+
+		previous := append([]uint64{}, connection...)
+		scale := field.Mul(delta, inverse(lastDelta, mod))
+
+		for j := gap; j <= n; j++ {
+			connection[j] = field.Add(connection[j], mod-field.Mul(scale, backup[j-gap]))
+		}
+
+		if 2*length > i {
+			continue
+		}
+
+		length = i + 1 - length
+		backup = previous
+		lastDelta = delta
+		gap = 0
+	}
+
+	coefficients := make([]uint64, length)
+
+	for i := range coefficients {
+		coefficients[i] = field.Add(0, mod-connection[i+1])
+	}
+
+	return coefficients
+}
+
+func inverse(value, mod uint64) uint64 {
+	field := Mod(mod)
+	result := field.One()
+
+	for exponent := mod - 2; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			result = field.Mul(result, value)
+		}
+
+		value = field.Mul(value, value)
+	}
+
+	return result
+}
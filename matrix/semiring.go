@@ -0,0 +1,102 @@
+package matrix
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Semiring supplies the two operations matrix products and recurrences are
+// built from. Nothing requires subtraction or division, so tropical and
+// boolean semirings work as well as modular arithmetic.
+type Semiring[T any] interface {
+	Zero() T
+	One() T
+	Add(a, b T) T
+	Mul(a, b T) T
+}
+
+// Mod is arithmetic modulo its value, with 128-bit intermediates so any
+// modulus up to 2^64-1 is safe
+type Mod uint64
+
+func (m Mod) Zero() uint64 {
+	return 0
+}
+
+func (m Mod) One() uint64 {
+	return 1 % uint64(m)
+}
+
+func (m Mod) Add(a, b uint64) uint64 {
+	sum, carry := bits.Add64(a%uint64(m), b%uint64(m), 0)
+
+	if carry != 0 || sum >= uint64(m) {
+		sum -= uint64(m)
+	}
+
+	return sum
+}
+
+func (m Mod) Mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a%uint64(m), b%uint64(m))
+
+	return bits.Rem64(hi, lo, uint64(m))
+}
+
+// MinPlus turns matrix products into shortest path relaxations, with
+// +Inf as the missing edge
+type MinPlus struct{}
+
+func (MinPlus) Zero() float64 {
+	return math.Inf(1)
+}
+
+func (MinPlus) One() float64 {
+	return 0
+}
+
+func (MinPlus) Add(a, b float64) float64 {
+	return math.Min(a, b)
+}
+
+func (MinPlus) Mul(a, b float64) float64 {
+	return a + b
+}
+
+// MaxPlus is the longest path counterpart of MinPlus
+type MaxPlus struct{}
+
+func (MaxPlus) Zero() float64 {
+	return math.Inf(-1)
+}
+
+func (MaxPlus) One() float64 {
+	return 0
+}
+
+func (MaxPlus) Add(a, b float64) float64 {
+	return math.Max(a, b)
+}
+
+func (MaxPlus) Mul(a, b float64) float64 {
+	return a + b
+}
+
+// Boolean turns matrix products into reachability
+type Boolean struct{}
+
+func (Boolean) Zero() bool {
+	return false
+}
+
+func (Boolean) One() bool {
+	return true
+}
+
+func (Boolean) Add(a, b bool) bool {
+	return a || b
+}
+
+func (Boolean) Mul(a, b bool) bool {
+	return a && b
+}
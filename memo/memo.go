@@ -0,0 +1,189 @@
+package memo
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"algorithms/cache"
+	"algorithms/hashtable"
+)
+
+type options struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+type Option func(*options)
+
+// WithMaxEntries keeps only the n most recently used results
+func WithMaxEntries(n int) Option {
+	if n <= 0 {
+		msg := fmt.Sprintf("invalid max entries: %d", n)
+		panic(errors.New(msg))
+	}
+
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// WithTTL recomputes results older than ttl
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+type result[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	ok    bool
+}
+
+// store is the part of the hash table and the LRU cache the memoizer uses
+type store[K comparable, V any] interface {
+	get(key K) (result[V], bool)
+	put(key K, value result[V])
+	remove(key K)
+}
+
+type tableStore[K comparable, V any] struct {
+	table *hashtable.HashTable[K, result[V]]
+}
+
+func (s tableStore[K, V]) get(key K) (result[V], bool) {
+	return s.table.TryGet(key)
+}
+
+func (s tableStore[K, V]) put(key K, value result[V]) {
+	s.table.Insert(key, value)
+}
+
+func (s tableStore[K, V]) remove(key K) {
+	s.table.Delete(key)
+}
+
+type lruStore[K comparable, V any] struct {
+	lru *cache.LRU[K, result[V]]
+}
+
+func (s lruStore[K, V]) get(key K) (result[V], bool) {
+	return s.lru.Get(key)
+}
+
+func (s lruStore[K, V]) put(key K, value result[V]) {
+	s.lru.Put(key, value)
+}
+
+func (s lruStore[K, V]) remove(key K) {
+	s.lru.Remove(key)
+}
+
+type memoizer[K comparable, V any] struct {
+	mutex    sync.Mutex
+	f        func(K) V
+	results  store[K, V]
+	inFlight *hashtable.HashTable[K, *call[V]]
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// Func returns f wrapped so every key is computed once and served from a
+// hash table afterwards. It is safe for concurrent use: callers asking for a
+// key that is being computed wait for that computation instead of starting
+// their own. The lock is not held while f runs, so f may call the memoized
+// function recursively for other keys.
+func Func[K comparable, V any](f func(K) V, opts ...Option) func(K) V {
+	o := options{now: time.Now}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m := &memoizer[K, V]{
+		f:        f,
+		inFlight: hashtable.NewHashTable[K, *call[V]](),
+		ttl:      o.ttl,
+		now:      o.now,
+	}
+
+	if o.maxEntries > 0 {
+		m.results = lruStore[K, V]{lru: cache.NewLRU[K, result[V]](o.maxEntries)}
+	} else {
+		m.results = tableStore[K, V]{table: hashtable.NewHashTable[K, result[V]]()}
+	}
+
+	return m.get
+}
+
+func (m *memoizer[K, V]) get(key K) V {
+	for {
+		m.mutex.Lock()
+
+		if stored, found := m.results.get(key); found {
+			if stored.expiresAt.IsZero() || m.now().Before(stored.expiresAt) {
+				m.mutex.Unlock()
+				return stored.value
+			}
+
+			m.results.remove(key)
+		}
+
+		if pending, found := m.inFlight.TryGet(key); found {
+			m.mutex.Unlock()
+			<-pending.done
+
+			if pending.ok {
+				return pending.value
+			}
+
+			// The computation panicked, try again from this caller
+			continue
+		}
+
+		pending := &call[V]{done: make(chan struct{})}
+		m.inFlight.Insert(key, pending)
+		m.mutex.Unlock()
+
+		return m.compute(key, pending)
+	}
+}
+
+func (m *memoizer[K, V]) compute(key K, pending *call[V]) V {
+	defer func() {
+		m.mutex.Lock()
+		m.inFlight.Delete(key)
+
+		if pending.ok {
+			stored := result[V]{value: pending.value}
+
+			if m.ttl > 0 {
+				stored.expiresAt = m.now().Add(m.ttl)
+			}
+
+			m.results.put(key, stored)
+		}
+
+		m.mutex.Unlock()
+		close(pending.done)
+	}()
+
+	pending.value = m.f(key)
+	pending.ok = true
+
+	return pending.value
+}
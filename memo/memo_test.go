@@ -0,0 +1,189 @@
+package memo
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFuncComputesOnce(t *testing.T) {
+	calls := 0
+
+	square := Func(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	for i := 0; i < 3; i++ {
+		if value := square(4); value != 16 {
+			t.Errorf("Expected value to be 16, got %d", value)
+		}
+	}
+
+	square(5)
+
+	if calls != 2 {
+		t.Errorf("Expected calls to be 2, got %d", calls)
+	}
+}
+
+func TestFuncRecursive(t *testing.T) {
+	var fib func(int) uint64
+
+	calls := 0
+
+	fib = Func(func(n int) uint64 {
+		calls++
+
+		if n < 2 {
+			return uint64(n)
+		}
+
+		return fib(n-1) + fib(n-2)
+	})
+
+	if value := fib(90); value != 2880067194370816120 {
+		t.Errorf("Expected value to be 2880067194370816120, got %d", value)
+	}
+
+	if calls != 91 {
+		t.Errorf("Expected calls to be 91, got %d", calls)
+	}
+}
+
+func TestFuncMaxEntries(t *testing.T) {
+	calls := 0
+
+	double := Func(func(n int) int {
+		calls++
+		return n * 2
+	}, WithMaxEntries(2))
+
+	double(1)
+	double(2)
+	double(1)
+	double(3)
+
+	if calls != 3 {
+		t.Errorf("Expected calls to be 3, got %d", calls)
+	}
+
+	double(1)
+
+	if calls != 3 {
+		t.Errorf("Expected 1 to stay cached, got %d calls", calls)
+	}
+
+	double(2)
+
+	if calls != 4 {
+		t.Errorf("Expected 2 to be evicted, got %d calls", calls)
+	}
+}
+
+func TestFuncInvalidMaxEntries(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	WithMaxEntries(0)
+}
+
+func TestFuncTTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	calls := 0
+
+	identity := Func(func(n int) int {
+		calls++
+		return n
+	}, WithTTL(time.Minute), WithClock(func() time.Time { return now }))
+
+	identity(1)
+	now = now.Add(30 * time.Second)
+	identity(1)
+
+	if calls != 1 {
+		t.Errorf("Expected calls to be 1, got %d", calls)
+	}
+
+	now = now.Add(time.Minute)
+	identity(1)
+
+	if calls != 2 {
+		t.Errorf("Expected expired result to be recomputed, got %d calls", calls)
+	}
+}
+
+func TestFuncSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	slow := Func(func(n int) int {
+		calls.Add(1)
+		<-release
+		return n + 1
+	})
+
+	var wg sync.WaitGroup
+
+	results := make([]int, 16)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			results[i] = slow(41)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected calls to be 1, got %d", calls.Load())
+	}
+
+	for _, result := range results {
+		if result != 42 {
+			t.Errorf("Expected result to be 42, got %d", result)
+		}
+	}
+}
+
+func TestFuncPanicIsNotCached(t *testing.T) {
+	calls := 0
+
+	flaky := Func(func(n int) int {
+		calls++
+
+		if calls == 1 {
+			panic("boom")
+		}
+
+		return n
+	})
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		flaky(7)
+	}()
+
+	if value := flaky(7); value != 7 {
+		t.Errorf("Expected value to be 7, got %d", value)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected calls to be 2, got %d", calls)
+	}
+}
@@ -0,0 +1,122 @@
+package minhash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"algorithms/hashtable"
+)
+
+// LSH splits signatures into bands of rows and buckets every band by its
+// hash. Two signatures become candidates when they share a bucket in at least
+// one band, which happens with probability 1-(1-s^rows)^bands for similarity s.
+type LSH[K comparable] struct {
+	bands      int
+	rows       int
+	buckets    []*hashtable.HashTable[uint64, []K]
+	signatures *hashtable.HashTable[K, Signature]
+}
+
+func NewLSH[K comparable](bands, rows int) *LSH[K] {
+	if bands <= 0 || rows <= 0 {
+		msg := fmt.Sprintf("invalid banding: %d bands of %d rows", bands, rows)
+		panic(errors.New(msg))
+	}
+
+	buckets := make([]*hashtable.HashTable[uint64, []K], bands)
+
+	for i := range buckets {
+		buckets[i] = hashtable.NewHashTable[uint64, []K]()
+	}
+
+	return &LSH[K]{
+		bands:      bands,
+		rows:       rows,
+		buckets:    buckets,
+		signatures: hashtable.NewHashTable[K, Signature](),
+	}
+}
+
+// Banding picks the bands and rows dividing numHashes whose S-curve threshold
+// (1/bands)^(1/rows) is closest to the requested similarity threshold.
+func Banding(numHashes int, threshold float64) (bands, rows int) {
+	best := math.Inf(1)
+
+	for r := 1; r <= numHashes; r++ {
+		if numHashes%r != 0 {
+			continue
+		}
+
+		b := numHashes / r
+		distance := math.Abs(math.Pow(1/float64(b), 1/float64(r)) - threshold)
+
+		if distance < best {
+			best, bands, rows = distance, b, r
+		}
+	}
+
+	return
+}
+
+func (l *LSH[K]) bandHash(signature Signature, band int) uint64 {
+	hash := uint64(band)
+
+	for _, value := range signature[band*l.rows : (band+1)*l.rows] {
+		hash = hashtable.IntHash(hash ^ value)
+	}
+
+	return hash
+}
+
+func (l *LSH[K]) Add(key K, signature Signature) error {
+	if len(signature) < l.bands*l.rows {
+		msg := fmt.Sprintf("signature too short: %d values for %d bands of %d rows", len(signature), l.bands, l.rows)
+		return errors.New(msg)
+	}
+
+	l.signatures.Insert(key, signature)
+
+	for band, bucket := range l.buckets {
+		hash := l.bandHash(signature, band)
+		keys, _ := bucket.TryGet(hash)
+		bucket.Insert(hash, append(keys, key))
+	}
+
+	return nil
+}
+
+func (l *LSH[K]) Candidates(signature Signature) []K {
+	seen := hashtable.NewHashTable[K, bool]()
+	candidates := make([]K, 0)
+
+	if len(signature) < l.bands*l.rows {
+		return candidates
+	}
+
+	for band, bucket := range l.buckets {
+		keys, _ := bucket.TryGet(l.bandHash(signature, band))
+
+		for _, key := range keys {
+			if !seen.Contains(key) {
+				seen.Insert(key, true)
+				candidates = append(candidates, key)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// Query returns the candidates whose estimated similarity reaches threshold
+func (l *LSH[K]) Query(signature Signature, threshold float64) []K {
+	matches := make([]K, 0)
+
+	for _, key := range l.Candidates(signature) {
+		if Similarity(signature, l.signatures.Get(key)) >= threshold {
+			matches = append(matches, key)
+		}
+	}
+
+	return matches
+}
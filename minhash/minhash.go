@@ -0,0 +1,104 @@
+package minhash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"algorithms/hashtable"
+)
+
+type Signature []uint64
+
+// MinHash estimates the Jaccard similarity of token sets. Each of its hash
+// functions xors a token hash with a random seed before mixing, and the
+// signature keeps the minimum value seen by every function.
+type MinHash struct {
+	seeds []uint64
+}
+
+func New(numHashes int, seed int64) *MinHash {
+	if numHashes <= 0 {
+		msg := fmt.Sprintf("invalid number of hashes: %d", numHashes)
+		panic(errors.New(msg))
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	seeds := make([]uint64, numHashes)
+
+	for i := range seeds {
+		seeds[i] = random.Uint64()
+	}
+
+	return &MinHash{seeds: seeds}
+}
+
+func (m *MinHash) Size() int {
+	return len(m.seeds)
+}
+
+func (m *MinHash) Signature(tokens []string) Signature {
+	signature := make(Signature, len(m.seeds))
+
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+
+	for _, token := range tokens {
+		base := hashtable.StringHash(token)
+
+		for i, seed := range m.seeds {
+			if h := hashtable.IntHash(base ^ seed); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+
+	return signature
+}
+
+func Similarity(a, b Signature) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	equal := 0
+
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(a))
+}
+
+func Jaccard(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, token := range a {
+		set[token] = true
+	}
+
+	other := make(map[string]bool, len(b))
+	intersection := 0
+
+	for _, token := range b {
+		if other[token] {
+			continue
+		}
+
+		other[token] = true
+
+		if set[token] {
+			intersection++
+		}
+	}
+
+	union := len(set) + len(other) - intersection
+	if union == 0 {
+		return 0
+	}
+
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,97 @@
+package minhash
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func shingles(text string, size int) []string {
+	words := strings.Fields(text)
+	result := make([]string, 0)
+
+	for i := 0; i+size <= len(words); i++ {
+		result = append(result, strings.Join(words[i:i+size], " "))
+	}
+
+	return result
+}
+
+func TestSimilarityApproximatesJaccard(t *testing.T) {
+	a := make([]string, 0)
+	b := make([]string, 0)
+
+	for i := 0; i < 300; i++ {
+		a = append(a, fmt.Sprint(i))
+	}
+
+	for i := 100; i < 400; i++ {
+		b = append(b, fmt.Sprint(i))
+	}
+
+	m := New(256, 1)
+	expected := Jaccard(a, b)
+	estimated := Similarity(m.Signature(a), m.Signature(b))
+
+	if math.Abs(expected-estimated) > 0.1 {
+		t.Errorf("Expected estimate to be close to %f, got %f", expected, estimated)
+	}
+}
+
+func TestIdenticalSetsHaveSimilarityOne(t *testing.T) {
+	m := New(64, 1)
+
+	if s := Similarity(m.Signature([]string{"a", "b"}), m.Signature([]string{"b", "a", "a"})); s != 1 {
+		t.Errorf("Expected similarity to be 1, got %f", s)
+	}
+}
+
+func TestBanding(t *testing.T) {
+	bands, rows := Banding(128, 0.8)
+
+	if bands*rows != 128 {
+		t.Errorf("Expected bands * rows to be 128, got %d * %d", bands, rows)
+	}
+
+	threshold := math.Pow(1/float64(bands), 1/float64(rows))
+
+	if math.Abs(threshold-0.8) > 0.1 {
+		t.Errorf("Expected threshold to be close to 0.8, got %f", threshold)
+	}
+}
+
+func TestLSHFindsNearDuplicates(t *testing.T) {
+	documents := map[string]string{
+		"original": "the quick brown fox jumps over the lazy dog near the river bank today",
+		"copy":     "the quick brown fox jumps over the lazy dog near the river bank tonight",
+		"other":    "a completely different sentence about hash tables and probabilistic sketches",
+	}
+
+	m := New(128, 7)
+	bands, rows := Banding(128, 0.5)
+	lsh := NewLSH[string](bands, rows)
+
+	for name, text := range documents {
+		lsh.Add(name, m.Signature(shingles(text, 2)))
+	}
+
+	matches := lsh.Query(m.Signature(shingles(documents["original"], 2)), 0.5)
+
+	found := make(map[string]bool)
+	for _, match := range matches {
+		found[match] = true
+	}
+
+	if !found["original"] || !found["copy"] || found["other"] {
+		t.Errorf("Expected to match original and copy only, got %v", matches)
+	}
+}
+
+func TestLSHRejectsShortSignatures(t *testing.T) {
+	lsh := NewLSH[int](4, 4)
+
+	if err := lsh.Add(1, make(Signature, 8)); err == nil {
+		t.Errorf("Expected error for a signature shorter than bands * rows")
+	}
+}
@@ -0,0 +1,292 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Pipeline tracks the goroutines of its stages. The first stage error
+// cancels the shared context so every other stage stops early.
+type Pipeline struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+func New(ctx context.Context) *Pipeline {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Pipeline{ctx: ctx, cancel: cancel}
+}
+
+func (p *Pipeline) Context() context.Context {
+	return p.ctx
+}
+
+func (p *Pipeline) fail(err error) {
+	p.once.Do(func() {
+		p.err = err
+		p.cancel()
+	})
+}
+
+func (p *Pipeline) spawn(f func()) {
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		f()
+	}()
+}
+
+// Wait blocks until every stage has finished and returns the first error,
+// or the context error when the parent context was cancelled.
+func (p *Pipeline) Wait() error {
+	p.wg.Wait()
+
+	p.once.Do(func() {
+		p.err = p.ctx.Err()
+	})
+
+	p.cancel()
+
+	return p.err
+}
+
+func send[E any](ctx context.Context, out chan<- E, element E) bool {
+	select {
+	case out <- element:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive reads the next element of in, giving up when the context is
+// cancelled even though in stays open
+func receive[E any](ctx context.Context, in <-chan E) (element E, ok bool) {
+	select {
+	case element, ok = <-in:
+		return element, ok
+	case <-ctx.Done():
+		return element, false
+	}
+}
+
+func validate(workers, buffer int) {
+	if workers <= 0 || buffer < 0 {
+		msg := fmt.Sprintf("invalid stage parameters: %d workers, buffer %d", workers, buffer)
+		panic(errors.New(msg))
+	}
+}
+
+func From[E any](p *Pipeline, source <-chan E, buffer int) <-chan E {
+	validate(1, buffer)
+
+	out := make(chan E, buffer)
+
+	p.spawn(func() {
+		defer close(out)
+
+		for {
+			element, ok := receive(p.ctx, source)
+
+			if !ok || !send(p.ctx, out, element) {
+				return
+			}
+		}
+	})
+
+	return out
+}
+
+func FromSlice[E any](p *Pipeline, elements []E, buffer int) <-chan E {
+	validate(1, buffer)
+
+	out := make(chan E, buffer)
+
+	p.spawn(func() {
+		defer close(out)
+
+		for _, element := range elements {
+			if !send(p.ctx, out, element) {
+				return
+			}
+		}
+	})
+
+	return out
+}
+
+// Map runs f on up to workers elements at a time. Outputs are emitted as
+// soon as they are ready, so their order is not preserved when workers > 1.
+func Map[In, Out any](p *Pipeline, in <-chan In, workers, buffer int, f func(context.Context, In) (Out, error)) <-chan Out {
+	validate(workers, buffer)
+
+	out := make(chan Out, buffer)
+	stage := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		stage.Add(1)
+
+		p.spawn(func() {
+			defer stage.Done()
+
+			for {
+				element, ok := receive(p.ctx, in)
+
+				if !ok {
+					return
+				}
+
+				result, err := f(p.ctx, element)
+				if err != nil {
+					p.fail(err)
+					return
+				}
+
+				if !send(p.ctx, out, result) {
+					return
+				}
+			}
+		})
+	}
+
+	p.spawn(func() {
+		stage.Wait()
+		close(out)
+	})
+
+	return out
+}
+
+func Filter[E any](p *Pipeline, in <-chan E, workers, buffer int, f func(context.Context, E) (bool, error)) <-chan E {
+	validate(workers, buffer)
+
+	out := make(chan E, buffer)
+	stage := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		stage.Add(1)
+
+		p.spawn(func() {
+			defer stage.Done()
+
+			for {
+				element, ok := receive(p.ctx, in)
+
+				if !ok {
+					return
+				}
+
+				keep, err := f(p.ctx, element)
+				if err != nil {
+					p.fail(err)
+					return
+				}
+
+				if keep && !send(p.ctx, out, element) {
+					return
+				}
+			}
+		})
+	}
+
+	p.spawn(func() {
+		stage.Wait()
+		close(out)
+	})
+
+	return out
+}
+
+// Merge fans several inputs into one output
+func Merge[E any](p *Pipeline, buffer int, inputs ...<-chan E) <-chan E {
+	validate(1, buffer)
+
+	out := make(chan E, buffer)
+	stage := sync.WaitGroup{}
+
+	for _, in := range inputs {
+		in := in
+		stage.Add(1)
+
+		p.spawn(func() {
+			defer stage.Done()
+
+			for {
+				element, ok := receive(p.ctx, in)
+
+				if !ok || !send(p.ctx, out, element) {
+					return
+				}
+			}
+		})
+	}
+
+	p.spawn(func() {
+		stage.Wait()
+		close(out)
+	})
+
+	return out
+}
+
+// Split fans one input out to n outputs, each element going to whichever
+// output is ready to receive it first
+func Split[E any](p *Pipeline, in <-chan E, n, buffer int) []<-chan E {
+	validate(n, buffer)
+
+	outputs := make([]<-chan E, n)
+	out := make(chan E)
+
+	for i := range outputs {
+		branch := make(chan E, buffer)
+		outputs[i] = branch
+
+		p.spawn(func() {
+			defer close(branch)
+
+			for {
+				element, ok := receive(p.ctx, out)
+
+				if !ok || !send(p.ctx, branch, element) {
+					return
+				}
+			}
+		})
+	}
+
+	p.spawn(func() {
+		defer close(out)
+
+		for {
+			element, ok := receive(p.ctx, in)
+
+			if !ok || !send(p.ctx, out, element) {
+				return
+			}
+		}
+	})
+
+	return outputs
+}
+
+// Collect drains in and waits for the pipeline to finish
+func Collect[E any](p *Pipeline, in <-chan E) ([]E, error) {
+	elements := make([]E, 0)
+
+	for element := range in {
+		elements = append(elements, element)
+	}
+
+	if err := p.Wait(); err != nil {
+		return nil, err
+	}
+
+	return elements, nil
+}
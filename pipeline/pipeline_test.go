@@ -0,0 +1,172 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func numbers(n int) []int {
+	elements := make([]int, n)
+
+	for i := range elements {
+		elements[i] = i
+	}
+
+	return elements
+}
+
+func TestMapAndFilter(t *testing.T) {
+	p := New(context.Background())
+
+	source := FromSlice(p, numbers(100), 10)
+	squares := Map(p, source, 4, 10, func(_ context.Context, i int) (int, error) {
+		return i * i, nil
+	})
+	even := Filter(p, squares, 2, 0, func(_ context.Context, i int) (bool, error) {
+		return i%2 == 0, nil
+	})
+
+	result, err := Collect(p, even)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if len(result) != 50 {
+		t.Errorf("Expected 50 elements, got %d", len(result))
+	}
+
+	for _, square := range result {
+		if square%2 != 0 {
+			t.Errorf("Expected only even squares, got %d", square)
+		}
+	}
+}
+
+func TestMapRespectsWorkerLimit(t *testing.T) {
+	p := New(context.Background())
+	var running, peak int32
+
+	out := Map(p, FromSlice(p, numbers(50), 0), 3, 0, func(_ context.Context, i int) (int, error) {
+		current := atomic.AddInt32(&running, 1)
+
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		return i, nil
+	})
+
+	Collect(p, out)
+
+	if peak > 3 {
+		t.Errorf("Expected at most 3 concurrent workers, got %d", peak)
+	}
+}
+
+func TestErrorCancelsPipeline(t *testing.T) {
+	p := New(context.Background())
+	failure := errors.New("boom")
+	var processed int32
+
+	out := Map(p, FromSlice(p, numbers(10_000), 0), 2, 0, func(ctx context.Context, i int) (int, error) {
+		atomic.AddInt32(&processed, 1)
+
+		if i == 10 {
+			return 0, failure
+		}
+
+		return i, nil
+	})
+
+	if _, err := Collect(p, out); err != failure {
+		t.Errorf("Expected the stage error, got %v", err)
+	}
+
+	if processed > 1000 {
+		t.Errorf("Expected the pipeline to stop early, processed %d", processed)
+	}
+}
+
+func TestSplitAndMerge(t *testing.T) {
+	p := New(context.Background())
+
+	branches := Split(p, FromSlice(p, numbers(100), 0), 3, 1)
+	merged := Merge(p, 0, branches...)
+
+	result, err := Collect(p, merged)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	sort.Ints(result)
+
+	for i, element := range result {
+		if element != i {
+			t.Fatalf("Expected element to be %d, got %d", i, element)
+		}
+	}
+
+	if len(result) != 100 {
+		t.Errorf("Expected 100 elements, got %d", len(result))
+	}
+}
+
+func TestParentCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New(ctx)
+
+	source := make(chan int)
+	out := From(p, source, 0)
+
+	cancel()
+
+	if _, err := Collect(p, out); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCancellationStopsStagesReadingOpenChannels(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New(ctx)
+
+	// Stages read the source directly, without From, so only their own
+	// select on the context lets them return while it stays open
+	source := make(chan int)
+	identity := func(_ context.Context, element int) (int, error) { return element, nil }
+	keep := func(context.Context, int) (bool, error) { return true, nil }
+
+	mapped := Map(p, source, 2, 0, identity)
+	filtered := Filter(p, source, 2, 0, keep)
+	branches := Split(p, source, 2, 0)
+	merged := Merge(p, 0, append(branches, mapped, filtered)...)
+
+	cancel()
+
+	done := make(chan error)
+
+	go func() {
+		_, err := Collect(p, merged)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected every stage to stop after cancellation")
+	}
+}
@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrTimeout = errors.New("queue: timeout")
+
+// BlockingQueue is a bounded FIFO queue stored in a ring buffer. Producers
+// block while it is full and consumers while it is empty, on a condition
+// variable each. After Close producers are rejected and consumers drain the
+// remaining elements.
+type BlockingQueue[E any] struct {
+	mutex    sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	elements []E
+	head     int
+	size     int
+	closed   bool
+}
+
+func NewBlockingQueue[E any](capacity int) *BlockingQueue[E] {
+	if capacity <= 0 {
+		msg := fmt.Sprintf("invalid capacity: %d", capacity)
+		panic(errors.New(msg))
+	}
+
+	queue := BlockingQueue[E]{
+		elements: make([]E, capacity),
+	}
+
+	queue.notEmpty = sync.NewCond(&queue.mutex)
+	queue.notFull = sync.NewCond(&queue.mutex)
+
+	return &queue
+}
+
+// Put blocks until there is room for value
+func (q *BlockingQueue[E]) Put(value E) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for q.size == len(q.elements) && !q.closed {
+		q.notFull.Wait()
+	}
+
+	return q.push(value)
+}
+
+// Offer waits at most timeout for room for value, a timeout of zero or less
+// does not wait at all
+func (q *BlockingQueue[E]) Offer(value E, timeout time.Duration) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	deadline := time.Now().Add(timeout)
+
+	for q.size == len(q.elements) && !q.closed {
+		if !waitUntil(q.notFull, deadline) {
+			return ErrTimeout
+		}
+	}
+
+	return q.push(value)
+}
+
+// Take blocks until an element is available or the queue is closed and
+// drained
+func (q *BlockingQueue[E]) Take() (E, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for q.size == 0 && !q.closed {
+		q.notEmpty.Wait()
+	}
+
+	return q.pop()
+}
+
+// Poll waits at most timeout for an element, a timeout of zero or less does
+// not wait at all
+func (q *BlockingQueue[E]) Poll(timeout time.Duration) (E, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	deadline := time.Now().Add(timeout)
+
+	for q.size == 0 && !q.closed {
+		if !waitUntil(q.notEmpty, deadline) {
+			var zero E
+			return zero, ErrTimeout
+		}
+	}
+
+	return q.pop()
+}
+
+// waitUntil waits on cond, whose lock is held, until it is signalled or the
+// deadline passes. It reports false once the deadline has passed.
+func waitUntil(cond *sync.Cond, deadline time.Time) bool {
+	remaining := time.Until(deadline)
+
+	if remaining <= 0 {
+		return false
+	}
+
+	// sync.Cond has no timed wait, so a timer wakes every waiter at the
+	// deadline and each one checks its own
+	timer := time.AfterFunc(remaining, func() {
+		cond.L.Lock()
+		defer cond.L.Unlock()
+
+		cond.Broadcast()
+	})
+
+	cond.Wait()
+	timer.Stop()
+
+	return true
+}
+
+func (q *BlockingQueue[E]) push(value E) error {
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.elements[(q.head+q.size)%len(q.elements)] = value
+	q.size++
+	q.notEmpty.Signal()
+
+	return nil
+}
+
+func (q *BlockingQueue[E]) pop() (value E, err error) {
+	if q.size == 0 {
+		return value, ErrClosed
+	}
+
+	var zero E
+
+	value = q.elements[q.head]
+	q.elements[q.head] = zero
+	q.head = (q.head + 1) % len(q.elements)
+	q.size--
+	q.notFull.Signal()
+
+	return value, nil
+}
+
+func (q *BlockingQueue[E]) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.size
+}
+
+func (q *BlockingQueue[E]) Capacity() int {
+	return len(q.elements)
+}
+
+// Close rejects new elements and wakes every blocked producer and consumer
+func (q *BlockingQueue[E]) Close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
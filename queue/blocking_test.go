@@ -0,0 +1,150 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueuePutAndTake(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+
+	q.Put(1)
+	q.Put(2)
+
+	if q.Len() != 2 || q.Capacity() != 2 {
+		t.Errorf("Expected a full queue of 2, got %d of %d", q.Len(), q.Capacity())
+	}
+
+	for expected := 1; expected <= 2; expected++ {
+		if value, err := q.Take(); err != nil || value != expected {
+			t.Errorf("Expected value to be %d, got %d (%v)", expected, value, err)
+		}
+	}
+}
+
+func TestBlockingQueueTimeouts(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+
+	start := time.Now()
+
+	if _, err := q.Poll(20 * time.Millisecond); err != ErrTimeout {
+		t.Errorf("Expected ErrTimeout, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected Poll to wait for the timeout, returned after %v", elapsed)
+	}
+
+	if err := q.Offer(1, 0); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := q.Offer(2, 10*time.Millisecond); err != ErrTimeout {
+		t.Errorf("Expected ErrTimeout on a full queue, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Take()
+	}()
+
+	if err := q.Offer(3, time.Second); err != nil {
+		t.Errorf("Expected Offer to succeed once room is made, got %v", err)
+	}
+
+	if value, err := q.Poll(0); err != nil || value != 3 {
+		t.Errorf("Expected value to be 3, got %d (%v)", value, err)
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	q.Put(1)
+
+	done := make(chan error)
+
+	go func() {
+		done <- q.Put(2)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+
+	if err := <-done; err != ErrClosed {
+		t.Errorf("Expected the blocked producer to get ErrClosed, got %v", err)
+	}
+
+	if value, err := q.Take(); err != nil || value != 1 {
+		t.Errorf("Expected to drain 1 after Close, got %d (%v)", value, err)
+	}
+
+	if _, err := q.Take(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed once drained, got %v", err)
+	}
+
+	if _, err := q.Poll(time.Second); err != ErrClosed {
+		t.Errorf("Expected ErrClosed from Poll, got %v", err)
+	}
+}
+
+func TestBlockingQueueProducersAndConsumers(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+
+	var producers, consumers sync.WaitGroup
+
+	sums := make([]int, 4)
+
+	for p := 0; p < 4; p++ {
+		producers.Add(1)
+
+		go func() {
+			defer producers.Done()
+
+			for i := 1; i <= 1000; i++ {
+				q.Put(i)
+			}
+		}()
+	}
+
+	for c := range sums {
+		consumers.Add(1)
+
+		go func() {
+			defer consumers.Done()
+
+			for {
+				value, err := q.Poll(time.Second)
+				if err != nil {
+					return
+				}
+
+				sums[c] += value
+			}
+		}()
+	}
+
+	producers.Wait()
+	q.Close()
+	consumers.Wait()
+
+	total := 0
+
+	for _, sum := range sums {
+		total += sum
+	}
+
+	if total != 4*500500 {
+		t.Errorf("Expected total to be %d, got %d", 4*500500, total)
+	}
+}
+
+func TestInvalidBlockingQueueCapacity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewBlockingQueue[int](0)
+}
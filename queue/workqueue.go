@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"algorithms/hashtable"
+)
+
+var ErrClosed = errors.New("queue: closed")
+
+type Delivery[E any] struct {
+	ID       uint64
+	Value    E
+	Attempts int
+}
+
+// WorkQueue hands out elements in enqueue order and tracks them until they
+// are acknowledged. A negative acknowledgment puts the element back at its
+// original position so that order is preserved across re-deliveries.
+type WorkQueue[E any] struct {
+	mutex    sync.Mutex
+	ready    *sync.Cond
+	pending  []Delivery[E]
+	inFlight *hashtable.HashTable[uint64, Delivery[E]]
+	nextID   uint64
+	closed   bool
+}
+
+func NewWorkQueue[E any]() *WorkQueue[E] {
+	queue := WorkQueue[E]{
+		pending:  make([]Delivery[E], 0),
+		inFlight: hashtable.NewHashTable[uint64, Delivery[E]](),
+	}
+
+	queue.ready = sync.NewCond(&queue.mutex)
+
+	return &queue
+}
+
+func (q *WorkQueue[E]) Enqueue(value E) (uint64, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.closed {
+		return 0, ErrClosed
+	}
+
+	q.nextID++
+	q.pending = append(q.pending, Delivery[E]{ID: q.nextID, Value: value})
+	q.ready.Signal()
+
+	return q.nextID, nil
+}
+
+// Dequeue blocks until an element is available or the queue is closed and drained
+func (q *WorkQueue[E]) Dequeue() (Delivery[E], error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for len(q.pending) == 0 && !q.closed {
+		q.ready.Wait()
+	}
+
+	if len(q.pending) == 0 {
+		return Delivery[E]{}, ErrClosed
+	}
+
+	return q.take(), nil
+}
+
+func (q *WorkQueue[E]) TryDequeue() (Delivery[E], bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.pending) == 0 {
+		return Delivery[E]{}, false
+	}
+
+	return q.take(), true
+}
+
+func (q *WorkQueue[E]) take() Delivery[E] {
+	delivery := q.pending[0]
+	q.pending[0] = Delivery[E]{}
+	q.pending = q.pending[1:]
+
+	delivery.Attempts++
+	q.inFlight.Insert(delivery.ID, delivery)
+
+	return delivery
+}
+
+func (q *WorkQueue[E]) Ack(id uint64) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, err := q.release(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *WorkQueue[E]) Nack(id uint64) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delivery, err := q.release(id)
+	if err != nil {
+		return err
+	}
+
+	index := sort.Search(len(q.pending), func(i int) bool {
+		return q.pending[i].ID > delivery.ID
+	})
+
+	q.pending = append(q.pending, Delivery[E]{})
+	copy(q.pending[index+1:], q.pending[index:])
+	q.pending[index] = delivery
+
+	q.ready.Signal()
+
+	return nil
+}
+
+func (q *WorkQueue[E]) release(id uint64) (Delivery[E], error) {
+	delivery, found := q.inFlight.Delete(id)
+
+	if !found {
+		msg := fmt.Sprintf("queue: delivery not in flight: %d", id)
+		return Delivery[E]{}, errors.New(msg)
+	}
+
+	return delivery, nil
+}
+
+func (q *WorkQueue[E]) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.pending)
+}
+
+func (q *WorkQueue[E]) InFlight() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return int(q.inFlight.Size())
+}
+
+// Close rejects new elements and wakes blocked consumers once the queue drains
+func (q *WorkQueue[E]) Close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.closed = true
+	q.ready.Broadcast()
+}
@@ -0,0 +1,150 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDequeueInEnqueueOrder(t *testing.T) {
+	queue := NewWorkQueue[string]()
+
+	queue.Enqueue("foo")
+	queue.Enqueue("bar")
+
+	first, _ := queue.Dequeue()
+	second, _ := queue.Dequeue()
+
+	if first.Value != "foo" || second.Value != "bar" {
+		t.Errorf("Expected foo then bar, got %s then %s", first.Value, second.Value)
+	}
+
+	if queue.InFlight() != 2 {
+		t.Errorf("Expected 2 in flight, got %d", queue.InFlight())
+	}
+}
+
+func TestNackRedeliversInOriginalOrder(t *testing.T) {
+	queue := NewWorkQueue[int]()
+
+	for i := 1; i <= 3; i++ {
+		queue.Enqueue(i)
+	}
+
+	first, _ := queue.Dequeue()
+	queue.Dequeue()
+
+	if err := queue.Nack(first.ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	redelivered, _ := queue.Dequeue()
+
+	if redelivered.Value != 1 {
+		t.Errorf("Expected value to be 1, got %d", redelivered.Value)
+	}
+
+	if redelivered.Attempts != 2 {
+		t.Errorf("Expected attempts to be 2, got %d", redelivered.Attempts)
+	}
+
+	next, _ := queue.Dequeue()
+
+	if next.Value != 3 {
+		t.Errorf("Expected value to be 3, got %d", next.Value)
+	}
+}
+
+func TestAckRemovesFromInFlight(t *testing.T) {
+	queue := NewWorkQueue[int]()
+
+	queue.Enqueue(1)
+	delivery, _ := queue.Dequeue()
+
+	if err := queue.Ack(delivery.ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if queue.InFlight() != 0 {
+		t.Errorf("Expected nothing in flight, got %d", queue.InFlight())
+	}
+
+	if err := queue.Ack(delivery.ID); err == nil {
+		t.Errorf("Expected error when acknowledging twice")
+	}
+}
+
+func TestCloseWakesConsumers(t *testing.T) {
+	queue := NewWorkQueue[int]()
+
+	queue.Enqueue(1)
+	queue.Close()
+
+	if _, err := queue.Enqueue(2); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+
+	if _, err := queue.Dequeue(); err != nil {
+		t.Errorf("Expected pending element to be delivered after close, got %s", err)
+	}
+
+	if _, err := queue.Dequeue(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestConcurrentProcessing(t *testing.T) {
+	queue := NewWorkQueue[int]()
+	wg := sync.WaitGroup{}
+	processed := sync.WaitGroup{}
+	mutex := sync.Mutex{}
+	seen := make(map[int]int)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				delivery, err := queue.Dequeue()
+				if err != nil {
+					return
+				}
+
+				// Fail every first attempt on odd values to exercise re-delivery
+				if delivery.Value%2 == 1 && delivery.Attempts == 1 {
+					queue.Nack(delivery.ID)
+					continue
+				}
+
+				mutex.Lock()
+				seen[delivery.Value]++
+				mutex.Unlock()
+
+				queue.Ack(delivery.ID)
+				processed.Done()
+			}
+		}()
+	}
+
+	processed.Add(1000)
+
+	for i := 0; i < 1000; i++ {
+		queue.Enqueue(i)
+	}
+
+	processed.Wait()
+
+	queue.Close()
+	wg.Wait()
+
+	if len(seen) != 1000 {
+		t.Errorf("Expected 1000 processed values, got %d", len(seen))
+	}
+
+	for value, count := range seen {
+		if count != 1 {
+			t.Errorf("Expected value %d to be processed once, got %d", value, count)
+		}
+	}
+}
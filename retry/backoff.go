@@ -0,0 +1,162 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff produces the delays between successive attempts
+type Backoff interface {
+	Next() time.Duration
+	Reset()
+}
+
+type Jitter int
+
+const (
+	// NoJitter waits exactly base * 2^attempt
+	NoJitter Jitter = iota
+	// FullJitter waits a uniform random time up to base * 2^attempt
+	FullJitter
+	// EqualJitter waits half of base * 2^attempt plus a random time up to
+	// the other half
+	EqualJitter
+)
+
+// Exponential doubles its delay after every attempt up to a cap
+type Exponential struct {
+	base    time.Duration
+	cap     time.Duration
+	jitter  Jitter
+	attempt int
+	random  func() float64
+}
+
+func NewExponential(base, cap time.Duration, jitter Jitter) *Exponential {
+	checkDelays(base, cap)
+
+	if jitter < NoJitter || jitter > EqualJitter {
+		msg := fmt.Sprintf("invalid jitter: %d", jitter)
+		panic(errors.New(msg))
+	}
+
+	return &Exponential{base: base, cap: cap, jitter: jitter, random: rand.Float64}
+}
+
+func checkDelays(base, cap time.Duration) {
+	if base <= 0 || cap < base {
+		msg := fmt.Sprintf("invalid delays: base %v, cap %v", base, cap)
+		panic(errors.New(msg))
+	}
+}
+
+func (e *Exponential) Next() time.Duration {
+	delay := float64(e.cap)
+
+	if e.attempt < 62 {
+		delay = min(delay, float64(e.base)*math.Exp2(float64(e.attempt)))
+		e.attempt++
+	}
+
+	switch e.jitter {
+	case FullJitter:
+		delay *= e.random()
+	case EqualJitter:
+		delay = delay/2 + delay/2*e.random()
+	}
+
+	return time.Duration(delay)
+}
+
+func (e *Exponential) Reset() {
+	e.attempt = 0
+}
+
+// Decorrelated draws every delay uniformly between base and three times the
+// previous one, capped, which spreads competing clients apart faster than
+// jittering a fixed schedule
+type Decorrelated struct {
+	base     time.Duration
+	cap      time.Duration
+	previous time.Duration
+	random   func() float64
+}
+
+func NewDecorrelated(base, cap time.Duration) *Decorrelated {
+	checkDelays(base, cap)
+
+	return &Decorrelated{base: base, cap: cap, previous: base, random: rand.Float64}
+}
+
+func (d *Decorrelated) Next() time.Duration {
+	upper := min(float64(d.cap), 3*float64(d.previous))
+	delay := float64(d.base) + (upper-float64(d.base))*d.random()
+	d.previous = time.Duration(delay)
+
+	return d.previous
+}
+
+func (d *Decorrelated) Reset() {
+	d.previous = d.base
+}
+
+type permanentError struct {
+	err error
+}
+
+func (p permanentError) Error() string {
+	return p.err.Error()
+}
+
+func (p permanentError) Unwrap() error {
+	return p.err
+}
+
+// Permanent marks err as not worth retrying: Do returns it unwrapped at once
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return permanentError{err: err}
+}
+
+// Do calls fn until it succeeds, returns a Permanent error or has been called
+// attempts times, sleeping between calls as backoff says. It returns the last
+// error, or the context error if ctx ends while waiting.
+func Do(ctx context.Context, backoff Backoff, attempts int, fn func(ctx context.Context) error) error {
+	if attempts <= 0 {
+		msg := fmt.Sprintf("invalid number of attempts: %d", attempts)
+		panic(errors.New(msg))
+	}
+
+	backoff.Reset()
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+
+		var permanent permanentError
+
+		switch {
+		case err == nil:
+			return nil
+		case errors.As(err, &permanent):
+			return permanent.err
+		case attempt == attempts:
+			return err
+		}
+
+		timer := time.NewTimer(backoff.Next())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExponential(t *testing.T) {
+	e := NewExponential(10*time.Millisecond, 50*time.Millisecond, NoJitter)
+	expected := []time.Duration{10, 20, 40, 50, 50}
+
+	for i, want := range expected {
+		if got := e.Next(); got != want*time.Millisecond {
+			t.Errorf("Expected delay %d to be %v, got %v", i, want*time.Millisecond, got)
+		}
+	}
+
+	e.Reset()
+
+	if got := e.Next(); got != 10*time.Millisecond {
+		t.Errorf("Expected 10ms after Reset, got %v", got)
+	}
+}
+
+func TestExponentialJitter(t *testing.T) {
+	full := NewExponential(time.Second, time.Minute, FullJitter)
+	full.random = func() float64 { return 0.25 }
+
+	full.Next()
+
+	if got := full.Next(); got != 500*time.Millisecond {
+		t.Errorf("Expected full jitter to scale 2s to 500ms, got %v", got)
+	}
+
+	equal := NewExponential(time.Second, time.Minute, EqualJitter)
+	equal.random = func() float64 { return 0.5 }
+
+	if got := equal.Next(); got != 750*time.Millisecond {
+		t.Errorf("Expected equal jitter to give 750ms, got %v", got)
+	}
+}
+
+func TestExponentialDoesNotOverflow(t *testing.T) {
+	e := NewExponential(time.Second, time.Hour, NoJitter)
+
+	for i := 0; i < 100; i++ {
+		if got := e.Next(); got <= 0 || got > time.Hour {
+			t.Fatalf("Expected delay %d to stay within the cap, got %v", i, got)
+		}
+	}
+}
+
+func TestDecorrelated(t *testing.T) {
+	d := NewDecorrelated(10*time.Millisecond, time.Second)
+	d.random = func() float64 { return 1 }
+
+	expected := []time.Duration{30, 90, 270, 810, 1000}
+
+	for i, want := range expected {
+		if got := d.Next(); got != want*time.Millisecond {
+			t.Errorf("Expected delay %d to be %v, got %v", i, want*time.Millisecond, got)
+		}
+	}
+
+	d.Reset()
+	d.random = func() float64 { return 0 }
+
+	if got := d.Next(); got != 10*time.Millisecond {
+		t.Errorf("Expected the base delay, got %v", got)
+	}
+}
+
+func TestInvalidDelays(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewDecorrelated(time.Second, time.Millisecond)
+}
+
+func TestDo(t *testing.T) {
+	backoff := NewExponential(time.Millisecond, time.Millisecond, NoJitter)
+	failure := errors.New("failure")
+	calls := 0
+
+	err := Do(context.Background(), backoff, 5, func(context.Context) error {
+		calls++
+
+		if calls < 3 {
+			return failure
+		}
+
+		return nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Errorf("Expected success on the third call, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = Do(context.Background(), backoff, 4, func(context.Context) error {
+		calls++
+		return failure
+	})
+
+	if err != failure || calls != 4 {
+		t.Errorf("Expected the last error after 4 calls, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = Do(context.Background(), backoff, 4, func(context.Context) error {
+		calls++
+		return Permanent(failure)
+	})
+
+	if err != failure || calls != 1 {
+		t.Errorf("Expected a permanent error to stop at once, got %v after %d calls", err, calls)
+	}
+}
+
+func TestDoHonoursContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := Do(ctx, NewExponential(time.Hour, time.Hour, NoJitter), 3, func(context.Context) error {
+		return errors.New("failure")
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("retry: circuit open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Breaker is a circuit breaker. It lets calls through while closed and opens
+// after threshold consecutive failures, rejecting calls for the cooldown.
+// Then it turns half-open and lets a single probe through: its success closes
+// the circuit again and its failure reopens it for another cooldown.
+type Breaker struct {
+	mutex     sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     State
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 || cooldown <= 0 {
+		msg := fmt.Sprintf("invalid breaker: threshold %d, cooldown %v", threshold, cooldown)
+		panic(errors.New(msg))
+	}
+
+	return &Breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.advance()
+
+	return b.state
+}
+
+func (b *Breaker) advance() {
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.state = HalfOpen
+		b.probing = false
+	}
+}
+
+// Allow reports whether a call may proceed, returning ErrOpen otherwise. An
+// allowed call must be followed by Success or Failure.
+func (b *Breaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.advance()
+
+	switch {
+	case b.state == Open:
+		return ErrOpen
+	case b.state == HalfOpen && b.probing:
+		return ErrOpen
+	case b.state == HalfOpen:
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Success and Failure of calls that were allowed before the circuit opened
+// are ignored while it stays open
+func (b *Breaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == Open {
+		return
+	}
+
+	b.state = Closed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *Breaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == Open {
+		return
+	}
+
+	b.failures++
+
+	if b.state == HalfOpen || b.failures >= b.threshold {
+		b.state = Open
+		b.openedAt = b.now()
+		b.probing = false
+	}
+}
+
+// Execute runs fn when the breaker allows it and records its outcome
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+
+	if err != nil {
+		b.Failure()
+	} else {
+		b.Success()
+	}
+
+	return err
+}
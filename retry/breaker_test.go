@@ -0,0 +1,89 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func TestBreaker(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(0, 0)}
+	b := NewBreaker(2, time.Minute)
+	b.now = clock.now
+	failure := errors.New("failure")
+
+	b.Execute(func() error { return failure })
+
+	if b.State() != Closed {
+		t.Errorf("Expected state to be closed, got %v", b.State())
+	}
+
+	b.Execute(func() error { return failure })
+
+	if b.State() != Open {
+		t.Errorf("Expected state to be open, got %v", b.State())
+	}
+
+	calls := 0
+
+	if err := b.Execute(func() error { calls++; return nil }); err != ErrOpen || calls != 0 {
+		t.Errorf("Expected ErrOpen without a call, got %v after %d calls", err, calls)
+	}
+
+	clock.current = clock.current.Add(time.Minute)
+
+	if b.State() != HalfOpen {
+		t.Errorf("Expected state to be half-open, got %v", b.State())
+	}
+
+	if err := b.Allow(); err != nil {
+		t.Errorf("Expected the probe to be allowed, got %v", err)
+	}
+
+	if err := b.Allow(); err != ErrOpen {
+		t.Errorf("Expected a second probe to be rejected, got %v", err)
+	}
+
+	b.Failure()
+
+	if b.State() != Open {
+		t.Errorf("Expected a failed probe to reopen, got %v", b.State())
+	}
+
+	clock.current = clock.current.Add(time.Minute)
+
+	if err := b.Execute(func() error { return nil }); err != nil || b.State() != Closed {
+		t.Errorf("Expected a successful probe to close, got %v in state %v", err, b.State())
+	}
+}
+
+func TestBreakerResetsFailuresOnSuccess(t *testing.T) {
+	b := NewBreaker(2, time.Minute)
+
+	b.Failure()
+	b.Success()
+	b.Failure()
+
+	if b.State() != Closed {
+		t.Errorf("Expected non-consecutive failures to keep it closed, got %v", b.State())
+	}
+}
+
+func TestBreakerIgnoresLateResults(t *testing.T) {
+	b := NewBreaker(1, time.Minute)
+
+	b.Failure()
+	b.Success()
+
+	if b.State() != Open {
+		t.Errorf("Expected a late success to be ignored, got %v", b.State())
+	}
+}
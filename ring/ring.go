@@ -0,0 +1,225 @@
+package ring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"algorithms/hashtable"
+)
+
+type point[N comparable] struct {
+	hash uint64
+	node N
+}
+
+// Ring maps keys to nodes by consistent hashing. Every node is placed at
+// replicas pseudo-random points of a circle of 64-bit hashes and a key
+// belongs to the first point at or after its own hash, so adding or removing
+// a node only moves the keys next to its points.
+type Ring[K any, N comparable] struct {
+	replicas int
+	keys     hashtable.Hasher[K]
+	hasher   hashtable.Hasher[N]
+	nodes    []N
+	points   []point[N]
+}
+
+func NewRing[K any, N comparable](replicas int, nodes ...N) *Ring[K, N] {
+	return NewRingWithHashers(replicas, hashtable.DefaultHasher[K](), hashtable.DefaultHasher[N](), nodes...)
+}
+
+func NewRingWithHashers[K any, N comparable](replicas int, keys hashtable.Hasher[K], hasher hashtable.Hasher[N], nodes ...N) *Ring[K, N] {
+	if replicas <= 0 {
+		msg := fmt.Sprintf("invalid number of replicas: %d", replicas)
+		panic(errors.New(msg))
+	}
+
+	r := &Ring[K, N]{
+		replicas: replicas,
+		keys:     keys,
+		hasher:   hasher,
+		nodes:    make([]N, 0, len(nodes)),
+		points:   make([]point[N], 0, replicas*len(nodes)),
+	}
+
+	for _, node := range nodes {
+		r.Add(node)
+	}
+
+	return r
+}
+
+func (r *Ring[K, N]) place(node N) []point[N] {
+	hash := hashtable.IntHash(r.hasher.Hash(node))
+	points := make([]point[N], r.replicas)
+
+	for i := range points {
+		points[i] = point[N]{hash: hashtable.IntHash(hash + uint64(i)), node: node}
+	}
+
+	return points
+}
+
+// Add places node on the ring, returning false if it was already there
+func (r *Ring[K, N]) Add(node N) bool {
+	if slices.Contains(r.nodes, node) {
+		return false
+	}
+
+	r.nodes = append(r.nodes, node)
+	r.points = append(r.points, r.place(node)...)
+
+	slices.SortStableFunc(r.points, func(a, b point[N]) int {
+		return compare(a.hash, b.hash)
+	})
+
+	return true
+}
+
+func (r *Ring[K, N]) Remove(node N) bool {
+	i := slices.Index(r.nodes, node)
+
+	if i < 0 {
+		return false
+	}
+
+	r.nodes = slices.Delete(r.nodes, i, i+1)
+	r.points = slices.DeleteFunc(r.points, func(p point[N]) bool {
+		return p.node == node
+	})
+
+	return true
+}
+
+// Nodes returns the nodes in the order they were added
+func (r *Ring[K, N]) Nodes() []N {
+	return slices.Clone(r.nodes)
+}
+
+func (r *Ring[K, N]) Size() int {
+	return len(r.nodes)
+}
+
+// Get returns the node key belongs to, which an empty ring lacks
+func (r *Ring[K, N]) Get(key K) (node N, found bool) {
+	if len(r.points) == 0 {
+		return
+	}
+
+	return r.successor(r.Hash(key)).node, true
+}
+
+// Hash is the position of key on the circle. The hasher's output is mixed
+// again because many hashers, like the default one for strings, leave the
+// high bits that decide the position poorly distributed.
+func (r *Ring[K, N]) Hash(key K) uint64 {
+	return hashtable.IntHash(r.keys.Hash(key))
+}
+
+// successor is the first point at or after hash, wrapping around the circle
+func (r *Ring[K, N]) successor(hash uint64) point[N] {
+	i, _ := slices.BinarySearchFunc(r.points, hash, func(p point[N], hash uint64) int {
+		return compare(p.hash, hash)
+	})
+
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.points[i]
+}
+
+func compare(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// Move is an arc of the circle whose keys would change node: those whose Hash
+// is after Start and up to End, wrapping past the largest hash when Start is
+// not below End. Start equal to End covers the whole circle.
+type Move[N comparable] struct {
+	Start uint64
+	End   uint64
+	From  N
+	To    N
+}
+
+// Plan describes what replacing the nodes of a ring would remap. Remapped
+// is the fraction of the circle the moves cover, which is also the expected
+// fraction of keys that change node when their hashes are uniform.
+type Plan[N comparable] struct {
+	Moves    []Move[N]
+	Remapped float64
+}
+
+// Rebalance plans replacing the nodes of the ring with nodes, without
+// changing it. Both the ring and nodes must be non-empty.
+func (r *Ring[K, N]) Rebalance(nodes []N) Plan[N] {
+	target := NewRingWithHashers(r.replicas, r.keys, r.hasher, nodes...)
+
+	if len(r.points) == 0 || len(target.points) == 0 {
+		msg := fmt.Sprintf("cannot rebalance between %d and %d nodes", len(r.nodes), len(target.nodes))
+		panic(errors.New(msg))
+	}
+
+	// Between two consecutive point hashes of either ring, every key keeps
+	// the same node in each of them
+	boundaries := make([]uint64, 0, len(r.points)+len(target.points))
+
+	for _, p := range r.points {
+		boundaries = append(boundaries, p.hash)
+	}
+
+	for _, p := range target.points {
+		boundaries = append(boundaries, p.hash)
+	}
+
+	slices.Sort(boundaries)
+	boundaries = slices.Compact(boundaries)
+
+	plan := Plan[N]{Moves: make([]Move[N], 0)}
+	previous := boundaries[len(boundaries)-1]
+
+	for _, end := range boundaries {
+		from, to := r.successor(end).node, target.successor(end).node
+
+		if from != to {
+			width := float64(end - previous)
+
+			if end == previous {
+				width = math.Exp2(64)
+			}
+
+			plan.Remapped += width / math.Exp2(64)
+			last := len(plan.Moves) - 1
+
+			if last >= 0 && plan.Moves[last].End == previous && plan.Moves[last].From == from && plan.Moves[last].To == to {
+				plan.Moves[last].End = end
+			} else {
+				plan.Moves = append(plan.Moves, Move[N]{Start: previous, End: end, From: from, To: to})
+			}
+		}
+
+		previous = end
+	}
+
+	// The arc wrapping around the circle may continue the last one
+	if moves := len(plan.Moves); moves > 1 {
+		first, last := plan.Moves[0], plan.Moves[moves-1]
+
+		if last.End == first.Start && last.From == first.From && last.To == first.To {
+			plan.Moves[0].Start = last.Start
+			plan.Moves = plan.Moves[:moves-1]
+		}
+	}
+
+	return plan
+}
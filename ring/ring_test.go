@@ -0,0 +1,122 @@
+package ring
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRingGet(t *testing.T) {
+	r := NewRing[string, string](100, "a", "b", "c")
+
+	if _, found := NewRing[string, string](10).Get("key"); found {
+		t.Errorf("Expected an empty ring to find no node")
+	}
+
+	counts := make(map[string]int)
+
+	for i := 0; i < 3000; i++ {
+		node, _ := r.Get(fmt.Sprint(i))
+		counts[node]++
+	}
+
+	for _, node := range []string{"a", "b", "c"} {
+		if counts[node] < 700 || counts[node] > 1300 {
+			t.Errorf("Expected about 1000 keys on %s, got %d", node, counts[node])
+		}
+	}
+
+	if r.Add("a") || !r.Remove("b") || r.Remove("b") || r.Size() != 2 {
+		t.Errorf("Expected nodes to be added and removed once")
+	}
+
+	for i := 0; i < 3000; i++ {
+		if node, _ := r.Get(fmt.Sprint(i)); node == "b" {
+			t.Fatalf("Expected no key on a removed node")
+		}
+	}
+
+	if fmt.Sprint(r.Nodes()) != "[a c]" {
+		t.Errorf("Expected [a c], got %v", r.Nodes())
+	}
+}
+
+func TestRingAddOnlyMovesKeysToTheNewNode(t *testing.T) {
+	before := NewRing[int, string](50, "a", "b", "c")
+	after := NewRing[int, string](50, "a", "b", "c", "d")
+
+	for i := 0; i < 5000; i++ {
+		from, _ := before.Get(i)
+
+		if to, _ := after.Get(i); to != from && to != "d" {
+			t.Fatalf("Expected key %d to stay on %s or move to d, got %s", i, from, to)
+		}
+	}
+}
+
+func moved[N comparable](plan Plan[N], hash uint64) (Move[N], bool) {
+	for _, move := range plan.Moves {
+		inside := hash > move.Start && hash <= move.End
+
+		if move.Start >= move.End {
+			inside = hash > move.Start || hash <= move.End
+		}
+
+		if inside {
+			return move, true
+		}
+	}
+
+	return Move[N]{}, false
+}
+
+func TestRebalance(t *testing.T) {
+	r := NewRing[int, string](100, "a", "b", "c", "d")
+	nodes := []string{"a", "b", "d", "e", "f"}
+	plan := r.Rebalance(nodes)
+	target := NewRing[int, string](100, nodes...)
+
+	if r.Size() != 4 {
+		t.Errorf("Expected Rebalance to leave the ring unchanged")
+	}
+
+	changed := 0
+
+	for i := 0; i < 20000; i++ {
+		from, _ := r.Get(i)
+		to, _ := target.Get(i)
+		move, found := moved(plan, r.Hash(i))
+
+		if found != (from != to) || found && (move.From != from || move.To != to) {
+			t.Fatalf("Expected key %d moving from %s to %s to match the plan, got %v", i, from, to, move)
+		}
+
+		if from != to {
+			changed++
+		}
+	}
+
+	if fraction := float64(changed) / 20000; math.Abs(fraction-plan.Remapped) > 0.02 {
+		t.Errorf("Expected about %.3f of the keys to move, got %.3f", plan.Remapped, fraction)
+	}
+}
+
+func TestRebalanceWithoutChanges(t *testing.T) {
+	r := NewRing[int, int](10, 1, 2, 3)
+
+	if plan := r.Rebalance([]int{3, 2, 1}); len(plan.Moves) != 0 || plan.Remapped != 0 {
+		t.Errorf("Expected no moves, got %v", plan)
+	}
+
+	if plan := r.Rebalance([]int{4}); len(plan.Moves) == 0 || math.Abs(plan.Remapped-1) > 1e-9 {
+		t.Errorf("Expected every key to move, got %v", plan.Remapped)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	r.Rebalance(nil)
+}
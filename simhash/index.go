@@ -0,0 +1,110 @@
+package simhash
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"algorithms/hashtable"
+)
+
+type Match[K comparable] struct {
+	Key         K
+	Fingerprint uint64
+	Distance    int
+}
+
+type indexed[K comparable] struct {
+	key         K
+	fingerprint uint64
+}
+
+// Index finds fingerprints within maxDistance bits using multi-index hashing:
+// the 64 bits are split into maxDistance+1 blocks and, by the pigeonhole
+// principle, any fingerprint close enough agrees exactly on at least one block.
+type Index[K comparable] struct {
+	maxDistance int
+	blocks      []block
+	tables      []*hashtable.HashTable[uint64, []indexed[K]]
+	size        int
+}
+
+type block struct {
+	shift uint
+	mask  uint64
+}
+
+func NewIndex[K comparable](maxDistance int) *Index[K] {
+	if maxDistance < 0 || maxDistance > 63 {
+		msg := fmt.Sprintf("invalid max distance: %d", maxDistance)
+		panic(errors.New(msg))
+	}
+
+	count := maxDistance + 1
+	index := Index[K]{
+		maxDistance: maxDistance,
+		blocks:      make([]block, count),
+		tables:      make([]*hashtable.HashTable[uint64, []indexed[K]], count),
+	}
+
+	shift := uint(0)
+
+	for i := range index.blocks {
+		width := uint(64 / count)
+		if i < 64%count {
+			width++
+		}
+
+		index.blocks[i] = block{shift: shift, mask: (1<<width - 1) << shift}
+		index.tables[i] = hashtable.NewHashTable[uint64, []indexed[K]]()
+		shift += width
+	}
+
+	return &index
+}
+
+func (x *Index[K]) Add(key K, fingerprint uint64) {
+	for i, b := range x.blocks {
+		part := fingerprint & b.mask
+		entries, _ := x.tables[i].TryGet(part)
+		x.tables[i].Insert(part, append(entries, indexed[K]{key: key, fingerprint: fingerprint}))
+	}
+
+	x.size++
+}
+
+func (x *Index[K]) Len() int {
+	return x.size
+}
+
+// Query returns the indexed fingerprints within the maximum distance, nearest first
+func (x *Index[K]) Query(fingerprint uint64) []Match[K] {
+	seen := hashtable.NewHashTable[K, bool]()
+	matches := make([]Match[K], 0)
+
+	for i, b := range x.blocks {
+		entries, _ := x.tables[i].TryGet(fingerprint & b.mask)
+
+		for _, entry := range entries {
+			if seen.Contains(entry.key) {
+				continue
+			}
+
+			seen.Insert(entry.key, true)
+
+			if distance := Distance(fingerprint, entry.fingerprint); distance <= x.maxDistance {
+				matches = append(matches, Match[K]{
+					Key:         entry.key,
+					Fingerprint: entry.fingerprint,
+					Distance:    distance,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	return matches
+}
@@ -0,0 +1,54 @@
+package simhash
+
+import (
+	"math/bits"
+
+	"algorithms/hashtable"
+)
+
+type Feature struct {
+	Token  string
+	Weight float64
+}
+
+func Fingerprint(tokens []string) uint64 {
+	features := make([]Feature, len(tokens))
+
+	for i, token := range tokens {
+		features[i] = Feature{Token: token, Weight: 1}
+	}
+
+	return WeightedFingerprint(features)
+}
+
+// WeightedFingerprint sets every bit whose weighted vote across the token
+// hashes is positive, so similar token streams differ in few bits.
+func WeightedFingerprint(features []Feature) uint64 {
+	var votes [64]float64
+
+	for _, feature := range features {
+		hash := hashtable.StringHash(feature.Token)
+
+		for bit := 0; bit < 64; bit++ {
+			if hash&(1<<bit) != 0 {
+				votes[bit] += feature.Weight
+			} else {
+				votes[bit] -= feature.Weight
+			}
+		}
+	}
+
+	var fingerprint uint64
+
+	for bit, vote := range votes {
+		if vote > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+
+	return fingerprint
+}
+
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,62 @@
+package simhash
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSimilarDocumentsHaveCloseFingerprints(t *testing.T) {
+	original := strings.Fields("the quick brown fox jumps over the lazy dog while the cat sleeps on the warm mat by the door")
+	edited := append(append([]string{}, original[:len(original)-1]...), "window")
+	other := strings.Fields("hash tables trade memory for constant time lookups in the average case of uniform hashing")
+
+	near := Distance(Fingerprint(original), Fingerprint(edited))
+	far := Distance(Fingerprint(original), Fingerprint(other))
+
+	if near >= far {
+		t.Errorf("Expected edited document to be closer than an unrelated one, got %d and %d", near, far)
+	}
+
+	if Distance(Fingerprint(original), Fingerprint(original)) != 0 {
+		t.Errorf("Expected identical documents to have distance 0")
+	}
+}
+
+func TestWeightsInfluenceFingerprint(t *testing.T) {
+	a := WeightedFingerprint([]Feature{{"foo", 10}, {"bar", 1}})
+	b := WeightedFingerprint([]Feature{{"foo", 1}, {"bar", 10}})
+
+	if a == b {
+		t.Errorf("Expected different weights to produce different fingerprints")
+	}
+}
+
+func TestIndexFindsFingerprintsWithinDistance(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	index := NewIndex[int](3)
+	fingerprints := make([]uint64, 1000)
+
+	for i := range fingerprints {
+		fingerprints[i] = random.Uint64()
+		index.Add(i, fingerprints[i])
+	}
+
+	// Flip three bits of a known fingerprint
+	query := fingerprints[123] ^ (1 << 5) ^ (1 << 40) ^ (1 << 63)
+	matches := index.Query(query)
+
+	if len(matches) == 0 || matches[0].Key != 123 || matches[0].Distance != 3 {
+		t.Fatalf("Expected to find 123 at distance 3, got %v", matches)
+	}
+
+	for _, match := range matches {
+		if Distance(query, fingerprints[match.Key]) > 3 {
+			t.Errorf("Expected all matches to be within distance 3, got %d", match.Distance)
+		}
+	}
+
+	if len(index.Query(fingerprints[123]^0xF0F0)) != 0 {
+		t.Errorf("Expected no matches beyond the maximum distance")
+	}
+}
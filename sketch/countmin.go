@@ -0,0 +1,121 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"algorithms/hashtable"
+)
+
+// CountMin estimates key frequencies in a depth x width matrix of counters.
+// Every key adds to one counter per row and its estimate is the smallest of
+// them, which never undercounts and, with width ⌈e/ε⌉ and depth ⌈ln 1/δ⌉,
+// overcounts by more than ε times the total with probability at most δ.
+type CountMin[K any] struct {
+	counters []uint64
+	width    uint64
+	depth    int
+	total    uint64
+	hasher   hashtable.Hasher[K]
+}
+
+func NewCountMin[K any](width uint64, depth int) *CountMin[K] {
+	return NewCountMinWithHasher(width, depth, hashtable.DefaultHasher[K]())
+}
+
+// NewCountMinWithError sizes a sketch whose estimates exceed the true count
+// by at most epsilon times the total with probability 1 - delta
+func NewCountMinWithError[K any](epsilon, delta float64) *CountMin[K] {
+	if !(epsilon > 0 && epsilon < 1) || !(delta > 0 && delta < 1) {
+		msg := fmt.Sprintf("invalid error bounds: epsilon %v, delta %v", epsilon, delta)
+		panic(errors.New(msg))
+	}
+
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+
+	return NewCountMin[K](width, depth)
+}
+
+func NewCountMinWithHasher[K any](width uint64, depth int, hasher hashtable.Hasher[K]) *CountMin[K] {
+	if width == 0 || depth <= 0 {
+		msg := fmt.Sprintf("invalid dimensions: width %d, depth %d", width, depth)
+		panic(errors.New(msg))
+	}
+
+	return &CountMin[K]{
+		counters: make([]uint64, width*uint64(depth)),
+		width:    width,
+		depth:    depth,
+		hasher:   hasher,
+	}
+}
+
+// columns derives the counter of every row by double hashing, like a Bloom
+// filter does
+func (c *CountMin[K]) columns(key K) (h1, h2 uint64) {
+	hash := c.hasher.Hash(key)
+	h1 = hashtable.IntHash(hash)
+	h2 = hashtable.IntHash(h1^hash) | 1
+
+	return
+}
+
+func (c *CountMin[K]) Add(key K, delta uint64) {
+	h1, h2 := c.columns(key)
+
+	for row := 0; row < c.depth; row++ {
+		column := (h1 + uint64(row)*h2) % c.width
+		c.counters[uint64(row)*c.width+column] += delta
+	}
+
+	c.total += delta
+}
+
+func (c *CountMin[K]) Estimate(key K) uint64 {
+	h1, h2 := c.columns(key)
+	estimate := uint64(math.MaxUint64)
+
+	for row := 0; row < c.depth; row++ {
+		column := (h1 + uint64(row)*h2) % c.width
+		estimate = min(estimate, c.counters[uint64(row)*c.width+column])
+	}
+
+	return estimate
+}
+
+// Total is the sum of every delta added
+func (c *CountMin[K]) Total() uint64 {
+	return c.total
+}
+
+func (c *CountMin[K]) Width() uint64 {
+	return c.width
+}
+
+func (c *CountMin[K]) Depth() int {
+	return c.depth
+}
+
+// Merge adds the counts of other, which must have the same dimensions and
+// hash keys the same way, as if every key added to it had been added here
+func (c *CountMin[K]) Merge(other *CountMin[K]) error {
+	if c.width != other.width || c.depth != other.depth {
+		msg := fmt.Sprintf("incompatible sketches: %dx%d and %dx%d", c.depth, c.width, other.depth, other.width)
+		return errors.New(msg)
+	}
+
+	for i, count := range other.counters {
+		c.counters[i] += count
+	}
+
+	c.total += other.total
+
+	return nil
+}
+
+func (c *CountMin[K]) Clear() {
+	clear(c.counters)
+	c.total = 0
+}
@@ -0,0 +1,104 @@
+package sketch
+
+import (
+	"fmt"
+	"testing"
+
+	"algorithms/cache"
+)
+
+var _ cache.FrequencyEstimator[string] = (*CountMin[string])(nil)
+
+func TestCountMinDimensions(t *testing.T) {
+	c := NewCountMinWithError[string](0.01, 0.01)
+
+	if c.Width() != 272 || c.Depth() != 5 {
+		t.Errorf("Expected a 5x272 sketch, got %dx%d", c.Depth(), c.Width())
+	}
+}
+
+func TestCountMinEstimate(t *testing.T) {
+	c := NewCountMinWithError[string](0.001, 0.01)
+	exact := make(map[string]uint64)
+
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("key-%d", i%500)
+		delta := uint64(i%7 + 1)
+
+		c.Add(key, delta)
+		exact[key] += delta
+	}
+
+	bound := uint64(0.001 * float64(c.Total()))
+	misses := 0
+
+	for key, count := range exact {
+		estimate := c.Estimate(key)
+
+		if estimate < count {
+			t.Fatalf("Expected %s to be counted at least %d times, got %d", key, count, estimate)
+		}
+
+		if estimate > count+bound {
+			misses++
+		}
+	}
+
+	if misses > 5 {
+		t.Errorf("Expected at most 1%% of estimates beyond the bound, got %d of %d", misses, len(exact))
+	}
+
+	if c.Estimate("absent") > bound {
+		t.Errorf("Expected an absent key to be estimated within the bound, got %d", c.Estimate("absent"))
+	}
+}
+
+func TestCountMinMerge(t *testing.T) {
+	a, b := NewCountMin[int](100, 4), NewCountMin[int](100, 4)
+
+	a.Add(1, 3)
+	b.Add(1, 4)
+	b.Add(2, 1)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if a.Estimate(1) < 7 || a.Estimate(2) < 1 || a.Total() != 8 {
+		t.Errorf("Expected merged counts, got %d, %d and total %d", a.Estimate(1), a.Estimate(2), a.Total())
+	}
+
+	if err := a.Merge(NewCountMin[int](50, 4)); err == nil {
+		t.Errorf("Expected an error for incompatible sketches")
+	}
+
+	a.Clear()
+
+	if a.Estimate(1) != 0 || a.Total() != 0 {
+		t.Errorf("Expected an empty sketch after Clear")
+	}
+}
+
+func TestCountMinInvalidBounds(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	NewCountMinWithError[int](0, 0.5)
+}
+
+func TestCountMinAdmission(t *testing.T) {
+	admission := cache.NewFrequencyAdmission[string](NewCountMin[string](64, 4))
+
+	for i := 0; i < 3; i++ {
+		admission.Record("hot")
+	}
+
+	admission.Record("cold")
+
+	if !admission.Admit("hot", "cold") || admission.Admit("cold", "hot") {
+		t.Errorf("Expected the sketch to favour the frequent key")
+	}
+}
@@ -0,0 +1,94 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"algorithms/hashtable"
+	"algorithms/internal/stream"
+)
+
+var errCorrupt = errors.New("sketch: corrupt sketch")
+
+// WriteTo streams the dimensions, the total and the counters without
+// encoding the sketch whole first. The hasher is not written, so the sketch
+// must be read back by one that hashes keys the same way.
+func (c *CountMin[K]) WriteTo(w io.Writer) (int64, error) {
+	return stream.Write(w, [3]uint64{c.width, uint64(c.depth), c.total}, c.counters)
+}
+
+// ReadFrom replaces the sketch with one written by WriteTo, reading no
+// further than its end. It keeps the hasher, or uses the default one on a
+// zero CountMin, and leaves the sketch unchanged on error.
+func (c *CountMin[K]) ReadFrom(r io.Reader) (int64, error) {
+	source := stream.NewReader(r)
+	width, depth, total, err := readHeader(source)
+
+	if err != nil {
+		return source.Consumed(), err
+	}
+
+	size := width * uint64(depth)
+	counters := make([]uint64, 0, min(size, stream.ChunkWords))
+
+	err = source.Words(size, func(_ int, count uint64) {
+		counters = append(counters, count)
+	})
+
+	if err != nil {
+		return source.Consumed(), err
+	}
+
+	if c.hasher == nil {
+		c.hasher = hashtable.DefaultHasher[K]()
+	}
+
+	c.counters, c.width, c.depth, c.total = counters, width, depth, total
+
+	return source.Consumed(), nil
+}
+
+// MergeFrom adds the counts of the sketch read from r as they stream in,
+// with the same requirements as Merge. If r fails partway the sketch may be
+// left with part of the other sketch's counts added.
+func (c *CountMin[K]) MergeFrom(r io.Reader) error {
+	source := stream.NewReader(r)
+	width, depth, total, err := readHeader(source)
+
+	if err != nil {
+		return err
+	}
+
+	if c.width != width || c.depth != depth {
+		msg := fmt.Sprintf("incompatible sketches: %dx%d and %dx%d", c.depth, c.width, depth, width)
+		return errors.New(msg)
+	}
+
+	err = source.Words(uint64(len(c.counters)), func(i int, count uint64) {
+		c.counters[i] += count
+	})
+
+	if err != nil {
+		return err
+	}
+
+	c.total += total
+
+	return nil
+}
+
+func readHeader(source *stream.Reader) (width uint64, depth int, total uint64, err error) {
+	fields, err := source.Header()
+
+	if err != nil {
+		return
+	}
+
+	if fields[0] == 0 || fields[1] == 0 || fields[1] > math.MaxInt32 || fields[0] > math.MaxUint64/fields[1] {
+		return 0, 0, 0, errCorrupt
+	}
+
+	return fields[0], int(fields[1]), fields[2], nil
+}
@@ -0,0 +1,75 @@
+package sketch
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCountMinWriteToReadFrom(t *testing.T) {
+	c := NewCountMin[int](1000, 4)
+
+	for i := 0; i < 100; i++ {
+		c.Add(i, uint64(i))
+	}
+
+	var buffer bytes.Buffer
+
+	written, err := c.WriteTo(&buffer)
+
+	if err != nil || written != int64(buffer.Len()) {
+		t.Fatalf("Expected %d bytes written without error, got %d and %v", buffer.Len(), written, err)
+	}
+
+	data := bytes.Clone(buffer.Bytes())
+
+	var restored CountMin[int]
+
+	if _, err := restored.ReadFrom(&buffer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if restored.Width() != 1000 || restored.Depth() != 4 || restored.Total() != c.Total() {
+		t.Errorf("Expected dimensions and total to survive a round trip")
+	}
+
+	for i := 0; i < 100; i++ {
+		if restored.Estimate(i) != c.Estimate(i) {
+			t.Fatalf("Expected estimate of %d to be %d, got %d", i, c.Estimate(i), restored.Estimate(i))
+		}
+	}
+
+	if _, err := restored.ReadFrom(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF for truncated data, got %v", err)
+	}
+
+	if _, err := restored.ReadFrom(bytes.NewReader([]byte{0, 4, 0})); err != errCorrupt {
+		t.Errorf("Expected errCorrupt for a zero width, got %v", err)
+	}
+}
+
+func TestCountMinMergeFrom(t *testing.T) {
+	a, b := NewCountMin[int](100, 4), NewCountMin[int](100, 4)
+
+	a.Add(1, 3)
+	b.Add(1, 4)
+	b.Add(2, 1)
+
+	var buffer bytes.Buffer
+
+	b.WriteTo(&buffer)
+
+	if err := a.MergeFrom(&buffer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if a.Estimate(1) < 7 || a.Estimate(2) < 1 || a.Total() != 8 {
+		t.Errorf("Expected merged counts, got %d, %d and total %d", a.Estimate(1), a.Estimate(2), a.Total())
+	}
+
+	NewCountMin[int](50, 4).WriteTo(&buffer)
+
+	if err := a.MergeFrom(&buffer); err == nil {
+		t.Errorf("Expected an error for incompatible sketches")
+	}
+}
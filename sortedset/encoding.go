@@ -0,0 +1,203 @@
+package sortedset
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Node is the nested form of the tree behind a Set, which encoding/json
+// writes as {"value": ..., "left": {...}, "right": {...}}
+type Node[E any] struct {
+	Value E        `json:"value"`
+	Left  *Node[E] `json:"left,omitempty"`
+	Right *Node[E] `json:"right,omitempty"`
+}
+
+// Flat is the parent-array form of the tree behind a Set: the elements in
+// preorder, each with the position of its parent or -1 for the root. Whether
+// a child is on the left or the right follows from the order of the set.
+type Flat[E any] struct {
+	Values  []E   `json:"values"`
+	Parents []int `json:"parents"`
+}
+
+// Tree returns the nested form of the set, nil when it is empty
+func (s Set[E]) Tree() *Node[E] {
+	return toTree(s.root)
+}
+
+func toTree[E any](n *node[E]) *Node[E] {
+	if n == nil {
+		return nil
+	}
+
+	return &Node[E]{Value: n.value, Left: toTree(n.left), Right: toTree(n.right)}
+}
+
+func (s Set[E]) Flat() Flat[E] {
+	flat := Flat[E]{
+		Values:  make([]E, 0, s.Len()),
+		Parents: make([]int, 0, s.Len()),
+	}
+
+	var visit func(n *node[E], parent int)
+
+	visit = func(n *node[E], parent int) {
+		if n == nil {
+			return
+		}
+
+		flat.Values = append(flat.Values, n.value)
+		flat.Parents = append(flat.Parents, parent)
+		position := len(flat.Values) - 1
+
+		visit(n.left, position)
+		visit(n.right, position)
+	}
+
+	visit(s.root, -1)
+
+	return flat
+}
+
+// FromTree rebuilds a set from its nested form. The elements must be in
+// strictly ascending order from left to right. The shape is kept when it
+// satisfies the balance of the set and rebuilt balanced otherwise, so trees
+// written by hand are accepted too.
+func FromTree[E any](root *Node[E], less func(a, b E) bool) (Set[E], error) {
+	values := make([]E, 0)
+	parents := make([]int, 0)
+	lefts := make([]bool, 0)
+
+	type frame struct {
+		node   *Node[E]
+		parent int
+		left   bool
+	}
+
+	stack := []frame{{root, -1, false}}
+
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if top.node == nil {
+			continue
+		}
+
+		values = append(values, top.node.Value)
+		parents = append(parents, top.parent)
+		lefts = append(lefts, top.left)
+		position := len(values) - 1
+
+		stack = append(stack, frame{top.node.Right, position, false}, frame{top.node.Left, position, true})
+	}
+
+	return build(values, parents, lefts, less)
+}
+
+// FromFlat rebuilds a set from its parent-array form, with the same
+// requirements and balancing as FromTree
+func FromFlat[E any](flat Flat[E], less func(a, b E) bool) (Set[E], error) {
+	if len(flat.Parents) != len(flat.Values) {
+		msg := fmt.Sprintf("sortedset: %d values but %d parents", len(flat.Values), len(flat.Parents))
+		return Set[E]{}, errors.New(msg)
+	}
+
+	lefts := make([]bool, len(flat.Values))
+
+	for i := 1; i < len(flat.Values); i++ {
+		if parent := flat.Parents[i]; parent >= 0 && parent < i {
+			lefts[i] = less(flat.Values[i], flat.Values[parent])
+		}
+	}
+
+	return build(flat.Values, flat.Parents, lefts, less)
+}
+
+// build links the elements, given in preorder, under their parents and
+// checks the result is a search tree
+func build[E any](values []E, parents []int, lefts []bool, less func(a, b E) bool) (Set[E], error) {
+	s := Set[E]{less: less}
+
+	if len(values) == 0 {
+		return s, nil
+	}
+
+	nodes := make([]*node[E], len(values))
+
+	for i, value := range values {
+		nodes[i] = &node[E]{value: value, size: 1}
+	}
+
+	for i := range nodes {
+		parent := parents[i]
+
+		if i == 0 && parent != -1 || i > 0 && (parent < 0 || parent >= i) {
+			msg := fmt.Sprintf("sortedset: invalid parent %d of element %d", parent, i)
+			return s, errors.New(msg)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		child, side := &nodes[parent].right, "right"
+
+		if lefts[i] {
+			child, side = &nodes[parent].left, "left"
+		}
+
+		if *child != nil {
+			msg := fmt.Sprintf("sortedset: element %d is a second %s child of element %d", i, side, parent)
+			return s, errors.New(msg)
+		}
+
+		*child = nodes[i]
+	}
+
+	// In preorder every child comes after its parent
+	for i := len(nodes) - 1; i > 0; i-- {
+		nodes[parents[i]].size += nodes[i].size
+	}
+
+	sorted := make([]E, 0, len(values))
+	balanced := true
+	stack := make([]*node[E], 0)
+
+	for n := nodes[0]; n != nil || len(stack) > 0; n = n.right {
+		for ; n != nil; n = n.left {
+			stack = append(stack, n)
+		}
+
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if last := len(sorted) - 1; last >= 0 && !less(sorted[last], n.value) {
+			msg := fmt.Sprintf("sortedset: elements out of order at position %d", len(sorted))
+			return s, errors.New(msg)
+		}
+
+		sorted = append(sorted, n.value)
+
+		if l, r := size(n.left), size(n.right); l+r > 1 && (l > delta*r || r > delta*l) {
+			balanced = false
+		}
+	}
+
+	if balanced {
+		return s.with(nodes[0]), nil
+	}
+
+	return s.with(fromSorted(sorted)), nil
+}
+
+func fromSorted[E any](values []E) *node[E] {
+	if len(values) == 0 {
+		return nil
+	}
+
+	middle := len(values) / 2
+
+	return newNode(values[middle], fromSorted(values[:middle]), fromSorted(values[middle+1:]))
+}
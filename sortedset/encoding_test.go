@@ -0,0 +1,120 @@
+package sortedset
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestTreeRoundTrip(t *testing.T) {
+	s := New(less)
+
+	for i := 0; i < 100; i++ {
+		s = s.Insert((i * 37) % 101)
+	}
+
+	data, err := json.Marshal(s.Tree())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var tree *Node[int]
+
+	if err := json.Unmarshal(data, &tree); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	restored, err := FromTree(tree, less)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fmt.Sprint(restored.Flat()) != fmt.Sprint(s.Flat()) {
+		t.Errorf("Expected the shape to survive a round trip")
+	}
+
+	checkBalanced(t, restored.root)
+
+	if empty, err := FromTree[int](nil, less); err != nil || empty.Len() != 0 {
+		t.Errorf("Expected an empty set, got %d elements and %v", empty.Len(), err)
+	}
+}
+
+func TestFlatRoundTrip(t *testing.T) {
+	s := New(less, 5, 3, 8, 1, 4, 7, 9)
+	flat := s.Flat()
+
+	if fmt.Sprint(flat) != "{[5 3 1 4 8 7 9] [-1 0 1 1 0 4 4]}" {
+		t.Errorf("Expected {[5 3 1 4 8 7 9] [-1 0 1 1 0 4 4]}, got %v", flat)
+	}
+
+	data, _ := json.Marshal(flat)
+
+	var decoded Flat[int]
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	restored, err := FromFlat(decoded, less)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fmt.Sprint(restored.Slice()) != "[1 3 4 5 7 8 9]" || fmt.Sprint(restored.Flat()) != fmt.Sprint(flat) {
+		t.Errorf("Expected the shape to survive a round trip, got %v", restored.Flat())
+	}
+
+	restored = restored.Insert(6)
+
+	if !restored.Contains(6) || restored.Len() != 8 {
+		t.Errorf("Expected a restored set to accept inserts")
+	}
+}
+
+func TestFromFlatRebalances(t *testing.T) {
+	chain := Flat[int]{Values: make([]int, 100), Parents: make([]int, 100)}
+
+	for i := range chain.Values {
+		chain.Values[i] = i
+		chain.Parents[i] = i - 1
+	}
+
+	s, err := FromFlat(chain, less)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	checkBalanced(t, s.root)
+
+	if s.Len() != 100 || !s.Contains(42) {
+		t.Errorf("Expected 100 elements, got %d", s.Len())
+	}
+}
+
+func TestFromInvalidForms(t *testing.T) {
+	invalid := []Flat[int]{
+		{Values: []int{1, 2}, Parents: []int{-1}},
+		{Values: []int{1, 2}, Parents: []int{0, -1}},
+		{Values: []int{1, 2}, Parents: []int{-1, 1}},
+		{Values: []int{1, 2, 3}, Parents: []int{-1, 0, 0}},
+		{Values: []int{1, 1}, Parents: []int{-1, 0}},
+		{Values: []int{5, 3, 6}, Parents: []int{-1, 0, 1}},
+	}
+
+	for _, flat := range invalid {
+		if _, err := FromFlat(flat, less); err == nil {
+			t.Errorf("Expected an error for %v", flat)
+		}
+	}
+
+	tree := &Node[int]{Value: 5, Left: &Node[int]{Value: 7}}
+
+	if _, err := FromTree(tree, less); err == nil {
+		t.Errorf("Expected an error for a left child above its parent")
+	}
+}
@@ -0,0 +1,396 @@
+package sortedset
+
+import "iter"
+
+// Balance parameters of the weight-balanced tree: a subtree may be at most
+// delta times heavier than its sibling, and a rotation is double when the
+// inner grandchild is at least ratio times heavier than the outer one
+const (
+	delta = 3
+	ratio = 2
+)
+
+type node[E any] struct {
+	value       E
+	left, right *node[E]
+	size        int
+}
+
+// Set is an immutable sorted set backed by a weight-balanced tree. Every
+// update returns a new Set that shares all untouched subtrees with the
+// original, so old versions stay valid and cheap to keep. Union, Intersect
+// and Difference split one tree around the root of the other and join the
+// results, taking O(m log(n/m + 1)) for sets of sizes m <= n.
+type Set[E any] struct {
+	root *node[E]
+	less func(a, b E) bool
+}
+
+func New[E any](less func(a, b E) bool, elements ...E) Set[E] {
+	s := Set[E]{less: less}
+
+	for _, element := range elements {
+		s = s.Insert(element)
+	}
+
+	return s
+}
+
+func (s Set[E]) Len() int {
+	return size(s.root)
+}
+
+func (s Set[E]) Contains(element E) bool {
+	for n := s.root; n != nil; {
+		switch {
+		case s.less(element, n.value):
+			n = n.left
+		case s.less(n.value, element):
+			n = n.right
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s Set[E]) Insert(element E) Set[E] {
+	return s.with(s.insert(s.root, element))
+}
+
+func (s Set[E]) Delete(element E) Set[E] {
+	return s.with(s.delete(s.root, element))
+}
+
+// Union keeps the elements of s when both sets hold equal elements
+func (s Set[E]) Union(other Set[E]) Set[E] {
+	return s.with(s.union(s.root, other.root))
+}
+
+func (s Set[E]) Intersect(other Set[E]) Set[E] {
+	return s.with(s.intersect(s.root, other.root))
+}
+
+func (s Set[E]) Difference(other Set[E]) Set[E] {
+	return s.with(s.difference(s.root, other.root))
+}
+
+func (s Set[E]) Min() (element E, found bool) {
+	if s.root == nil {
+		return
+	}
+
+	n := s.root
+
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n.value, true
+}
+
+func (s Set[E]) Max() (element E, found bool) {
+	if s.root == nil {
+		return
+	}
+
+	n := s.root
+
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n.value, true
+}
+
+// Range visits the elements in ascending order until f returns false
+func (s Set[E]) Range(f func(E) bool) {
+	walk(s.root, f)
+}
+
+func (s Set[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		walk(s.root, yield)
+	}
+}
+
+func (s Set[E]) Slice() []E {
+	elements := make([]E, 0, s.Len())
+
+	walk(s.root, func(element E) bool {
+		elements = append(elements, element)
+		return true
+	})
+
+	return elements
+}
+
+func (s Set[E]) with(root *node[E]) Set[E] {
+	return Set[E]{root: root, less: s.less}
+}
+
+func walk[E any](n *node[E], f func(E) bool) bool {
+	for n != nil {
+		if !walk(n.left, f) || !f(n.value) {
+			return false
+		}
+
+		n = n.right
+	}
+
+	return true
+}
+
+func size[E any](n *node[E]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+func newNode[E any](value E, left, right *node[E]) *node[E] {
+	return &node[E]{value: value, left: left, right: right, size: size(left) + size(right) + 1}
+}
+
+// balance builds a node whose subtrees were balanced before a single insert,
+// delete or join changed one of them
+func balance[E any](value E, left, right *node[E]) *node[E] {
+	l, r := size(left), size(right)
+
+	switch {
+	case l+r <= 1:
+		return newNode(value, left, right)
+	case r > delta*l:
+		if size(right.left) < ratio*size(right.right) {
+			return newNode(right.value, newNode(value, left, right.left), right.right)
+		}
+
+		inner := right.left
+
+		return newNode(inner.value, newNode(value, left, inner.left), newNode(right.value, inner.right, right.right))
+	case l > delta*r:
+		if size(left.right) < ratio*size(left.left) {
+			return newNode(left.value, left.left, newNode(value, left.right, right))
+		}
+
+		inner := left.right
+
+		return newNode(inner.value, newNode(left.value, left.left, inner.left), newNode(value, inner.right, right))
+	default:
+		return newNode(value, left, right)
+	}
+}
+
+func (s Set[E]) insert(n *node[E], element E) *node[E] {
+	if n == nil {
+		return newNode(element, nil, nil)
+	}
+
+	switch {
+	case s.less(element, n.value):
+		left := s.insert(n.left, element)
+
+		if left == n.left {
+			return n
+		}
+
+		return balance(n.value, left, n.right)
+	case s.less(n.value, element):
+		right := s.insert(n.right, element)
+
+		if right == n.right {
+			return n
+		}
+
+		return balance(n.value, n.left, right)
+	default:
+		return n
+	}
+}
+
+func (s Set[E]) delete(n *node[E], element E) *node[E] {
+	if n == nil {
+		return nil
+	}
+
+	switch {
+	case s.less(element, n.value):
+		left := s.delete(n.left, element)
+
+		if left == n.left {
+			return n
+		}
+
+		return balance(n.value, left, n.right)
+	case s.less(n.value, element):
+		right := s.delete(n.right, element)
+
+		if right == n.right {
+			return n
+		}
+
+		return balance(n.value, n.left, right)
+	default:
+		return glue(n.left, n.right)
+	}
+}
+
+func insertMin[E any](value E, n *node[E]) *node[E] {
+	if n == nil {
+		return newNode(value, nil, nil)
+	}
+
+	return balance(n.value, insertMin(value, n.left), n.right)
+}
+
+func insertMax[E any](value E, n *node[E]) *node[E] {
+	if n == nil {
+		return newNode(value, nil, nil)
+	}
+
+	return balance(n.value, n.left, insertMax(value, n.right))
+}
+
+func deleteMin[E any](n *node[E]) (E, *node[E]) {
+	if n.left == nil {
+		return n.value, n.right
+	}
+
+	value, left := deleteMin(n.left)
+
+	return value, balance(n.value, left, n.right)
+}
+
+func deleteMax[E any](n *node[E]) (E, *node[E]) {
+	if n.right == nil {
+		return n.value, n.left
+	}
+
+	value, right := deleteMax(n.right)
+
+	return value, balance(n.value, n.left, right)
+}
+
+// glue concatenates two subtrees of the same node, which are balanced
+// against each other
+func glue[E any](left, right *node[E]) *node[E] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.size > right.size:
+		value, left := deleteMax(left)
+		return balance(value, left, right)
+	default:
+		value, right := deleteMin(right)
+		return balance(value, left, right)
+	}
+}
+
+// link joins two trees of any size around a value lying between them
+func link[E any](value E, left, right *node[E]) *node[E] {
+	switch {
+	case left == nil:
+		return insertMin(value, right)
+	case right == nil:
+		return insertMax(value, left)
+	case delta*left.size < right.size:
+		return balance(right.value, link(value, left, right.left), right.right)
+	case delta*right.size < left.size:
+		return balance(left.value, left.left, link(value, left.right, right))
+	default:
+		return newNode(value, left, right)
+	}
+}
+
+// merge joins two trees of any size whose elements are already in order
+func merge[E any](left, right *node[E]) *node[E] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case delta*left.size < right.size:
+		return balance(right.value, merge(left, right.left), right.right)
+	case delta*right.size < left.size:
+		return balance(left.value, left.left, merge(left.right, right))
+	default:
+		return glue(left, right)
+	}
+}
+
+// split divides n into the elements smaller and larger than element
+func (s Set[E]) split(n *node[E], element E) (left *node[E], found bool, right *node[E]) {
+	if n == nil {
+		return nil, false, nil
+	}
+
+	switch {
+	case s.less(element, n.value):
+		left, found, right = s.split(n.left, element)
+		return left, found, link(n.value, right, n.right)
+	case s.less(n.value, element):
+		left, found, right = s.split(n.right, element)
+		return link(n.value, n.left, left), found, right
+	default:
+		return n.left, true, n.right
+	}
+}
+
+func (s Set[E]) union(a, b *node[E]) *node[E] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+
+	l, _, r := s.split(b, a.value)
+	left := s.union(a.left, l)
+	right := s.union(a.right, r)
+
+	if left == a.left && right == a.right {
+		return a
+	}
+
+	return link(a.value, left, right)
+}
+
+func (s Set[E]) intersect(a, b *node[E]) *node[E] {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	l, found, r := s.split(b, a.value)
+	left := s.intersect(a.left, l)
+	right := s.intersect(a.right, r)
+
+	if !found {
+		return merge(left, right)
+	}
+
+	if left == a.left && right == a.right {
+		return a
+	}
+
+	return link(a.value, left, right)
+}
+
+func (s Set[E]) difference(a, b *node[E]) *node[E] {
+	if a == nil || b == nil {
+		return a
+	}
+
+	l, _, r := s.split(a, b.value)
+	left := s.difference(l, b.left)
+	right := s.difference(r, b.right)
+
+	if size(left)+size(right) == a.size {
+		return a
+	}
+
+	return merge(left, right)
+}
@@ -0,0 +1,224 @@
+package sortedset
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func less(a, b int) bool {
+	return a < b
+}
+
+func checkBalanced[E any](t *testing.T, n *node[E]) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+
+	l, r := size(n.left), size(n.right)
+
+	if n.size != l+r+1 {
+		t.Fatalf("Expected size to be %d, got %d", l+r+1, n.size)
+	}
+
+	if l+r > 1 && (l > delta*r || r > delta*l) {
+		t.Fatalf("Expected subtrees of sizes %d and %d to be balanced", l, r)
+	}
+
+	checkBalanced(t, n.left)
+	checkBalanced(t, n.right)
+}
+
+func sorted(elements map[int]bool) []int {
+	keys := make([]int, 0, len(elements))
+
+	for key := range elements {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+
+	return keys
+}
+
+func TestInsertAndDelete(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	set := New(less)
+	expected := map[int]bool{}
+
+	for i := 0; i < 5000; i++ {
+		value := random.Intn(1000)
+
+		if random.Intn(3) == 0 {
+			set = set.Delete(value)
+			delete(expected, value)
+		} else {
+			set = set.Insert(value)
+			expected[value] = true
+		}
+	}
+
+	checkBalanced(t, set.root)
+
+	if fmt.Sprint(set.Slice()) != fmt.Sprint(sorted(expected)) {
+		t.Errorf("Expected %v, got %v", sorted(expected), set.Slice())
+	}
+
+	for i := 0; i < 1000; i++ {
+		if set.Contains(i) != expected[i] {
+			t.Errorf("Expected Contains(%d) to be %v", i, expected[i])
+		}
+	}
+}
+
+func TestVersionsAreImmutable(t *testing.T) {
+	v1 := New(less, 3, 1, 2)
+	v2 := v1.Insert(4).Delete(1)
+
+	if fmt.Sprint(v1.Slice()) != "[1 2 3]" {
+		t.Errorf("Expected [1 2 3], got %v", v1.Slice())
+	}
+
+	if fmt.Sprint(v2.Slice()) != "[2 3 4]" {
+		t.Errorf("Expected [2 3 4], got %v", v2.Slice())
+	}
+
+	if v1.Insert(2).root != v1.root || v1.Delete(7).root != v1.root {
+		t.Errorf("Expected no-op updates to return the same tree")
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+
+	for round := 0; round < 50; round++ {
+		a, b := New(less), New(less)
+		inA, inB := map[int]bool{}, map[int]bool{}
+
+		for i := random.Intn(500); i > 0; i-- {
+			value := random.Intn(800)
+			a = a.Insert(value)
+			inA[value] = true
+		}
+
+		for i := random.Intn(50); i > 0; i-- {
+			value := random.Intn(800)
+			b = b.Insert(value)
+			inB[value] = true
+		}
+
+		union, intersection, difference := map[int]bool{}, map[int]bool{}, map[int]bool{}
+
+		for value := range inA {
+			union[value] = true
+
+			if inB[value] {
+				intersection[value] = true
+			} else {
+				difference[value] = true
+			}
+		}
+
+		for value := range inB {
+			union[value] = true
+		}
+
+		cases := []struct {
+			name     string
+			set      Set[int]
+			expected map[int]bool
+		}{
+			{"Union", a.Union(b), union},
+			{"Union", b.Union(a), union},
+			{"Intersect", a.Intersect(b), intersection},
+			{"Intersect", b.Intersect(a), intersection},
+			{"Difference", a.Difference(b), difference},
+		}
+
+		for _, c := range cases {
+			checkBalanced(t, c.set.root)
+
+			if fmt.Sprint(c.set.Slice()) != fmt.Sprint(sorted(c.expected)) {
+				t.Errorf("Expected %s to be %v, got %v", c.name, sorted(c.expected), c.set.Slice())
+			}
+		}
+	}
+}
+
+func TestSetAlgebraSharesStructure(t *testing.T) {
+	a := New(less)
+
+	for i := 0; i < 1000; i++ {
+		a = a.Insert(i)
+	}
+
+	subset := New(less, 10, 500, 900)
+
+	if a.Union(subset).root != a.root {
+		t.Errorf("Expected union with a subset to return the same tree")
+	}
+
+	if a.Difference(New(less, -1, 2000)).root != a.root {
+		t.Errorf("Expected difference with a disjoint set to return the same tree")
+	}
+
+	if a.Intersect(a).root != a.root {
+		t.Errorf("Expected intersection with itself to return the same tree")
+	}
+}
+
+func TestMinMaxAndRange(t *testing.T) {
+	set := New(less, 5, 3, 9, 1)
+
+	if value, _ := set.Min(); value != 1 {
+		t.Errorf("Expected min to be 1, got %d", value)
+	}
+
+	if value, _ := set.Max(); value != 9 {
+		t.Errorf("Expected max to be 9, got %d", value)
+	}
+
+	if _, found := New(less).Min(); found {
+		t.Errorf("Expected an empty set to have no min")
+	}
+
+	visited := make([]int, 0)
+
+	set.Range(func(value int) bool {
+		visited = append(visited, value)
+		return value < 5
+	})
+
+	if fmt.Sprint(visited) != "[1 3 5]" {
+		t.Errorf("Expected [1 3 5], got %v", visited)
+	}
+
+	count := 0
+
+	for range set.All() {
+		count++
+	}
+
+	if count != set.Len() {
+		t.Errorf("Expected All to yield %d elements, got %d", set.Len(), count)
+	}
+}
+
+func BenchmarkUnionSmallIntoLarge(b *testing.B) {
+	large := New(less)
+
+	for i := 0; i < 1_000_000; i++ {
+		large = large.Insert(i * 2)
+	}
+
+	small := New(less, 1, 1001, 500_001, 999_999)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		large.Union(small)
+	}
+}
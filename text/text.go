@@ -0,0 +1,184 @@
+package text
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"algorithms/iterator"
+)
+
+// Stream is a lazy sequence of tokens. Nothing is tokenized until it is
+// ranged over, and every pass tokenizes the input again. It implements
+// iterator.Iterator, and Slice collects it for the similarity packages that
+// take a []string.
+type Stream struct {
+	each func(yield func(string) bool)
+}
+
+func (s Stream) Range(f func(string) bool) {
+	s.each(f)
+}
+
+func (s Stream) All() iter.Seq[string] {
+	return s.each
+}
+
+func (s Stream) ForEach(f func(string)) {
+	s.each(func(token string) bool {
+		f(token)
+		return true
+	})
+}
+
+func (s Stream) Iter() <-chan string {
+	iterator := make(chan string)
+
+	go func() {
+		s.ForEach(func(token string) {
+			iterator <- token
+		})
+
+		close(iterator)
+	}()
+
+	return iterator
+}
+
+func (s Stream) Map(f func(string) interface{}) iterator.Collection[interface{}] {
+	collection := iterator.NewList[interface{}]()
+
+	s.ForEach(func(token string) {
+		collection.Append(f(token))
+	})
+
+	return collection
+}
+
+func (s Stream) Filter(f func(string) bool) iterator.Collection[string] {
+	collection := iterator.NewList[string]()
+
+	s.ForEach(func(token string) {
+		if f(token) {
+			collection.Append(token)
+		}
+	})
+
+	return collection
+}
+
+func (s Stream) Slice() []string {
+	tokens := make([]string, 0)
+
+	s.ForEach(func(token string) {
+		tokens = append(tokens, token)
+	})
+
+	return tokens
+}
+
+// Words splits s into lower cased runs of letters and digits
+func Words(s string) Stream {
+	return Stream{each: func(yield func(string) bool) {
+		start := -1
+
+		for i, r := range s {
+			inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+			switch {
+			case inWord && start < 0:
+				start = i
+			case !inWord && start >= 0:
+				if !yield(strings.ToLower(s[start:i])) {
+					return
+				}
+
+				start = -1
+			}
+		}
+
+		if start >= 0 {
+			yield(strings.ToLower(s[start:]))
+		}
+	}}
+}
+
+// Runes yields every rune of s as a string
+func Runes(s string) Stream {
+	return Stream{each: func(yield func(string) bool) {
+		for i, r := range s {
+			if !yield(s[i : i+utf8.RuneLen(r)]) {
+				return
+			}
+		}
+	}}
+}
+
+// CharNGrams yields every window of n consecutive runes of s, or s itself
+// when it is shorter
+func CharNGrams(s string, n int) Stream {
+	checkN(n)
+
+	return Stream{each: func(yield func(string) bool) {
+		starts := make([]int, 0, n+1)
+
+		for i := range s {
+			starts = append(starts, i)
+
+			if len(starts) > n {
+				if !yield(s[starts[0]:i]) {
+					return
+				}
+
+				starts = starts[1:]
+			}
+		}
+
+		if len(starts) > 0 {
+			yield(s[starts[0]:])
+		}
+	}}
+}
+
+// NGrams yields every window of n consecutive tokens of src joined by sep,
+// or the joined tokens when there are fewer than n. It keeps only the
+// current window, so src may be a long lazy stream.
+func NGrams(src iterator.Iterator[string], n int, sep string) Stream {
+	checkN(n)
+
+	return Stream{each: func(yield func(string) bool) {
+		window := make([]string, 0, n)
+		complete := false
+
+		src.Range(func(token string) bool {
+			if len(window) == n {
+				copy(window, window[1:])
+				window = window[:n-1]
+			}
+
+			window = append(window, token)
+
+			if len(window) < n {
+				return true
+			}
+
+			complete = true
+
+			return yield(strings.Join(window, sep))
+		})
+
+		if !complete && len(window) > 0 {
+			yield(strings.Join(window, sep))
+		}
+	}}
+}
+
+func checkN(n int) {
+	if n <= 0 {
+		msg := fmt.Sprintf("invalid n-gram size: %d", n)
+		panic(errors.New(msg))
+	}
+}
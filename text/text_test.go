@@ -0,0 +1,110 @@
+package text
+
+import (
+	"fmt"
+	"testing"
+
+	"algorithms/iterator"
+	"algorithms/minhash"
+)
+
+func TestWords(t *testing.T) {
+	words := Words("Hello, World! It's 2024 -- naïve café.").Slice()
+
+	if fmt.Sprint(words) != "[hello world it s 2024 naïve café]" {
+		t.Errorf("Expected [hello world it s 2024 naïve café], got %v", words)
+	}
+
+	if words := Words("  ...  ").Slice(); len(words) != 0 {
+		t.Errorf("Expected no words, got %v", words)
+	}
+}
+
+func TestRunes(t *testing.T) {
+	if runes := Runes("añb").Slice(); fmt.Sprint(runes) != "[a ñ b]" {
+		t.Errorf("Expected [a ñ b], got %v", runes)
+	}
+}
+
+func TestCharNGrams(t *testing.T) {
+	cases := map[string]string{
+		"hello": "[hel ell llo]",
+		"héllo": "[hél éll llo]",
+		"hi":    "[hi]",
+		"abc":   "[abc]",
+		"":      "[]",
+	}
+
+	for input, expected := range cases {
+		if grams := CharNGrams(input, 3).Slice(); fmt.Sprint(grams) != expected {
+			t.Errorf("Expected %q to give %s, got %v", input, expected, grams)
+		}
+	}
+}
+
+func TestNGrams(t *testing.T) {
+	bigrams := NGrams(Words("the quick brown fox"), 2, " ").Slice()
+
+	if fmt.Sprint(bigrams) != "[the quick quick brown brown fox]" {
+		t.Errorf("Expected [the quick quick brown brown fox], got %v", bigrams)
+	}
+
+	list := iterator.NewList[string]()
+	list.Append("a")
+	list.Append("b")
+
+	if grams := NGrams(list, 3, "_").Slice(); fmt.Sprint(grams) != "[a_b]" {
+		t.Errorf("Expected [a_b], got %v", grams)
+	}
+
+	count := 0
+
+	NGrams(Words("a b c d e f"), 2, " ").Range(func(string) bool {
+		count++
+		return count < 2
+	})
+
+	if count != 2 {
+		t.Errorf("Expected to stop after 2 n-grams, got %d", count)
+	}
+}
+
+func TestInvalidNGramSize(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	CharNGrams("abc", 0)
+}
+
+func TestStreamIterator(t *testing.T) {
+	var stream iterator.Iterator[string] = Words("one two three")
+
+	long := stream.Filter(func(word string) bool { return len(word) > 3 })
+
+	if long.Size() != 1 {
+		t.Errorf("Expected 1 long word, got %d", long.Size())
+	}
+
+	lengths := 0
+
+	for word := range stream.Iter() {
+		lengths += len(word)
+	}
+
+	if lengths != 11 {
+		t.Errorf("Expected total length to be 11, got %d", lengths)
+	}
+}
+
+func TestShinglesFeedMinHash(t *testing.T) {
+	a := CharNGrams("the quick brown fox", 3).Slice()
+	b := CharNGrams("the quick brown box", 3).Slice()
+	c := CharNGrams("lorem ipsum dolor", 3).Slice()
+
+	if minhash.Jaccard(a, b) <= minhash.Jaccard(a, c) {
+		t.Errorf("Expected similar strings to share more shingles")
+	}
+}
@@ -0,0 +1,306 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+)
+
+// length and checksum of the payload
+const headerSize = 4 + 4
+
+const MaxRecordSize = 1 << 30
+
+var (
+	ErrCorrupted = errors.New("wal: corrupted record")
+	ErrClosed    = errors.New("wal: log closed")
+)
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+type Record struct {
+	Offset int64
+	Data   []byte
+}
+
+type Log struct {
+	mutex  sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	size   int64
+}
+
+// Open creates the log file if needed and truncates any torn record left at
+// the tail by a crash, so that appends always follow a valid record. A
+// corrupted record followed by more data is not the result of a crash: Open
+// leaves the file as it is and returns ErrCorrupted.
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := validLength(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Log{
+		file:   file,
+		writer: bufio.NewWriter(file),
+		size:   size,
+	}, nil
+}
+
+// validLength returns the length of the file without its torn tail, if any
+func validLength(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	reader := NewReader(file)
+
+	for {
+		_, err := reader.Next()
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return reader.offset, nil
+		}
+
+		if err == ErrCorrupted {
+			return tornTail(file, reader.offset, info.Size())
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// tornTail accepts a corrupted record at offset only when its header makes
+// it reach the end of the file, as the last record written before a crash
+// does when its bytes were not all persisted
+func tornTail(file *os.File, offset, size int64) (int64, error) {
+	header := [headerSize]byte{}
+
+	if _, err := file.ReadAt(header[:], offset); err != nil {
+		return 0, err
+	}
+
+	length := int64(binary.LittleEndian.Uint32(header[0:]))
+
+	if offset+headerSize+length < size {
+		return 0, ErrCorrupted
+	}
+
+	return offset, nil
+}
+
+func (l *Log) Append(data []byte) (offset int64, err error) {
+	if len(data) > MaxRecordSize {
+		msg := fmt.Sprintf("wal: record too large: %d bytes", len(data))
+		return 0, errors.New(msg)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return 0, ErrClosed
+	}
+
+	header := [headerSize]byte{}
+	binary.LittleEndian.PutUint32(header[0:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.Checksum(data, table))
+
+	if _, err = l.writer.Write(header[:]); err != nil {
+		return
+	}
+
+	if _, err = l.writer.Write(data); err != nil {
+		return
+	}
+
+	offset = l.size
+	l.size += int64(headerSize + len(data))
+
+	return
+}
+
+// Sync flushes buffered records and commits them to stable storage
+func (l *Log) Sync() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return ErrClosed
+	}
+
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+
+	return l.file.Sync()
+}
+
+func (l *Log) Size() int64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.size
+}
+
+// Replay calls f for every record in append order, stopping at the first
+// error. The log stays locked while f runs, so f must not call methods of
+// the log, which would deadlock, and appends from other goroutines wait for
+// the replay to finish.
+func (l *Log) Replay(f func(Record) error) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return ErrClosed
+	}
+
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+
+	reader := NewReader(io.NewSectionReader(l.file, 0, l.size))
+
+	for {
+		record, err := reader.Next()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := f(record); err != nil {
+			return err
+		}
+	}
+}
+
+// Truncate discards the record starting at offset and every record after it
+func (l *Log) Truncate(offset int64) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return ErrClosed
+	}
+
+	if offset < 0 || offset > l.size {
+		msg := fmt.Sprintf("wal: truncate offset out of range: %d", offset)
+		return errors.New(msg)
+	}
+
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+
+	if err := l.file.Truncate(offset); err != nil {
+		return err
+	}
+
+	if _, err := l.file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	l.size = offset
+
+	return l.file.Sync()
+}
+
+func (l *Log) Reset() error {
+	return l.Truncate(0)
+}
+
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return ErrClosed
+	}
+
+	err := l.writer.Flush()
+
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	l.file = nil
+
+	return err
+}
+
+type Reader struct {
+	reader *bufio.Reader
+	offset int64
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{reader: bufio.NewReader(r)}
+}
+
+// Next returns io.EOF after the last complete record, io.ErrUnexpectedEOF on a
+// torn record and ErrCorrupted when a checksum does not match.
+func (r *Reader) Next() (Record, error) {
+	header := [headerSize]byte{}
+
+	if _, err := io.ReadFull(r.reader, header[:]); err != nil {
+		return Record{}, err
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:])
+	checksum := binary.LittleEndian.Uint32(header[4:])
+
+	if length > MaxRecordSize {
+		return Record{}, ErrCorrupted
+	}
+
+	data := make([]byte, length)
+
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return Record{}, err
+	}
+
+	if crc32.Checksum(data, table) != checksum {
+		return Record{}, ErrCorrupted
+	}
+
+	record := Record{Offset: r.offset, Data: data}
+	r.offset += int64(headerSize) + int64(length)
+
+	return record, nil
+}
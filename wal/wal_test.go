@@ -0,0 +1,194 @@
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func replayAll(t *testing.T, log *Log) []string {
+	records := make([]string, 0)
+
+	err := log.Replay(func(record Record) error {
+		records = append(records, string(record.Data))
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	return records
+}
+
+func TestAppendAndReplay(t *testing.T) {
+	log, err := Open(filepath.Join(t.TempDir(), "wal"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	defer log.Close()
+
+	for i := 0; i < 10; i++ {
+		log.Append([]byte(fmt.Sprint(i)))
+	}
+
+	records := replayAll(t, log)
+
+	if len(records) != 10 {
+		t.Fatalf("Expected 10 records, got %d", len(records))
+	}
+
+	for i, record := range records {
+		if record != fmt.Sprint(i) {
+			t.Errorf("Expected record to be %d, got %s", i, record)
+		}
+	}
+}
+
+func TestReopenKeepsRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+
+	log, _ := Open(path)
+	log.Append([]byte("foo"))
+	log.Append([]byte("bar"))
+	log.Close()
+
+	log, _ = Open(path)
+	defer log.Close()
+
+	log.Append([]byte("baz"))
+
+	records := replayAll(t, log)
+
+	if len(records) != 3 || records[2] != "baz" {
+		t.Errorf("Expected [foo bar baz], got %v", records)
+	}
+}
+
+func TestOpenTruncatesTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+
+	log, _ := Open(path)
+	log.Append([]byte("foo"))
+	offset, _ := log.Append([]byte("bar"))
+	log.Close()
+
+	// Simulate a crash in the middle of writing the second record
+	os.Truncate(path, offset+headerSize+1)
+
+	log, _ = Open(path)
+	defer log.Close()
+
+	if log.Size() != offset {
+		t.Errorf("Expected size to be %d, got %d", offset, log.Size())
+	}
+
+	records := replayAll(t, log)
+
+	if len(records) != 1 || records[0] != "foo" {
+		t.Errorf("Expected [foo], got %v", records)
+	}
+}
+
+func TestReaderDetectsCorruption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+
+	log, _ := Open(path)
+	log.Append([]byte("foo"))
+	log.Close()
+
+	file, _ := os.OpenFile(path, os.O_RDWR, 0)
+	file.WriteAt([]byte("x"), headerSize)
+	file.Seek(0, 0)
+
+	if _, err := NewReader(file).Next(); err != ErrCorrupted {
+		t.Errorf("Expected ErrCorrupted, got %v", err)
+	}
+
+	file.Close()
+}
+
+func TestTruncate(t *testing.T) {
+	log, _ := Open(filepath.Join(t.TempDir(), "wal"))
+	defer log.Close()
+
+	log.Append([]byte("foo"))
+	offset, _ := log.Append([]byte("bar"))
+	log.Append([]byte("baz"))
+
+	if err := log.Truncate(offset); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	log.Append([]byte("qux"))
+
+	records := replayAll(t, log)
+
+	if len(records) != 2 || records[1] != "qux" {
+		t.Errorf("Expected [foo qux], got %v", records)
+	}
+
+	log.Reset()
+
+	if len(replayAll(t, log)) != 0 {
+		t.Errorf("Expected no records after reset")
+	}
+}
+
+func TestClosedLog(t *testing.T) {
+	log, _ := Open(filepath.Join(t.TempDir(), "wal"))
+	log.Close()
+
+	if _, err := log.Append([]byte("foo")); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestOpenTruncatesCorruptedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+
+	log, _ := Open(path)
+	log.Append([]byte("foo"))
+	offset, _ := log.Append([]byte("bar"))
+	log.Close()
+
+	// The last record reached its full length but not all of its bytes did
+	file, _ := os.OpenFile(path, os.O_RDWR, 0)
+	file.WriteAt([]byte{0}, offset+headerSize+2)
+	file.Close()
+
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	defer log.Close()
+
+	if records := replayAll(t, log); len(records) != 1 || records[0] != "foo" {
+		t.Errorf("Expected [foo], got %v", records)
+	}
+}
+
+func TestOpenRejectsCorruptionBeforeTheTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+
+	log, _ := Open(path)
+	log.Append([]byte("foo"))
+	log.Append([]byte("bar"))
+	size := log.Size()
+	log.Close()
+
+	file, _ := os.OpenFile(path, os.O_RDWR, 0)
+	file.WriteAt([]byte("x"), headerSize)
+	file.Close()
+
+	if _, err := Open(path); err != ErrCorrupted {
+		t.Errorf("Expected ErrCorrupted, got %v", err)
+	}
+
+	if info, _ := os.Stat(path); info.Size() != size {
+		t.Errorf("Expected the file to keep its %d bytes, got %d", size, info.Size())
+	}
+}
@@ -0,0 +1,362 @@
+package window
+
+import (
+	"errors"
+	"iter"
+	"time"
+)
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+type Aggregate[E Number] struct {
+	Count int
+	Sum   E
+	Min   E
+	Max   E
+}
+
+type sample[E Number] struct {
+	value E
+	at    time.Time
+	seq   uint64
+}
+
+type Window[E Number] struct {
+	size         int
+	duration     time.Duration
+	samples      []sample[E]
+	minDeque     []sample[E]
+	maxDeque     []sample[E]
+	sum          E
+	compensation E
+	evicted      int
+	nextSeq      uint64
+	latest       time.Time
+}
+
+func NewCountWindow[E Number](size int) *Window[E] {
+	if size <= 0 {
+		panic(errors.New("window size must be positive"))
+	}
+
+	return &Window[E]{size: size}
+}
+
+func NewTimeWindow[E Number](duration time.Duration) *Window[E] {
+	if duration <= 0 {
+		panic(errors.New("window duration must be positive"))
+	}
+
+	return &Window[E]{duration: duration}
+}
+
+func (w *Window[E]) Add(value E) {
+	w.AddAt(value, time.Now())
+}
+
+// AddAt adds a sample taken at the given time. Eviction from a time window
+// assumes the samples arrive in order, so a sample older than the latest one
+// makes it panic.
+func (w *Window[E]) AddAt(value E, at time.Time) {
+	if w.duration > 0 && at.Before(w.latest) {
+		panic(errors.New("window samples must not go back in time"))
+	}
+
+	w.latest = at
+
+	s := sample[E]{value: value, at: at, seq: w.nextSeq}
+	w.nextSeq++
+
+	w.samples = append(w.samples, s)
+	w.add(value)
+
+	for len(w.minDeque) > 0 && w.minDeque[len(w.minDeque)-1].value >= value {
+		w.minDeque = w.minDeque[:len(w.minDeque)-1]
+	}
+	w.minDeque = append(w.minDeque, s)
+
+	for len(w.maxDeque) > 0 && w.maxDeque[len(w.maxDeque)-1].value <= value {
+		w.maxDeque = w.maxDeque[:len(w.maxDeque)-1]
+	}
+	w.maxDeque = append(w.maxDeque, s)
+
+	if w.size > 0 {
+		for len(w.samples) > w.size {
+			w.evict()
+		}
+	} else {
+		w.AdvanceTo(at)
+	}
+}
+
+func (w *Window[E]) AdvanceTo(now time.Time) {
+	if w.duration == 0 {
+		return
+	}
+
+	cutoff := now.Add(-w.duration)
+
+	for len(w.samples) > 0 && !w.samples[0].at.After(cutoff) {
+		w.evict()
+	}
+}
+
+func (w *Window[E]) evict() {
+	oldest := w.samples[0]
+	w.samples = w.samples[1:]
+	w.add(-oldest.value)
+
+	// Rounding errors still build up over a long stream of floats, so the
+	// sum is rebuilt each time the window has turned over, which costs O(1)
+	// per sample in amortized time
+	if w.evicted++; w.evicted >= len(w.samples) {
+		w.evicted = 0
+		w.sum, w.compensation = 0, 0
+
+		for _, s := range w.samples {
+			w.add(s.value)
+		}
+	}
+
+	if len(w.minDeque) > 0 && w.minDeque[0].seq == oldest.seq {
+		w.minDeque = w.minDeque[1:]
+	}
+
+	if len(w.maxDeque) > 0 && w.maxDeque[0].seq == oldest.seq {
+		w.maxDeque = w.maxDeque[1:]
+	}
+}
+
+// add folds x into the running sum with Neumaier's compensated summation,
+// which keeps the low-order bits a float sum loses. For integers the
+// compensation stays zero.
+func (w *Window[E]) add(x E) {
+	t := w.sum + x
+
+	if abs(w.sum) >= abs(x) {
+		w.compensation += (w.sum - t) + x
+	} else {
+		w.compensation += (x - t) + w.sum
+	}
+
+	w.sum = t
+}
+
+func abs[E Number](x E) E {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
+func (w *Window[E]) Count() int {
+	return len(w.samples)
+}
+
+func (w *Window[E]) Sum() E {
+	return w.sum + w.compensation
+}
+
+func (w *Window[E]) Min() (min E, ok bool) {
+	if len(w.minDeque) == 0 {
+		return
+	}
+
+	return w.minDeque[0].value, true
+}
+
+func (w *Window[E]) Max() (max E, ok bool) {
+	if len(w.maxDeque) == 0 {
+		return
+	}
+
+	return w.maxDeque[0].value, true
+}
+
+func (w *Window[E]) Aggregate() Aggregate[E] {
+	min, _ := w.Min()
+	max, _ := w.Max()
+
+	return Aggregate[E]{
+		Count: w.Count(),
+		Sum:   w.Sum(),
+		Min:   min,
+		Max:   max,
+	}
+}
+
+func Sliding[E Number](source <-chan E, w *Window[E]) <-chan Aggregate[E] {
+	aggregates := make(chan Aggregate[E])
+
+	go func() {
+		for value := range source {
+			w.Add(value)
+			aggregates <- w.Aggregate()
+		}
+
+		close(aggregates)
+	}()
+
+	return aggregates
+}
+
+func Tumbling[E Number](source <-chan E, size int) <-chan Aggregate[E] {
+	if size <= 0 {
+		panic(errors.New("window size must be positive"))
+	}
+
+	aggregates := make(chan Aggregate[E])
+
+	go func() {
+		w := NewCountWindow[E](size)
+
+		for value := range source {
+			w.Add(value)
+
+			if w.Count() == size {
+				aggregates <- w.Aggregate()
+				w = NewCountWindow[E](size)
+			}
+		}
+
+		if w.Count() > 0 {
+			aggregates <- w.Aggregate()
+		}
+
+		close(aggregates)
+	}()
+
+	return aggregates
+}
+
+// TumblingDuration emits the aggregate of the values received during every
+// interval d, including empty ones with a Count of 0, and the partial last
+// one when source is closed
+func TumblingDuration[E Number](source <-chan E, d time.Duration) <-chan Aggregate[E] {
+	if d <= 0 {
+		panic(errors.New("window duration must be positive"))
+	}
+
+	aggregates := make(chan Aggregate[E])
+
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+
+		// Without a size or duration a window keeps every value it is given
+		w := &Window[E]{}
+
+		for {
+			select {
+			case value, ok := <-source:
+				if !ok {
+					if w.Count() > 0 {
+						aggregates <- w.Aggregate()
+					}
+
+					close(aggregates)
+
+					return
+				}
+
+				w.Add(value)
+			case <-ticker.C:
+				aggregates <- w.Aggregate()
+				w = &Window[E]{}
+			}
+		}
+	}()
+
+	return aggregates
+}
+
+// SlidingSeq yields the aggregate of w after each value of source
+func SlidingSeq[E Number](source iter.Seq[E], w *Window[E]) iter.Seq[Aggregate[E]] {
+	return func(yield func(Aggregate[E]) bool) {
+		for value := range source {
+			w.Add(value)
+
+			if !yield(w.Aggregate()) {
+				return
+			}
+		}
+	}
+}
+
+// TumblingSeq yields the aggregate of every size values of source, and of
+// the partial last batch
+func TumblingSeq[E Number](source iter.Seq[E], size int) iter.Seq[Aggregate[E]] {
+	if size <= 0 {
+		panic(errors.New("window size must be positive"))
+	}
+
+	return func(yield func(Aggregate[E]) bool) {
+		w := NewCountWindow[E](size)
+
+		for value := range source {
+			w.Add(value)
+
+			if w.Count() == size {
+				if !yield(w.Aggregate()) {
+					return
+				}
+
+				w = NewCountWindow[E](size)
+			}
+		}
+
+		if w.Count() > 0 {
+			yield(w.Aggregate())
+		}
+	}
+}
+
+// TumblingDurationSeq groups timestamped values into intervals of d starting
+// at the first timestamp, rather than following the clock as TumblingDuration
+// does. Empty intervals yield a Count of 0, and timestamps going back in time
+// make it panic.
+func TumblingDurationSeq[E Number](source iter.Seq2[time.Time, E], d time.Duration) iter.Seq[Aggregate[E]] {
+	if d <= 0 {
+		panic(errors.New("window duration must be positive"))
+	}
+
+	return func(yield func(Aggregate[E]) bool) {
+		var end, latest time.Time
+
+		w := &Window[E]{}
+		started := false
+
+		for at, value := range source {
+			if at.Before(latest) {
+				panic(errors.New("window samples must not go back in time"))
+			}
+
+			latest = at
+
+			if !started {
+				end = at.Add(d)
+				started = true
+			}
+
+			for !at.Before(end) {
+				if !yield(w.Aggregate()) {
+					return
+				}
+
+				w = &Window[E]{}
+				end = end.Add(d)
+			}
+
+			w.Add(value)
+		}
+
+		if w.Count() > 0 {
+			yield(w.Aggregate())
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package window
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCountWindowAggregates(t *testing.T) {
+	w := NewCountWindow[int](3)
+
+	for _, value := range []int{5, 1, 4, 2, 8} {
+		w.Add(value)
+	}
+
+	if w.Count() != 3 {
+		t.Errorf("Expected count to be 3, got %d", w.Count())
+	}
+
+	if w.Sum() != 14 {
+		t.Errorf("Expected sum to be 14, got %d", w.Sum())
+	}
+
+	if min, _ := w.Min(); min != 2 {
+		t.Errorf("Expected min to be 2, got %d", min)
+	}
+
+	if max, _ := w.Max(); max != 8 {
+		t.Errorf("Expected max to be 8, got %d", max)
+	}
+}
+
+func TestTimeWindowEvictsOldSamples(t *testing.T) {
+	w := NewTimeWindow[float64](time.Minute)
+	start := time.Now()
+
+	w.AddAt(10, start)
+	w.AddAt(1, start.Add(30*time.Second))
+	w.AddAt(3, start.Add(70*time.Second))
+
+	if w.Count() != 2 {
+		t.Errorf("Expected count to be 2, got %d", w.Count())
+	}
+
+	if max, _ := w.Max(); max != 3 {
+		t.Errorf("Expected max to be 3, got %f", max)
+	}
+
+	w.AdvanceTo(start.Add(5 * time.Minute))
+
+	if _, ok := w.Min(); ok {
+		t.Errorf("Expected window to be empty")
+	}
+}
+
+func TestTumbling(t *testing.T) {
+	source := make(chan int)
+
+	go func() {
+		for i := 1; i <= 7; i++ {
+			source <- i
+		}
+
+		close(source)
+	}()
+
+	expectedSums := []int{6, 15, 7}
+	counter := 0
+
+	for aggregate := range Tumbling(source, 3) {
+		if aggregate.Sum != expectedSums[counter] {
+			t.Errorf("Expected sum to be %d, got %d", expectedSums[counter], aggregate.Sum)
+		}
+
+		counter++
+	}
+
+	if counter != 3 {
+		t.Errorf("Expected counter to be 3, got %d", counter)
+	}
+}
+
+func TestSliding(t *testing.T) {
+	source := make(chan int)
+
+	go func() {
+		for _, value := range []int{3, 1, 2} {
+			source <- value
+		}
+
+		close(source)
+	}()
+
+	expectedMins := []int{3, 1, 1}
+	counter := 0
+
+	for aggregate := range Sliding(source, NewCountWindow[int](2)) {
+		if aggregate.Min != expectedMins[counter] {
+			t.Errorf("Expected min to be %d, got %d", expectedMins[counter], aggregate.Min)
+		}
+
+		counter++
+	}
+}
+
+func TestFloatSumDoesNotDrift(t *testing.T) {
+	w := NewCountWindow[float64](3)
+
+	for _, value := range []float64{1e16, 1, 1, 1} {
+		w.Add(value)
+	}
+
+	if w.Sum() != 3 {
+		t.Errorf("Expected sum to be 3, got %f", w.Sum())
+	}
+
+	w = NewCountWindow[float64](10)
+
+	for i := 0; i < 1000000; i++ {
+		w.Add(float64(i%7) * 0.1)
+	}
+
+	expected := 0.0
+
+	for i := 1000000 - 10; i < 1000000; i++ {
+		expected += float64(i%7) * 0.1
+	}
+
+	if diff := w.Sum() - expected; diff > 1e-12 || diff < -1e-12 {
+		t.Errorf("Expected sum to be %f, got %f", expected, w.Sum())
+	}
+}
+
+func TestTumblingDuration(t *testing.T) {
+	source := make(chan int)
+	d := 10 * time.Millisecond
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			source <- i
+		}
+
+		time.Sleep(5 * d)
+
+		for i := 6; i <= 10; i++ {
+			source <- i
+		}
+
+		close(source)
+	}()
+
+	count, sum, empty := 0, 0, 0
+
+	for aggregate := range TumblingDuration(source, d) {
+		count += aggregate.Count
+		sum += aggregate.Sum
+
+		if aggregate.Count == 0 {
+			empty++
+		}
+	}
+
+	if count != 10 || sum != 55 {
+		t.Errorf("Expected 10 values summing to 55, got %d and %d", count, sum)
+	}
+
+	if empty == 0 {
+		t.Errorf("Expected the pause to produce empty windows")
+	}
+}
+
+func TestTimeWindowRejectsOutOfOrderSamples(t *testing.T) {
+	w := NewTimeWindow[int](time.Minute)
+	start := time.Now()
+
+	w.AddAt(1, start)
+	w.AddAt(2, start)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	w.AddAt(3, start.Add(-time.Second))
+}
+
+func TestSlidingSeq(t *testing.T) {
+	sums := make([]int, 0)
+
+	for aggregate := range SlidingSeq(slices.Values([]int{1, 2, 3, 4}), NewCountWindow[int](2)) {
+		sums = append(sums, aggregate.Sum)
+	}
+
+	if fmt.Sprint(sums) != "[1 3 5 7]" {
+		t.Errorf("Expected sums to be [1 3 5 7], got %v", sums)
+	}
+}
+
+func TestTumblingSeq(t *testing.T) {
+	sums := make([]int, 0)
+
+	for aggregate := range TumblingSeq(slices.Values([]int{1, 2, 3, 4, 5, 6, 7}), 3) {
+		sums = append(sums, aggregate.Sum)
+	}
+
+	if fmt.Sprint(sums) != "[6 15 7]" {
+		t.Errorf("Expected sums to be [6 15 7], got %v", sums)
+	}
+
+	for range TumblingSeq(slices.Values([]int{1, 2, 3, 4}), 2) {
+		break
+	}
+}
+
+func TestTumblingDurationSeq(t *testing.T) {
+	start := time.Now()
+	offsets := []time.Duration{0, time.Second, 9 * time.Second, 10 * time.Second, 35 * time.Second}
+
+	source := func(yield func(time.Time, int) bool) {
+		for i, offset := range offsets {
+			if !yield(start.Add(offset), i+1) {
+				return
+			}
+		}
+	}
+
+	counts := make([]int, 0)
+
+	for aggregate := range TumblingDurationSeq(source, 10*time.Second) {
+		counts = append(counts, aggregate.Count)
+	}
+
+	if fmt.Sprint(counts) != "[3 1 0 1]" {
+		t.Errorf("Expected counts to be [3 1 0 1], got %v", counts)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	offsets = []time.Duration{time.Second, 0}
+
+	for range TumblingDurationSeq(source, 10*time.Second) {
+	}
+}
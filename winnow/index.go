@@ -0,0 +1,107 @@
+package winnow
+
+import (
+	"sort"
+
+	"algorithms/hashtable"
+)
+
+type posting[D comparable] struct {
+	document D
+	position int
+}
+
+type Match struct {
+	Query    int
+	Document int
+}
+
+// Report describes how much of a query is found in one indexed document.
+// Similarity is the share of the query's distinct fingerprints the document
+// contains, so a query copied entirely from a longer document scores 1.
+type Report[D comparable] struct {
+	Document   D
+	Shared     int
+	Similarity float64
+	Matches    []Match
+}
+
+type Index[D comparable] struct {
+	postings *hashtable.HashTable[uint64, []posting[D]]
+	size     int
+}
+
+func NewIndex[D comparable]() *Index[D] {
+	return &Index[D]{
+		postings: hashtable.NewHashTable[uint64, []posting[D]](),
+	}
+}
+
+func (x *Index[D]) Add(document D, fingerprints []Fingerprint) {
+	for _, fingerprint := range fingerprints {
+		postings, _ := x.postings.TryGet(fingerprint.Hash)
+		x.postings.Insert(fingerprint.Hash, append(postings, posting[D]{document: document, position: fingerprint.Position}))
+	}
+
+	x.size++
+}
+
+func (x *Index[D]) Len() int {
+	return x.size
+}
+
+// Query reports every indexed document sharing at least minShared distinct
+// fingerprints with the query, most similar first.
+func (x *Index[D]) Query(fingerprints []Fingerprint, minShared int) []Report[D] {
+	query := distinct(fingerprints)
+	reports := hashtable.NewHashTable[D, *Report[D]]()
+	order := make([]*Report[D], 0)
+
+	for hash, position := range query.All() {
+		postings, found := x.postings.TryGet(hash)
+
+		if !found {
+			continue
+		}
+
+		seen := hashtable.NewHashTable[D, bool]()
+
+		for _, p := range postings {
+			report := reports.ComputeIfAbsent(p.document, func(document D) *Report[D] {
+				report := &Report[D]{Document: document}
+				order = append(order, report)
+
+				return report
+			})
+
+			report.Matches = append(report.Matches, Match{Query: position, Document: p.position})
+
+			if !seen.Contains(p.document) {
+				seen.Insert(p.document, true)
+				report.Shared++
+			}
+		}
+	}
+
+	results := make([]Report[D], 0, len(order))
+
+	for _, report := range order {
+		if report.Shared < minShared {
+			continue
+		}
+
+		report.Similarity = float64(report.Shared) / float64(query.Size())
+
+		sort.Slice(report.Matches, func(i, j int) bool {
+			return report.Matches[i].Query < report.Matches[j].Query
+		})
+
+		results = append(results, *report)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Similarity > results[j].Similarity
+	})
+
+	return results
+}
@@ -0,0 +1,138 @@
+package winnow
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithms/hashtable"
+)
+
+// base is the multiplier of the polynomial rolling hash over token hashes
+const base = 0x100000001b3
+
+type Fingerprint struct {
+	Hash     uint64
+	Position int
+}
+
+// Winnower hashes every run of K tokens with a rolling polynomial hash and
+// keeps the minimum of each Window consecutive hashes. Any match of at least
+// K+Window-1 tokens is then guaranteed to share a fingerprint.
+type Winnower struct {
+	k      int
+	window int
+	power  uint64
+}
+
+func New(k, window int) *Winnower {
+	if k <= 0 || window <= 0 {
+		msg := fmt.Sprintf("invalid k-gram size %d or window %d", k, window)
+		panic(errors.New(msg))
+	}
+
+	power := uint64(1)
+
+	for i := 1; i < k; i++ {
+		power *= base
+	}
+
+	return &Winnower{k: k, window: window, power: power}
+}
+
+// kgrams returns the rolling hash of every k-gram, indexed by its first token
+func (w *Winnower) kgrams(tokens []string) []uint64 {
+	if len(tokens) < w.k {
+		return nil
+	}
+
+	hashes := make([]uint64, 0, len(tokens)-w.k+1)
+	tokenHashes := make([]uint64, len(tokens))
+	rolling := uint64(0)
+
+	for i, token := range tokens {
+		tokenHashes[i] = hashtable.StringHash(token)
+
+		if i >= w.k {
+			rolling -= tokenHashes[i-w.k] * w.power
+		}
+
+		rolling = rolling*base + tokenHashes[i]
+
+		if i >= w.k-1 {
+			hashes = append(hashes, rolling)
+		}
+	}
+
+	return hashes
+}
+
+// Fingerprints selects the rightmost minimum of every window, recording it
+// once per run where it stays selected. Streams shorter than a window keep
+// their single minimum.
+func (w *Winnower) Fingerprints(tokens []string) []Fingerprint {
+	hashes := w.kgrams(tokens)
+	fingerprints := make([]Fingerprint, 0)
+
+	if len(hashes) == 0 {
+		return fingerprints
+	}
+
+	// Monotonic deque of positions with increasing hashes
+	deque := make([]int, 0, w.window)
+	last := -1
+
+	for i, hash := range hashes {
+		for len(deque) > 0 && hashes[deque[len(deque)-1]] >= hash {
+			deque = deque[:len(deque)-1]
+		}
+
+		deque = append(deque, i)
+
+		if deque[0] <= i-w.window {
+			deque = deque[1:]
+		}
+
+		if i < w.window-1 && i < len(hashes)-1 {
+			continue
+		}
+
+		if selected := deque[0]; selected != last {
+			fingerprints = append(fingerprints, Fingerprint{Hash: hashes[selected], Position: selected})
+			last = selected
+		}
+	}
+
+	return fingerprints
+}
+
+// Similarity is the Jaccard similarity of the distinct fingerprint hashes
+func Similarity(a, b []Fingerprint) float64 {
+	left := distinct(a)
+	right := distinct(b)
+
+	if left.Size() == 0 && right.Size() == 0 {
+		return 1
+	}
+
+	shared := 0
+
+	for hash := range left.Keys() {
+		if right.Contains(hash) {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(int(left.Size())+int(right.Size())-shared)
+}
+
+func distinct(fingerprints []Fingerprint) *hashtable.HashTable[uint64, int] {
+	hashes := hashtable.NewHashTableWithOptions[uint64, int](hashtable.WithCapacity(uint32(len(fingerprints))))
+
+	for _, fingerprint := range fingerprints {
+		hashes.GetOrInsert(fingerprint.Hash, func() int {
+			return fingerprint.Position
+		})
+	}
+
+	return hashes
+}
@@ -0,0 +1,105 @@
+package winnow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFingerprintsCoverEveryWindow(t *testing.T) {
+	winnower := New(3, 4)
+	tokens := strings.Fields("a b c d e f g h i j k l m n o p q r s t")
+
+	fingerprints := winnower.Fingerprints(tokens)
+	kgrams := len(tokens) - 3 + 1
+
+	for start := 0; start+4 <= kgrams; start++ {
+		covered := false
+
+		for _, fingerprint := range fingerprints {
+			if fingerprint.Position >= start && fingerprint.Position < start+4 {
+				covered = true
+			}
+		}
+
+		if !covered {
+			t.Errorf("Expected window starting at %d to hold a fingerprint", start)
+		}
+	}
+
+	for i := 1; i < len(fingerprints); i++ {
+		if fingerprints[i].Position <= fingerprints[i-1].Position {
+			t.Errorf("Expected positions to increase, got %v", fingerprints)
+		}
+	}
+}
+
+func TestFingerprintsOfShortStreams(t *testing.T) {
+	winnower := New(3, 4)
+
+	if fingerprints := winnower.Fingerprints([]string{"a", "b"}); len(fingerprints) != 0 {
+		t.Errorf("Expected no fingerprints, got %v", fingerprints)
+	}
+
+	if fingerprints := winnower.Fingerprints([]string{"a", "b", "c", "d"}); len(fingerprints) != 1 {
+		t.Errorf("Expected a single fingerprint, got %v", fingerprints)
+	}
+}
+
+func TestSharedPassageIsDetected(t *testing.T) {
+	winnower := New(4, 4)
+	passage := "the quick brown fox jumps over the lazy dog near the river bank"
+
+	a := winnower.Fingerprints(strings.Fields("once upon a time " + passage + " and then it rained"))
+	b := winnower.Fingerprints(strings.Fields("it is said that " + passage + " every single morning"))
+	c := winnower.Fingerprints(strings.Fields("completely unrelated words make up this other sentence here today"))
+
+	if Similarity(a, b) == 0 {
+		t.Errorf("Expected documents sharing a passage to be similar")
+	}
+
+	if Similarity(a, c) != 0 {
+		t.Errorf("Expected unrelated documents not to be similar, got %v", Similarity(a, c))
+	}
+
+	if Similarity(a, a) != 1 {
+		t.Errorf("Expected a document to be identical to itself, got %v", Similarity(a, a))
+	}
+}
+
+func TestIndexQuery(t *testing.T) {
+	winnower := New(3, 3)
+	index := NewIndex[string]()
+
+	documents := map[string]string{
+		"original": "to be or not to be that is the question whether tis nobler in the mind to suffer",
+		"partial":  "whether tis nobler in the mind to suffer the slings and arrows",
+		"other":    "all the world is a stage and all the men and women merely players",
+	}
+
+	for name, text := range documents {
+		index.Add(name, winnower.Fingerprints(strings.Fields(text)))
+	}
+
+	if index.Len() != 3 {
+		t.Errorf("Expected 3 documents, got %d", index.Len())
+	}
+
+	query := winnower.Fingerprints(strings.Fields(documents["original"]))
+	reports := index.Query(query, 1)
+
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 matching documents, got %v", reports)
+	}
+
+	if reports[0].Document != "original" || reports[0].Similarity != 1 {
+		t.Errorf("Expected the original to match fully first, got %+v", reports[0])
+	}
+
+	if reports[1].Document != "partial" || reports[1].Shared == 0 || len(reports[1].Matches) == 0 {
+		t.Errorf("Expected the partial copy second, got %+v", reports[1])
+	}
+
+	if reports := index.Query(query, 1000); len(reports) != 0 {
+		t.Errorf("Expected no document above the threshold, got %v", reports)
+	}
+}
@@ -0,0 +1,46 @@
+package zobrist
+
+import (
+	"math/rand"
+
+	"algorithms/hashtable"
+)
+
+type Table[F comparable] struct {
+	keys *hashtable.HashTable[F, uint64]
+}
+
+func NewTable[F comparable](features []F, seed int64) *Table[F] {
+	random := rand.New(rand.NewSource(seed))
+	keys := hashtable.NewHashTable[F, uint64]()
+
+	for _, feature := range features {
+		keys.Insert(feature, random.Uint64())
+	}
+
+	return &Table[F]{keys: keys}
+}
+
+func (t *Table[F]) Key(feature F) uint64 {
+	return t.keys.Get(feature)
+}
+
+func (t *Table[F]) Hash(features []F) (hash uint64) {
+	for _, feature := range features {
+		hash ^= t.Key(feature)
+	}
+
+	return
+}
+
+func (t *Table[F]) Toggle(hash uint64, feature F) uint64 {
+	return hash ^ t.Key(feature)
+}
+
+func (t *Table[F]) Replace(hash uint64, removed, added F) uint64 {
+	return hash ^ t.Key(removed) ^ t.Key(added)
+}
+
+func (t *Table[F]) Size() uint32 {
+	return t.keys.Size()
+}
@@ -0,0 +1,63 @@
+package zobrist
+
+import "testing"
+
+type square struct {
+	Piece  string
+	Square int
+}
+
+func boardFeatures() []square {
+	features := make([]square, 0, 2*64)
+
+	for _, piece := range []string{"king", "queen"} {
+		for i := 0; i < 64; i++ {
+			features = append(features, square{Piece: piece, Square: i})
+		}
+	}
+
+	return features
+}
+
+func TestHashIsIndependentOfFeatureOrder(t *testing.T) {
+	table := NewTable(boardFeatures(), 1)
+
+	a := table.Hash([]square{{"king", 4}, {"queen", 3}})
+	b := table.Hash([]square{{"queen", 3}, {"king", 4}})
+
+	if a != b {
+		t.Errorf("Expected hashes to be equal, got %d and %d", a, b)
+	}
+}
+
+func TestIncrementalUpdateMatchesFullHash(t *testing.T) {
+	table := NewTable(boardFeatures(), 1)
+
+	hash := table.Hash([]square{{"king", 4}, {"queen", 3}})
+	hash = table.Replace(hash, square{"king", 4}, square{"king", 12})
+
+	expected := table.Hash([]square{{"king", 12}, {"queen", 3}})
+
+	if hash != expected {
+		t.Errorf("Expected hash to be %d, got %d", expected, hash)
+	}
+
+	hash = table.Toggle(hash, square{"queen", 3})
+
+	if hash != table.Key(square{"king", 12}) {
+		t.Errorf("Expected toggle to remove the queen from the hash")
+	}
+}
+
+func TestSameSeedGeneratesSameKeys(t *testing.T) {
+	a := NewTable(boardFeatures(), 7)
+	b := NewTable(boardFeatures(), 7)
+
+	if a.Key(square{"queen", 10}) != b.Key(square{"queen", 10}) {
+		t.Errorf("Expected keys generated with the same seed to be equal")
+	}
+
+	if a.Size() != 128 {
+		t.Errorf("Expected size to be 128, got %d", a.Size())
+	}
+}